@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
 
 	rspec "github.com/opencontainers/runtime-spec/specs-go"
@@ -27,6 +28,7 @@ type Cgroup interface {
 	GetMemoryData(pid int, cgPath string) (*rspec.LinuxMemory, error)
 	GetNetworkData(pid int, cgPath string) (*rspec.LinuxNetwork, error)
 	GetPidsData(pid int, cgPath string) (*rspec.LinuxPids, error)
+	GetRdmaData(pid int, cgPath string) (map[string]rspec.LinuxRdma, error)
 }
 
 // FindCgroup gets cgroup root mountpoint
@@ -76,6 +78,47 @@ func FindCgroup() (Cgroup, error) {
 	return nil, fmt.Errorf("cgroup is not found")
 }
 
+// parseRdmaMax parses the content of rdma.max, which is the same for
+// cgroup v1 and v2:
+//
+//	mlx4_0 hca_handle=2 hca_object=2000
+//	ocrdma1 hca_handle=3 hca_object=max
+func parseRdmaMax(contents string) (map[string]rspec.LinuxRdma, error) {
+	rdma := make(map[string]rspec.LinuxRdma)
+	for _, line := range strings.Split(strings.TrimSpace(contents), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		var limits rspec.LinuxRdma
+		for _, field := range fields[1:] {
+			kv := strings.SplitN(field, "=", 2)
+			if len(kv) != 2 {
+				return nil, fmt.Errorf("invalid rdma.max entry %q", line)
+			}
+			if kv[1] == "max" {
+				continue
+			}
+			value, err := strconv.ParseUint(kv[1], 10, 32)
+			if err != nil {
+				return nil, err
+			}
+			limit := uint32(value)
+			switch kv[0] {
+			case "hca_handle":
+				limits.HcaHandles = &limit
+			case "hca_object":
+				limits.HcaObjects = &limit
+			default:
+				return nil, fmt.Errorf("unknown RDMA resource %q in rdma.max", kv[0])
+			}
+		}
+		rdma[fields[0]] = limits
+	}
+	return rdma, nil
+}
+
 // GetSubsystemPath gets path of subsystem
 func GetSubsystemPath(pid int, subsystem string) (string, error) {
 	contents, err := os.ReadFile(fmt.Sprintf("/proc/%d/cgroup", pid))
@@ -268,6 +268,44 @@ func (cg *CgroupV1) GetCPUData(pid int, cgPath string) (*rspec.LinuxCPU, error)
 			lc.Period = &period
 		}
 	}
+	// cpu.cfs_burst_us and cpu.idle only exist since Linux 5.14 and 5.15,
+	// leave them unset if the kernel does not provide them.
+	names = []string{"cfs_burst_us", "idle"}
+	for i, name := range names {
+		fileName := strings.Join([]string{"cpu", name}, ".")
+		filePath := filepath.Join(cg.MountPath, "cpu", cgPath, fileName)
+		if !filepath.IsAbs(cgPath) {
+			subPath, err := GetSubsystemPath(pid, "cpu")
+			if err != nil {
+				return nil, err
+			}
+			filePath = filepath.Join(cg.MountPath, "cpu", subPath, fileName)
+		}
+		contents, err := os.ReadFile(filePath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		switch i {
+		case 0:
+			res, err := strconv.ParseUint(strings.TrimSpace(string(contents)), 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			burst := res
+			lc.Burst = &burst
+		case 1:
+			res, err := strconv.ParseInt(strings.TrimSpace(string(contents)), 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			idle := res
+			lc.Idle = &idle
+		}
+	}
+
 	// CONFIG_RT_GROUP_SCHED may be not set
 	// Can always get rt data from /proc
 	contents, err := os.ReadFile("/proc/sys/kernel/sched_rt_period_us")
@@ -489,7 +527,7 @@ func (cg *CgroupV1) GetMemoryData(pid int, cgPath string) (*rspec.LinuxMemory, e
 		}
 	}
 	lm := &rspec.LinuxMemory{}
-	names := []string{"limit_in_bytes", "soft_limit_in_bytes", "memsw.limit_in_bytes", "kmem.limit_in_bytes", "kmem.tcp.limit_in_bytes", "swappiness", "oom_control"}
+	names := []string{"limit_in_bytes", "soft_limit_in_bytes", "memsw.limit_in_bytes", "kmem.limit_in_bytes", "kmem.tcp.limit_in_bytes", "swappiness", "oom_control", "use_hierarchy"}
 	for i, name := range names {
 		fileName := strings.Join([]string{"memory", name}, ".")
 		filePath := filepath.Join(cg.MountPath, "memory", cgPath, fileName)
@@ -566,6 +604,13 @@ func (cg *CgroupV1) GetMemoryData(pid int, cgPath string) (*rspec.LinuxMemory, e
 				oom = true
 			}
 			lm.DisableOOMKiller = &oom
+		case 7:
+			res, err := strconv.ParseInt(strings.TrimSpace(string(contents)), 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			useHierarchy := res == 1
+			lm.UseHierarchy = &useHierarchy
 		}
 	}
 
@@ -688,3 +733,38 @@ func (cg *CgroupV1) GetPidsData(pid int, cgPath string) (*rspec.LinuxPids, error
 
 	return lp, nil
 }
+
+// GetRdmaData gets cgroup rdma data
+func (cg *CgroupV1) GetRdmaData(pid int, cgPath string) (map[string]rspec.LinuxRdma, error) {
+	if filepath.IsAbs(cgPath) {
+		path := filepath.Join(cg.MountPath, "rdma", cgPath)
+		if _, err := os.Stat(path); err != nil {
+			if os.IsNotExist(err) {
+				return nil, specerror.NewError(specerror.CgroupsAbsPathRelToMount, fmt.Errorf("In the case of an absolute path, the runtime MUST take the path to be relative to the cgroups mount point"), rspec.Version)
+			}
+			return nil, err
+		}
+	}
+	fileName := strings.Join([]string{"rdma", "max"}, ".")
+	filePath := filepath.Join(cg.MountPath, "rdma", cgPath, fileName)
+	if !filepath.IsAbs(cgPath) {
+		subPath, err := GetSubsystemPath(pid, "rdma")
+		if err != nil {
+			return nil, err
+		}
+		if !strings.Contains(subPath, cgPath) {
+			return nil, fmt.Errorf("cgroup subsystem %s is not mounted as expected", "rdma")
+		}
+		filePath = filepath.Join(cg.MountPath, "rdma", subPath, fileName)
+	}
+	contents, err := os.ReadFile(filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, specerror.NewError(specerror.CgroupsPathAttach, fmt.Errorf("The runtime MUST consistently attach to the same place in the cgroups hierarchy given the same value of `cgroupsPath`"), rspec.Version)
+		}
+
+		return nil, err
+	}
+
+	return parseRdmaMax(string(contents))
+}
@@ -0,0 +1,98 @@
+package cgroups
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/opencontainers/runtime-tools/specerror"
+)
+
+func uint32Ptr(v uint32) *uint32 {
+	return &v
+}
+
+func TestGetRdmaData(t *testing.T) {
+	mountPath := t.TempDir()
+	cg := &CgroupV1{MountPath: mountPath}
+
+	_, err := cg.GetRdmaData(0, AbsCgroupPath)
+	assert.Equal(t, specerror.CgroupsAbsPathRelToMount, err.(*specerror.Error).Code)
+
+	dir := filepath.Join(mountPath, "rdma", AbsCgroupPath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	_, err = cg.GetRdmaData(0, AbsCgroupPath)
+	assert.Equal(t, specerror.CgroupsPathAttach, err.(*specerror.Error).Code)
+
+	content := "mlx4_0 hca_handle=2 hca_object=2000\nocrdma1 hca_handle=3 hca_object=max\nmlx5_1 hca_handle=max hca_object=max\n"
+	if err := os.WriteFile(filepath.Join(dir, "rdma.max"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	rdma, err := cg.GetRdmaData(0, AbsCgroupPath)
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]rspec.LinuxRdma{
+			"mlx4_0":  {HcaHandles: uint32Ptr(2), HcaObjects: uint32Ptr(2000)},
+			"ocrdma1": {HcaHandles: uint32Ptr(3)},
+			"mlx5_1":  {},
+		}, rdma)
+	}
+}
+
+func TestParseRdmaMax(t *testing.T) {
+	for _, content := range []string{
+		"mlx4_0 hca_handle",
+		"mlx4_0 hca_handle=-1",
+		"mlx4_0 hca_handle=2 unknown=3",
+	} {
+		_, err := parseRdmaMax(content)
+		assert.Error(t, err, content)
+	}
+
+	rdma, err := parseRdmaMax("")
+	assert.NoError(t, err)
+	assert.Empty(t, rdma)
+}
+
+func TestGetMemoryData(t *testing.T) {
+	mountPath := t.TempDir()
+	cg := &CgroupV1{MountPath: mountPath}
+
+	dir := filepath.Join(mountPath, "memory", AbsCgroupPath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"memory.limit_in_bytes":          "50593792\n",
+		"memory.soft_limit_in_bytes":     "50593792\n",
+		"memory.memsw.limit_in_bytes":    "50593792\n",
+		"memory.kmem.limit_in_bytes":     "50593792\n",
+		"memory.kmem.tcp.limit_in_bytes": "50593792\n",
+		"memory.swappiness":              "10\n",
+		"memory.oom_control":             "oom_kill_disable 1\nunder_oom 0\n",
+		"memory.use_hierarchy":           "1\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	lm, err := cg.GetMemoryData(0, AbsCgroupPath)
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(50593792), *lm.Limit)
+		assert.Equal(t, uint64(10), *lm.Swappiness)
+		assert.True(t, *lm.DisableOOMKiller)
+		assert.True(t, *lm.UseHierarchy)
+	}
+
+	if err := os.Remove(filepath.Join(dir, "memory.use_hierarchy")); err != nil {
+		t.Fatal(err)
+	}
+	_, err = cg.GetMemoryData(0, AbsCgroupPath)
+	assert.Equal(t, specerror.CgroupsPathAttach, err.(*specerror.Error).Code)
+}
@@ -45,3 +45,8 @@ func GetNetworkData(pid int, cgPath string) (*rspec.LinuxNetwork, error) {
 func GetPidsData(pid int, cgPath string) (*rspec.LinuxPids, error) {
 	return nil, fmt.Errorf("unimplemented yet")
 }
+
+// GetRdmaData gets cgroup rdma data
+func (cg *CgroupV2) GetRdmaData(pid int, cgPath string) (map[string]rspec.LinuxRdma, error) {
+	return nil, fmt.Errorf("unimplemented yet")
+}
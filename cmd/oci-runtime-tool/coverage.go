@@ -0,0 +1,313 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/opencontainers/runtime-tools/specerror"
+	"github.com/urfave/cli"
+)
+
+const specerrorImportPath = "github.com/opencontainers/runtime-tools/specerror"
+
+// coverageLayers are the layers checking spec requirements, with the
+// source directories implementing them.
+var coverageLayers = []struct {
+	Name string
+	Dir  string
+}{
+	{"static", "validate"},
+	{"in-container", "cmd/runtimetest"},
+	{"lifecycle", "validation"},
+}
+
+var coverageFlags = []cli.Flag{
+	cli.StringFlag{Name: "source", Value: ".", Usage: "path to the runtime-tools source tree"},
+	cli.StringFlag{Name: "format", Value: "text", Usage: "output format (text, csv, or json)"},
+	cli.BoolFlag{Name: "uncovered", Usage: "only list the requirements which no layer checks"},
+}
+
+var coverageCommand = cli.Command{
+	Name:   "coverage",
+	Usage:  "report which spec requirements are checked by the validation layers",
+	Flags:  coverageFlags,
+	Before: before,
+	Action: func(context *cli.Context) error {
+		source := context.String("source")
+		requirements, err := scanCoverage(source)
+		if err != nil {
+			return err
+		}
+		if context.Bool("uncovered") {
+			var uncovered []*requirementCoverage
+			for _, r := range requirements {
+				if len(r.Coverage) == 0 {
+					uncovered = append(uncovered, r)
+				}
+			}
+			requirements = uncovered
+		}
+
+		switch context.String("format") {
+		case "text":
+			return writeCoverageText(os.Stdout, requirements)
+		case "csv":
+			return writeCoverageCSV(os.Stdout, requirements)
+		case "json":
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "\t")
+			return encoder.Encode(requirements)
+		default:
+			return fmt.Errorf("unknown coverage format %q", context.String("format"))
+		}
+	},
+}
+
+// requirementCoverage is a row of the coverage matrix.
+type requirementCoverage struct {
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	Level       string `json:"level"`
+	Reference   string `json:"reference"`
+	Requirement string `json:"requirement,omitempty"`
+	// Coverage maps layer names to the files referencing the code.
+	Coverage map[string][]string `json:"coverage"`
+}
+
+// scanCoverage lists the registered requirements with the files of each
+// layer referencing them in the source tree.
+func scanCoverage(source string) ([]*requirementCoverage, error) {
+	scan, err := scanSources(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var matrix []*requirementCoverage
+	for _, requirement := range specerror.Catalog() {
+		if requirement.Lint {
+			continue
+		}
+		reference, err := requirement.Reference(rspec.Version)
+		if err != nil {
+			return nil, err
+		}
+		coverage := scan.coverage[requirement.Name]
+		if coverage == nil {
+			coverage = map[string][]string{}
+		}
+		matrix = append(matrix, &requirementCoverage{
+			Code:        requirement.Code.String(),
+			Name:        requirement.Name,
+			Level:       requirement.Level.String(),
+			Reference:   reference,
+			Requirement: requirement.Text,
+			Coverage:    coverage,
+		})
+	}
+	return matrix, nil
+}
+
+// sourceScan holds the references to specerror codes found in the
+// sources of the layers.
+type sourceScan struct {
+	// coverage maps code names to layer names to the files referencing
+	// the code.
+	coverage map[string]map[string][]string
+	// messages maps code names to the formats of the error messages
+	// created with them.
+	messages map[string][]string
+}
+
+// scanSources parses the sources of each layer for references to the
+// specerror package.
+func scanSources(source string) (*sourceScan, error) {
+	if _, err := os.Stat(filepath.Join(source, "specerror")); err != nil {
+		return nil, fmt.Errorf("cannot read the specerror package, is %q the runtime-tools source tree? %w", source, err)
+	}
+
+	scan := &sourceScan{
+		coverage: map[string]map[string][]string{},
+		messages: map[string][]string{},
+	}
+	for _, layer := range coverageLayers {
+		err := filepath.WalkDir(filepath.Join(source, layer.Dir), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+				return nil
+			}
+			used, messages, err := specerrorReferences(path)
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(source, path)
+			if err != nil {
+				rel = path
+			}
+			for _, name := range used {
+				if scan.coverage[name] == nil {
+					scan.coverage[name] = map[string][]string{}
+				}
+				scan.coverage[name][layer.Name] = append(scan.coverage[name][layer.Name], filepath.ToSlash(rel))
+			}
+			for name, formats := range messages {
+				scan.messages[name] = append(scan.messages[name], formats...)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return scan, nil
+}
+
+// specerrorReferences returns the names selected from the specerror
+// package in a Go file, and the formats of the error messages passed
+// along with them to specerror.NewError.
+func specerrorReferences(path string) ([]string, map[string][]string, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, nil, err
+	}
+	local := ""
+	for _, imp := range file.Imports {
+		if p, _ := strconv.Unquote(imp.Path.Value); p == specerrorImportPath {
+			local = "specerror"
+			if imp.Name != nil {
+				local = imp.Name.Name
+			}
+		}
+	}
+	if local == "" {
+		return nil, nil, nil
+	}
+
+	var used []string
+	messages := map[string][]string{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			if ident, ok := n.X.(*ast.Ident); ok && ident.Name == local && !slices.Contains(used, n.Sel.Name) {
+				used = append(used, n.Sel.Name)
+			}
+		case *ast.CallExpr:
+			if name, format, ok := newErrorMessage(n, local); ok {
+				messages[name] = append(messages[name], format)
+			}
+		}
+		return true
+	})
+	return used, messages, nil
+}
+
+// newErrorMessage matches calls like
+// specerror.NewError(specerror.Name, fmt.Errorf("format", ...), version)
+// and returns the code name and the message format.
+func newErrorMessage(call *ast.CallExpr, local string) (name string, format string, ok bool) {
+	if !isSelector(call.Fun, local, "NewError") || len(call.Args) < 2 {
+		return "", "", false
+	}
+	code, ok := call.Args[0].(*ast.SelectorExpr)
+	if !ok || !isSelector(code, local, code.Sel.Name) {
+		return "", "", false
+	}
+	message, ok := call.Args[1].(*ast.CallExpr)
+	if !ok || len(message.Args) == 0 || !(isSelector(message.Fun, "fmt", "Errorf") || isSelector(message.Fun, "errors", "New")) {
+		return "", "", false
+	}
+	lit, ok := message.Args[0].(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", "", false
+	}
+	format, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return "", "", false
+	}
+	return code.Sel.Name, format, true
+}
+
+func isSelector(expr ast.Expr, pkg string, name string) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != name {
+		return false
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	return ok && ident.Name == pkg
+}
+
+func writeCoverageText(w io.Writer, requirements []*requirementCoverage) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	header := []string{"CODE", "NAME", "LEVEL"}
+	for _, layer := range coverageLayers {
+		header = append(header, strings.ToUpper(layer.Name))
+	}
+	header = append(header, "REFERENCE")
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+	covered := map[string]int{}
+	uncovered := 0
+	for _, r := range requirements {
+		row := []string{r.Code, r.Name, r.Level}
+		for _, layer := range coverageLayers {
+			if len(r.Coverage[layer.Name]) > 0 {
+				row = append(row, "x")
+				covered[layer.Name]++
+			} else {
+				row = append(row, "-")
+			}
+		}
+		if len(r.Coverage) == 0 {
+			uncovered++
+		}
+		row = append(row, r.Reference)
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	summary := []string{fmt.Sprintf("%d requirements", len(requirements))}
+	for _, layer := range coverageLayers {
+		summary = append(summary, fmt.Sprintf("%s: %d", layer.Name, covered[layer.Name]))
+	}
+	summary = append(summary, fmt.Sprintf("unchecked: %d", uncovered))
+	_, err := fmt.Fprintln(w, "\n"+strings.Join(summary, ", "))
+	return err
+}
+
+func writeCoverageCSV(w io.Writer, requirements []*requirementCoverage) error {
+	cw := csv.NewWriter(w)
+	header := []string{"code", "name", "level", "reference"}
+	for _, layer := range coverageLayers {
+		header = append(header, layer.Name)
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, r := range requirements {
+		row := []string{r.Code, r.Name, r.Level, r.Reference}
+		for _, layer := range coverageLayers {
+			row = append(row, strings.Join(r.Coverage[layer.Name], " "))
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/opencontainers/runtime-tools/specerror"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+var explainFlags = []cli.Flag{
+	cli.StringFlag{Name: "source", Value: ".", Usage: "path to the runtime-tools source tree, to report which layers check a code"},
+	cli.StringFlag{Name: "spec-version", Value: rspec.Version, Usage: "runtime-spec version of the reference URLs"},
+	cli.BoolFlag{Name: "list", Usage: "dump the whole catalog"},
+	cli.StringFlag{Name: "format", Value: "markdown", Usage: "output format (markdown or json)"},
+}
+
+var explainCommand = cli.Command{
+	Name:      "explain",
+	Usage:     "explain a spec error code",
+	ArgsUsage: "<code|name|message>",
+	Flags:     explainFlags,
+	Before:    before,
+	Action: func(context *cli.Context) error {
+		// The source tree is optional: without it the checking layers
+		// are unknown.
+		scan, err := scanSources(context.String("source"))
+		if err != nil {
+			logrus.Warnf("the checking layers are unknown: %v", err)
+			scan = &sourceScan{}
+		}
+
+		var requirements []specerror.Requirement
+		if context.Bool("list") {
+			if context.NArg() != 0 {
+				return fmt.Errorf("--list takes no arguments")
+			}
+			requirements = specerror.Catalog()
+		} else {
+			if context.NArg() != 1 {
+				return fmt.Errorf("explain takes exactly one code, code name or error message")
+			}
+			requirements = findRequirements(context.Args().First(), scan)
+			if len(requirements) == 0 {
+				return fmt.Errorf("no spec error code matches %q", context.Args().First())
+			}
+		}
+
+		explanations := make([]*explanation, 0, len(requirements))
+		for _, requirement := range requirements {
+			e, err := explain(requirement, context.String("spec-version"), scan)
+			if err != nil {
+				return err
+			}
+			explanations = append(explanations, e)
+		}
+
+		switch context.String("format") {
+		case "markdown":
+			return writeExplanationsMarkdown(os.Stdout, explanations)
+		case "json":
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "\t")
+			return encoder.Encode(explanations)
+		default:
+			return fmt.Errorf("unknown explain format %q", context.String("format"))
+		}
+	},
+}
+
+// explanation describes a code for the explain command.
+type explanation struct {
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	Level       string `json:"level"`
+	Lint        bool   `json:"lint,omitempty"`
+	Reference   string `json:"reference"`
+	Requirement string `json:"requirement"`
+	// CheckedBy maps layer names to the files referencing the code.
+	CheckedBy map[string][]string `json:"checkedBy,omitempty"`
+	// Messages are the formats of the error messages reported with
+	// the code.
+	Messages []string `json:"messages,omitempty"`
+	// scanned is set when the sources were scanned, so that CheckedBy
+	// is known.
+	scanned bool
+}
+
+func explain(requirement specerror.Requirement, version string, scan *sourceScan) (*explanation, error) {
+	reference, err := requirement.Reference(version)
+	if err != nil {
+		return nil, err
+	}
+	return &explanation{
+		Code:        requirement.Code.String(),
+		Name:        requirement.Name,
+		Level:       requirement.Level.String(),
+		Lint:        requirement.Lint,
+		Reference:   reference,
+		Requirement: requirement.Text,
+		CheckedBy:   scan.coverage[requirement.Name],
+		Messages:    scan.messages[requirement.Name],
+		scanned:     scan.coverage != nil,
+	}, nil
+}
+
+// findRequirements looks the query up as a numeric code, then as the
+// name of a Code constant, and finally as a fragment of the requirement
+// text, of the reference, or of an error message reported with a code.
+func findRequirements(query string, scan *sourceScan) []specerror.Requirement {
+	if number, err := strconv.ParseInt(query, 0, 64); err == nil {
+		if requirement, ok := specerror.Lookup(specerror.Code(number)); ok {
+			return []specerror.Requirement{requirement}
+		}
+		return nil
+	}
+	if requirement, ok := specerror.LookupName(query); ok {
+		return []specerror.Requirement{requirement}
+	}
+
+	fragment := strings.ToLower(query)
+	var requirements []specerror.Requirement
+	for _, requirement := range specerror.Catalog() {
+		reference, _ := requirement.Reference(rspec.Version)
+		if strings.Contains(strings.ToLower(requirement.Text), fragment) ||
+			strings.Contains(strings.ToLower(reference), fragment) ||
+			matchesMessage(query, scan.messages[requirement.Name]) {
+			requirements = append(requirements, requirement)
+		}
+	}
+	return requirements
+}
+
+var formatVerb = regexp.MustCompile(`%[-+# 0]*[0-9]*(\.[0-9]*)?[a-zA-Z%]`)
+
+// matchesMessage reports whether query is a fragment of one of the
+// message formats, or a message produced by one of them.
+func matchesMessage(query string, formats []string) bool {
+	for _, format := range formats {
+		if strings.Contains(strings.ToLower(format), strings.ToLower(query)) {
+			return true
+		}
+		var pattern strings.Builder
+		pattern.WriteString("(?i)")
+		last := 0
+		for _, verb := range formatVerb.FindAllStringIndex(format, -1) {
+			pattern.WriteString(regexp.QuoteMeta(format[last:verb[0]]))
+			if format[verb[0]:verb[1]] == "%%" {
+				pattern.WriteString("%")
+			} else {
+				pattern.WriteString(".*")
+			}
+			last = verb[1]
+		}
+		pattern.WriteString(regexp.QuoteMeta(format[last:]))
+		if re, err := regexp.Compile(pattern.String()); err == nil && re.MatchString(query) {
+			return true
+		}
+	}
+	return false
+}
+
+func writeExplanationsMarkdown(w io.Writer, explanations []*explanation) error {
+	for i, e := range explanations {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		level := e.Level
+		if e.Lint {
+			level = "LINT"
+		}
+		fmt.Fprintf(w, "## %s %s\n\n", e.Code, e.Name)
+		fmt.Fprintf(w, "* Level: %s\n", level)
+		fmt.Fprintf(w, "* Reference: %s\n", e.Reference)
+		if !e.scanned {
+			fmt.Fprintf(w, "* Checked by: unknown\n")
+		} else if len(e.CheckedBy) == 0 {
+			fmt.Fprintf(w, "* Checked by: none\n")
+		} else {
+			fmt.Fprintf(w, "* Checked by:\n")
+			for _, layer := range coverageLayers {
+				files := e.CheckedBy[layer.Name]
+				if len(files) == 0 {
+					continue
+				}
+				sort.Strings(files)
+				fmt.Fprintf(w, "  * %s: %s\n", layer.Name, strings.Join(files, ", "))
+			}
+		}
+		fmt.Fprintf(w, "\n> %s\n", e.Requirement)
+		if len(e.Messages) > 0 {
+			fmt.Fprintf(w, "\nReported as:\n\n")
+			for _, message := range e.Messages {
+				fmt.Fprintf(w, "    %s\n", message)
+			}
+		}
+	}
+	return nil
+}
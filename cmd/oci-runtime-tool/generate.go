@@ -41,6 +41,8 @@ var generateFlags = []cli.Flag{
 	cli.StringSliceFlag{Name: "linux-blkio-write-bps-device", Usage: "Limit write rate (bytes per second) to a device"},
 	cli.StringSliceFlag{Name: "linux-blkio-write-iops-device", Usage: "Limit write rate (IO per second) to a device"},
 	cli.StringFlag{Name: "linux-cgroups-path", Usage: "specify the path to the cgroups"},
+	cli.Uint64Flag{Name: "linux-cpu-burst", Usage: "the CPU time a cgroup may accumulate and use in addition to its quota (in usecs)"},
+	cli.Int64Flag{Name: "linux-cpu-idle", Usage: "set the cgroup to SCHED_IDLE with 1, or to the default behavior with 0"},
 	cli.Uint64Flag{Name: "linux-cpu-period", Usage: "the CPU period to be used for hardcapping (in usecs)"},
 	cli.Uint64Flag{Name: "linux-cpu-quota", Usage: "the allowed CPU time in a given period (in usecs)"},
 	cli.StringFlag{Name: "linux-cpus", Usage: "CPUs to use within the cpuset (default is to use any CPU available)"},
@@ -55,15 +57,22 @@ var generateFlags = []cli.Flag{
 	cli.StringSliceFlag{Name: "linux-hugepage-limits-add", Usage: "add hugepage resource limits"},
 	cli.StringSliceFlag{Name: "linux-hugepage-limits-drop", Usage: "drop hugepage resource limits"},
 	cli.StringFlag{Name: "linux-intelRdt-closid", Usage: "RDT Class of Service, i.e. group under the resctrl pseudo-filesystem which to associate the container with"},
+	cli.BoolFlag{Name: "linux-intelRdt-enableMonitoring", Usage: "enables resctrl monitoring for the container"},
 	cli.StringFlag{Name: "linux-intelRdt-l3CacheSchema", Usage: "specifies the schema for L3 cache id and capacity bitmask"},
+	cli.StringFlag{Name: "linux-intelRdt-memBwSchema", Usage: "specifies the schema of memory bandwidth per L3 cache id"},
+	cli.StringSliceFlag{Name: "linux-intelRdt-schemata", Usage: "specifies a line of the complete schemata to be written to resctrl"},
 	cli.StringSliceFlag{Name: "linux-masked-paths", Usage: "specifies paths can not be read inside container"},
+	cli.BoolFlag{Name: "linux-mem-check-before-update", Usage: "reject memory limit updates lower than the current usage"},
+	cli.Int64Flag{Name: "linux-mem-high", Usage: "cgroup v2 memory.high throttle limit (in bytes, -1 for max)"},
 	cli.Uint64Flag{Name: "linux-mem-kernel-limit", Usage: "kernel memory limit (in bytes)"},
 	cli.Uint64Flag{Name: "linux-mem-kernel-tcp", Usage: "kernel memory limit for tcp (in bytes)"},
 	cli.Uint64Flag{Name: "linux-mem-limit", Usage: "memory limit (in bytes)"},
 	cli.Uint64Flag{Name: "linux-mem-reservation", Usage: "memory reservation or soft limit (in bytes)"},
+	cli.Int64Flag{Name: "linux-mem-min", Usage: "cgroup v2 memory.min protected memory (in bytes, -1 for max)"},
 	cli.StringFlag{Name: "linux-mems", Usage: "list of memory nodes in the cpuset (default is to use any available memory node)"},
 	cli.Uint64Flag{Name: "linux-mem-swap", Usage: "total memory limit (memory + swap) (in bytes)"},
 	cli.Uint64Flag{Name: "linux-mem-swappiness", Usage: "how aggressive the kernel will swap memory pages (Range from 0 to 100)"},
+	cli.BoolFlag{Name: "linux-mem-use-hierarchy", Usage: "enable hierarchical memory accounting"},
 	cli.StringFlag{Name: "linux-mount-label", Usage: "selinux mount context label"},
 	cli.StringSliceFlag{Name: "linux-namespace-add", Usage: "adds a namespace to the set of namespaces to create or join of the form 'ns[:path]'"},
 	cli.StringSliceFlag{Name: "linux-namespace-remove", Usage: "removes a namespace from the set of namespaces to create or join of the form 'ns'"},
@@ -72,6 +81,8 @@ var generateFlags = []cli.Flag{
 	cli.StringSliceFlag{Name: "linux-network-priorities", Usage: "specifies priorities of network traffic"},
 	cli.IntFlag{Name: "linux-oom-score-adj", Usage: "oom_score_adj for the container"},
 	cli.Int64Flag{Name: "linux-pids-limit", Usage: "maximum number of PIDs"},
+	cli.StringSliceFlag{Name: "linux-rdma-add", Usage: "add RDMA resource limits for a device e.g. mlx5_1,hcaHandles=3,hcaObjects=10000"},
+	cli.StringSliceFlag{Name: "linux-rdma-drop", Usage: "drop RDMA resource limits for a device"},
 	cli.StringSliceFlag{Name: "linux-readonly-paths", Usage: "specifies paths readonly inside container"},
 	cli.Int64Flag{Name: "linux-realtime-period", Usage: "CPU period to be used for realtime scheduling (in usecs)"},
 	cli.Int64Flag{Name: "linux-realtime-runtime", Usage: "the time realtime scheduling may use (in usecs)"},
@@ -89,6 +100,7 @@ var generateFlags = []cli.Flag{
 	cli.StringFlag{Name: "linux-seccomp-trap", Usage: "specifies syscalls to respond with trap"},
 	cli.StringFlag{Name: "linux-selinux-label", Usage: "process selinux label"},
 	cli.StringSliceFlag{Name: "linux-sysctl", Usage: "add sysctl settings e.g net.ipv4.forward=1"},
+	cli.StringSliceFlag{Name: "linux-time-offset", Usage: "add time namespace offsets e.g. monotonic:secs:nanosecs"},
 	cli.StringSliceFlag{Name: "linux-uidmappings", Usage: "add UIDMappings e.g HostID:ContainerID:Size"},
 	cli.StringSliceFlag{Name: "mounts-add", Usage: "configures additional mounts inside container"},
 	cli.StringSliceFlag{Name: "mounts-remove", Usage: "remove destination mountpoints from inside container"},
@@ -124,6 +136,7 @@ var generateFlags = []cli.Flag{
 	cli.StringFlag{Name: "process-username", Usage: "username for the process"},
 	cli.StringFlag{Name: "rootfs-path", Value: "rootfs", Usage: "path to the root filesystem"},
 	cli.BoolFlag{Name: "rootfs-readonly", Usage: "make the container's rootfs readonly"},
+	cli.BoolFlag{Name: "rootless", Usage: "generate a configuration for a runtime running without root privileges"},
 	cli.StringSliceFlag{Name: "solaris-anet", Usage: "set up networking for Solaris application containers"},
 	cli.StringFlag{Name: "solaris-capped-cpu-ncpus", Usage: "Specifies the percentage of CPU usage"},
 	cli.StringFlag{Name: "solaris-capped-memory-physical", Usage: "Specifies the physical caps on the memory"},
@@ -487,21 +500,64 @@ func setupSpec(g *generate.Generator, context *cli.Context) error {
 		g.SetProcessConsoleSize(width, height)
 	}
 
-	var uidMaps, gidMaps []string
+	var uidMappings, gidMappings []rspec.LinuxIDMapping
 
 	if context.IsSet("linux-uidmappings") {
-		uidMaps = context.StringSlice("linux-uidmappings")
+		mappings, err := parseIDMappings(context.StringSlice("linux-uidmappings"))
+		if err != nil {
+			return err
+		}
+		uidMappings = mappings
 	}
 
 	if context.IsSet("linux-gidmappings") {
-		gidMaps = context.StringSlice("linux-gidmappings")
+		mappings, err := parseIDMappings(context.StringSlice("linux-gidmappings"))
+		if err != nil {
+			return err
+		}
+		gidMappings = mappings
 	}
 
 	// Add default user namespace.
-	if len(uidMaps) > 0 || len(gidMaps) > 0 {
+	if len(uidMappings) > 0 || len(gidMappings) > 0 {
 		g.AddOrReplaceLinuxNamespace("user", "")
 	}
 
+	if context.Bool("rootless") {
+		// Explicit mappings replace the ones for the current user.
+		rootlessUIDMappings, rootlessGIDMappings, err := generate.RootlessIDMappings()
+		if err != nil {
+			return err
+		}
+		if len(uidMappings) == 0 {
+			uidMappings = rootlessUIDMappings
+		}
+		if len(gidMappings) == 0 {
+			gidMappings = rootlessGIDMappings
+		}
+		g.SetupRootless(uidMappings, gidMappings)
+	} else {
+		for _, m := range uidMappings {
+			g.AddLinuxUIDMapping(m.HostID, m.ContainerID, m.Size)
+		}
+		for _, m := range gidMappings {
+			g.AddLinuxGIDMapping(m.HostID, m.ContainerID, m.Size)
+		}
+	}
+
+	if context.IsSet("linux-time-offset") {
+		offsets := context.StringSlice("linux-time-offset")
+		for _, o := range offsets {
+			clock, offset, err := parseTimeOffset(o)
+			if err != nil {
+				return err
+			}
+			g.SetLinuxTimeOffset(clock, offset)
+		}
+		// Offsets are only meaningful within a new time namespace.
+		g.AddOrReplaceLinuxNamespace("time", "")
+	}
+
 	if context.IsSet("mounts-remove-all") {
 		g.ClearMounts()
 	}
@@ -576,24 +632,6 @@ func setupSpec(g *generate.Generator, context *cli.Context) error {
 		}
 	}
 
-	for _, uidMap := range uidMaps {
-		hid, cid, size, err := parseIDMapping(uidMap)
-		if err != nil {
-			return err
-		}
-
-		g.AddLinuxUIDMapping(hid, cid, size)
-	}
-
-	for _, gidMap := range gidMaps {
-		hid, cid, size, err := parseIDMapping(gidMap)
-		if err != nil {
-			return err
-		}
-
-		g.AddLinuxGIDMapping(hid, cid, size)
-	}
-
 	if context.IsSet("linux-disable-oom-kill") {
 		g.SetLinuxResourcesMemoryDisableOOMKiller(context.Bool("linux-disable-oom-kill"))
 	}
@@ -712,6 +750,14 @@ func setupSpec(g *generate.Generator, context *cli.Context) error {
 		g.SetLinuxResourcesCPUQuota(context.Int64("linux-cpu-quota"))
 	}
 
+	if context.IsSet("linux-cpu-burst") {
+		g.SetLinuxResourcesCPUBurst(context.Uint64("linux-cpu-burst"))
+	}
+
+	if context.IsSet("linux-cpu-idle") {
+		g.SetLinuxResourcesCPUIdle(context.Int64("linux-cpu-idle"))
+	}
+
 	if context.IsSet("linux-realtime-runtime") {
 		g.SetLinuxResourcesCPURealtimeRuntime(context.Int64("linux-realtime-runtime"))
 	}
@@ -746,6 +792,24 @@ func setupSpec(g *generate.Generator, context *cli.Context) error {
 		}
 	}
 
+	if context.IsSet("linux-rdma-add") {
+		rdmaList := context.StringSlice("linux-rdma-add")
+		for _, v := range rdmaList {
+			device, rdma, err := parseRdma(v)
+			if err != nil {
+				return err
+			}
+			g.AddLinuxResourcesRdma(device, rdma)
+		}
+	}
+
+	if context.IsSet("linux-rdma-drop") {
+		rdmaList := context.StringSlice("linux-rdma-drop")
+		for _, v := range rdmaList {
+			g.DropLinuxResourcesRdma(v)
+		}
+	}
+
 	if context.IsSet("linux-intelRdt-closid") {
 		g.SetLinuxIntelRdtClosID(context.String("linux-intelRdt-closid"))
 	}
@@ -754,6 +818,21 @@ func setupSpec(g *generate.Generator, context *cli.Context) error {
 		g.SetLinuxIntelRdtL3CacheSchema(context.String("linux-intelRdt-l3CacheSchema"))
 	}
 
+	if context.IsSet("linux-intelRdt-memBwSchema") {
+		g.SetLinuxIntelRdtMemBwSchema(context.String("linux-intelRdt-memBwSchema"))
+	}
+
+	if context.IsSet("linux-intelRdt-schemata") {
+		schemata := context.StringSlice("linux-intelRdt-schemata")
+		for _, schema := range schemata {
+			g.AddLinuxIntelRdtSchemata(schema)
+		}
+	}
+
+	if context.IsSet("linux-intelRdt-enableMonitoring") {
+		g.SetLinuxIntelRdtEnableMonitoring(context.Bool("linux-intelRdt-enableMonitoring"))
+	}
+
 	if context.IsSet("linux-mems") {
 		g.SetLinuxResourcesCPUMems(context.String("linux-mems"))
 	}
@@ -782,6 +861,22 @@ func setupSpec(g *generate.Generator, context *cli.Context) error {
 		g.SetLinuxResourcesMemorySwappiness(context.Uint64("linux-mem-swappiness"))
 	}
 
+	if context.IsSet("linux-mem-use-hierarchy") {
+		g.SetLinuxResourcesMemoryUseHierarchy(context.Bool("linux-mem-use-hierarchy"))
+	}
+
+	if context.IsSet("linux-mem-check-before-update") {
+		g.SetLinuxResourcesMemoryCheckBeforeUpdate(context.Bool("linux-mem-check-before-update"))
+	}
+
+	if context.IsSet("linux-mem-high") {
+		g.SetLinuxResourcesMemoryHigh(context.Int64("linux-mem-high"))
+	}
+
+	if context.IsSet("linux-mem-min") {
+		g.SetLinuxResourcesMemoryMin(context.Int64("linux-mem-min"))
+	}
+
 	if context.IsSet("linux-network-classid") {
 		g.SetLinuxResourcesNetworkClassID(uint32(context.Int("linux-network-classid")))
 	}
@@ -1040,6 +1135,18 @@ func parseConsoleSize(consoleSize string) (uint, uint, error) {
 	return uint(width), uint(height), nil
 }
 
+func parseIDMappings(idms []string) ([]rspec.LinuxIDMapping, error) {
+	var mappings []rspec.LinuxIDMapping
+	for _, idm := range idms {
+		hid, cid, size, err := parseIDMapping(idm)
+		if err != nil {
+			return nil, err
+		}
+		mappings = append(mappings, rspec.LinuxIDMapping{HostID: hid, ContainerID: cid, Size: size})
+	}
+	return mappings, nil
+}
+
 func parseIDMapping(idms string) (uint32, uint32, uint32, error) {
 	idm := strings.Split(idms, ":")
 	if len(idm) != 3 {
@@ -1078,6 +1185,36 @@ func parseHugepageLimit(pageLimit string) (string, uint64, error) {
 	return pl[0], uint64(limit), nil
 }
 
+func parseRdma(rdma string) (string, rspec.LinuxRdma, error) {
+	var limits rspec.LinuxRdma
+
+	parts := strings.Split(rdma, ",")
+	if parts[0] == "" {
+		return "", limits, fmt.Errorf("invalid value %v for --linux-rdma-add", rdma)
+	}
+	for _, part := range parts[1:] {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return "", limits, fmt.Errorf("invalid value %v for --linux-rdma-add", rdma)
+		}
+		value, err := strconv.ParseUint(kv[1], 10, 32)
+		if err != nil {
+			return "", limits, err
+		}
+		limit := uint32(value)
+		switch kv[0] {
+		case "hcaHandles":
+			limits.HcaHandles = &limit
+		case "hcaObjects":
+			limits.HcaObjects = &limit
+		default:
+			return "", limits, fmt.Errorf("unknown RDMA resource %q for --linux-rdma-add", kv[0])
+		}
+	}
+
+	return parts[0], limits, nil
+}
+
 func parseNetworkPriority(np string) (string, int32, error) {
 	var err error
 
@@ -1112,6 +1249,33 @@ func parseRlimit(rlimit string) (string, uint64, uint64, error) {
 	return parts[0], uint64(hard), uint64(soft), nil
 }
 
+func parseTimeOffset(timeOffset string) (string, rspec.LinuxTimeOffset, error) {
+	parts := strings.Split(timeOffset, ":")
+	if len(parts) != 3 {
+		return "", rspec.LinuxTimeOffset{}, fmt.Errorf("invalid time offset value: %s", timeOffset)
+	}
+
+	clock := parts[0]
+	if clock != "monotonic" && clock != "boottime" {
+		return "", rspec.LinuxTimeOffset{}, fmt.Errorf("invalid clock %q for time offset, must be monotonic or boottime", clock)
+	}
+
+	secs, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", rspec.LinuxTimeOffset{}, err
+	}
+
+	nanosecs, err := strconv.ParseUint(parts[2], 10, 32)
+	if err != nil {
+		return "", rspec.LinuxTimeOffset{}, err
+	}
+	if nanosecs >= 1000000000 {
+		return "", rspec.LinuxTimeOffset{}, fmt.Errorf("invalid nanosecs %d for time offset, must be less than 1000000000", nanosecs)
+	}
+
+	return clock, rspec.LinuxTimeOffset{Secs: secs, Nanosecs: uint32(nanosecs)}, nil
+}
+
 func parseNamespace(ns string) (string, string, error) {
 	parts := strings.SplitN(ns, ":", 2)
 	if len(parts) == 0 || parts[0] == "" {
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/opencontainers/runtime-tools/generate"
+	"github.com/opencontainers/runtime-tools/lint"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+var lintFlags = []cli.Flag{
+	cli.StringFlag{Name: "path", Value: ".", Usage: "path to a bundle"},
+	cli.StringFlag{Name: "severity", Value: "low", Usage: "lowest severity of the reported findings (low, medium or high)"},
+	cli.StringFlag{Name: "format", Value: "text", Usage: "output format (text or json)"},
+	cli.BoolFlag{Name: "list", Usage: "list the rules"},
+}
+
+var lintCommand = cli.Command{
+	Name:   "lint",
+	Usage:  "report risky but valid settings of an OCI bundle",
+	Flags:  lintFlags,
+	Before: before,
+	Action: func(context *cli.Context) error {
+		format := context.String("format")
+		if format != "text" && format != "json" {
+			return fmt.Errorf("unknown lint format %q", format)
+		}
+
+		if context.Bool("list") {
+			rules := lint.Rules()
+			if format == "json" {
+				encoder := json.NewEncoder(os.Stdout)
+				encoder.SetIndent("", "\t")
+				return encoder.Encode(rules)
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tSEVERITY\tDESCRIPTION")
+			for _, rule := range rules {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", rule.ID, rule.Severity, rule.Description)
+			}
+			return w.Flush()
+		}
+
+		severity, err := lint.ParseSeverity(context.String("severity"))
+		if err != nil {
+			return err
+		}
+		specgen, err := generate.NewFromFile(filepath.Join(context.String("path"), "config.json"))
+		if err != nil {
+			return err
+		}
+		for key := range specgen.Config.Annotations {
+			if id, ok := strings.CutPrefix(key, lint.SuppressAnnotationPrefix); ok {
+				if _, known := lint.LookupRule(id); !known {
+					logrus.Warnf("annotation %q suppresses the unknown lint rule %q", key, id)
+				}
+			}
+		}
+
+		findings := lint.Lint(specgen.Config, severity)
+		if format == "json" {
+			if findings == nil {
+				findings = []lint.Finding{}
+			}
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "\t")
+			if err := encoder.Encode(findings); err != nil {
+				return err
+			}
+		} else {
+			for _, finding := range findings {
+				fmt.Println(finding)
+			}
+		}
+		if len(findings) > 0 {
+			return fmt.Errorf("%d lint findings", len(findings))
+		}
+		return nil
+	},
+}
@@ -45,6 +45,10 @@ func main() {
 	app.Commands = []cli.Command{
 		generateCommand,
 		bundleValidateCommand,
+		coverageCommand,
+		explainCommand,
+		migrateCommand,
+		lintCommand,
 	}
 
 	if err := app.Run(os.Args); err != nil {
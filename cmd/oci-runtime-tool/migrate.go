@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/opencontainers/runtime-tools/generate"
+	"github.com/opencontainers/runtime-tools/validate"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+var migrateFlags = []cli.Flag{
+	cli.StringFlag{Name: "to", Value: rspec.Version, Usage: "runtime-spec version to migrate the configuration to"},
+	cli.StringFlag{Name: "output", Usage: "output file (defaults to stdout)"},
+	cli.StringFlag{Name: "changelog", Usage: "file to write the JSON list of changes to (defaults to stderr)"},
+	cli.StringFlag{Name: "platform", Value: runtime.GOOS, Usage: "platform of the target bundle (linux, windows, solaris)"},
+}
+
+var migrateCommand = cli.Command{
+	Name:      "migrate",
+	Usage:     "migrate a configuration to a newer runtime-spec version",
+	ArgsUsage: "<config.json>",
+	Flags:     migrateFlags,
+	Before:    before,
+	Action: func(context *cli.Context) error {
+		if context.NArg() != 1 {
+			return fmt.Errorf("migrate takes exactly one configuration file")
+		}
+		configPath := context.Args().First()
+
+		specgen, err := generate.NewFromFile(configPath)
+		if err != nil {
+			return err
+		}
+		changes, err := specgen.Migrate(context.String("to"))
+		if err != nil {
+			return err
+		}
+		for _, change := range changes {
+			if change.Warning != "" {
+				logrus.Warnf("%s: %s", change.Path, change.Warning)
+			}
+		}
+
+		changelog := os.Stderr
+		if context.IsSet("changelog") {
+			changelog, err = os.Create(context.String("changelog"))
+			if err != nil {
+				return err
+			}
+			defer changelog.Close()
+		}
+		if changes == nil {
+			changes = []generate.MigrationChange{}
+		}
+		encoder := json.NewEncoder(changelog)
+		encoder.SetIndent("", "\t")
+		if err := encoder.Encode(changes); err != nil {
+			return err
+		}
+
+		// The migrated configuration is validated as a part of the
+		// bundle holding the original one, before it is written, so
+		// that an invalid one does not replace the original.
+		v, err := validate.NewValidator(specgen.Config, filepath.Dir(configPath), context.GlobalBool("host-specific"), context.String("platform"))
+		if err != nil {
+			return err
+		}
+		if err := checkAll(context, v); err != nil {
+			return err
+		}
+
+		exportOpts := generate.ExportOptions{}
+		if context.IsSet("output") {
+			return specgen.SaveToFile(context.String("output"), exportOpts)
+		}
+		return specgen.Save(os.Stdout, exportOpts)
+	},
+}
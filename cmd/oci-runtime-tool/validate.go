@@ -1,7 +1,9 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 	"runtime"
 
 	rfc2119 "github.com/opencontainers/runtime-tools/error"
@@ -12,8 +14,14 @@ import (
 )
 
 var bundleValidateFlags = []cli.Flag{
+	cli.BoolFlag{Name: "apparmor-rootfs", Usage: "check that process.apparmorProfile is shipped in the rootfs /etc/apparmor.d, without --host-specific"},
+	cli.BoolFlag{Name: "effective-capabilities", Usage: "print the capability sets of the process after it executes process.args[0]"},
+	cli.StringFlag{Name: "kernel-version", Usage: "Linux release of the target hosts, e.g. 5.4, to check the capabilities against"},
+	cli.StringSliceFlag{Name: "dangerous-mount-dir", Usage: "host directory which bind mounts should not expose writable as a whole, replacing the default list (repeatable)"},
+	cli.StringSliceFlag{Name: "dangerous-mount-source", Usage: "host path which bind mounts should not expose writable, replacing the default list (repeatable)"},
 	cli.StringFlag{Name: "path", Value: ".", Usage: "path to a bundle"},
 	cli.StringFlag{Name: "platform", Value: runtime.GOOS, Usage: "platform of the target bundle (linux, windows, solaris)"},
+	cli.StringSliceFlag{Name: "policy", Usage: "file of policy rules to check along with the spec (repeatable)"},
 }
 
 var bundleValidateCommand = cli.Command{
@@ -23,31 +31,71 @@ var bundleValidateCommand = cli.Command{
 	Before: before,
 	Action: func(context *cli.Context) error {
 		hostSpecific := context.GlobalBool("host-specific")
-		complianceLevelString := context.GlobalString("compliance-level")
-		complianceLevel, err := rfc2119.ParseLevel(complianceLevelString)
-		if err != nil {
-			complianceLevel = rfc2119.Must
-			logrus.Warningf("%s, using 'MUST' by default.", err.Error())
-		}
 		inputPath := context.String("path")
 		platform := context.String("platform")
 		v, err := validate.NewValidatorFromPath(inputPath, hostSpecific, platform)
 		if err != nil {
 			return err
 		}
-
-		if err := v.CheckAll(); err != nil {
-			levelErrors, err := specerror.SplitLevel(err, complianceLevel)
+		if context.IsSet("dangerous-mount-source") {
+			v.DangerousMountSources = context.StringSlice("dangerous-mount-source")
+		}
+		if context.IsSet("dangerous-mount-dir") {
+			v.DangerousMountDirs = context.StringSlice("dangerous-mount-dir")
+		}
+		v.KernelVersion = context.String("kernel-version")
+		v.ApparmorRootfs = context.Bool("apparmor-rootfs")
+		if context.Bool("effective-capabilities") {
+			caps := v.EffectiveCapabilities()
+			if caps == nil {
+				return fmt.Errorf("the configuration has no process.capabilities")
+			}
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "\t")
+			if err := encoder.Encode(caps); err != nil {
+				return err
+			}
+		}
+		for _, policy := range context.StringSlice("policy") {
+			rules, err := validate.LoadPolicy(policy)
 			if err != nil {
 				return err
 			}
-			for _, e := range levelErrors.Warnings {
-				logrus.Warn(e)
+			for _, rule := range rules {
+				if err := v.AddCheck(rule); err != nil {
+					return err
+				}
 			}
+		}
 
-			return levelErrors.Error.ErrorOrNil()
+		if err := checkAll(context, v); err != nil {
+			return err
 		}
 		fmt.Println("Bundle validation succeeded.")
 		return nil
 	},
 }
+
+// checkAll runs all the checks of v, logs the errors below the global
+// compliance level as warnings, and returns the others.
+func checkAll(context *cli.Context, v validate.Validator) error {
+	complianceLevelString := context.GlobalString("compliance-level")
+	complianceLevel, err := rfc2119.ParseLevel(complianceLevelString)
+	if err != nil {
+		complianceLevel = rfc2119.Must
+		logrus.Warningf("%s, using 'MUST' by default.", err.Error())
+	}
+
+	if err := v.CheckAll(); err != nil {
+		levelErrors, err := specerror.SplitLevel(err, complianceLevel)
+		if err != nil {
+			return err
+		}
+		for _, e := range levelErrors.Warnings {
+			logrus.Warn(e)
+		}
+
+		return levelErrors.Error.ErrorOrNil()
+	}
+	return nil
+}
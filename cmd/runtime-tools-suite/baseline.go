@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/opencontainers/runtime-tools/specerror"
+)
+
+// baseline lists the known failures of the runtime under test, so that
+// only regressions fail the suite.
+//
+//	{
+//		"knownFailures": [
+//			{
+//				"test": "default",
+//				"code": "0xf001",
+//				"reason": "/dev/ptmx is a device node, not a symlink"
+//			},
+//			{
+//				"test": "process_user",
+//				"description": "has expected additional group IDs",
+//				"reason": "additional groups are not supported yet"
+//			}
+//		]
+//	}
+//
+// A known failure matches the test points of a validation test with the
+// spec error code reported in their diagnostics, or, for test points
+// without a code, with their description.
+type baseline struct {
+	KnownFailures []knownFailure `json:"knownFailures"`
+}
+
+type knownFailure struct {
+	Test        string `json:"test"`
+	Code        string `json:"code,omitempty"`
+	Description string `json:"description,omitempty"`
+	Reason      string `json:"reason"`
+}
+
+func loadBaseline(path string) (*baseline, error) {
+	var b baseline
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("invalid baseline %s: %w", path, err)
+	}
+	for i := range b.KnownFailures {
+		kf := &b.KnownFailures[i]
+		if err := kf.normalize(); err != nil {
+			return nil, fmt.Errorf("invalid baseline %s: knownFailures[%d]: %w", path, i, err)
+		}
+	}
+	return &b, nil
+}
+
+// normalize checks a known failure and rewrites its code in the form
+// used in TAP diagnostics.
+func (kf *knownFailure) normalize() error {
+	if kf.Test == "" {
+		return errors.New("test is required")
+	}
+	if kf.Reason == "" {
+		return errors.New("reason is required")
+	}
+	if (kf.Code == "") == (kf.Description == "") {
+		return errors.New("exactly one of code and description is required")
+	}
+	if kf.Code != "" {
+		code, err := strconv.ParseInt(kf.Code, 0, 64)
+		if err != nil {
+			return fmt.Errorf("invalid code %q", kf.Code)
+		}
+		kf.Code = specerror.Code(code).String()
+	}
+	return nil
+}
+
+func (b *baseline) match(test string, result tapResult) *knownFailure {
+	for i, kf := range b.KnownFailures {
+		if kf.Test != test {
+			continue
+		}
+		if kf.Code != "" && strings.EqualFold(kf.Code, result.Code) || kf.Description != "" && kf.Description == result.Description {
+			return &b.KnownFailures[i]
+		}
+	}
+	return nil
+}
+
+// name identifies the known failure in messages.
+func (kf *knownFailure) name() string {
+	if kf.Code != "" {
+		return "code " + kf.Code
+	}
+	return kf.Description
+}
+
+// apply turns the failing test points of r matching a known failure into
+// TODO test points, in the results and in the TAP output, and updates
+// the status of r.  Passing test points are left alone, as sibling test
+// points often share a code: a known failure is an unexpected pass only
+// when none of the test points it matches failed.
+func (b *baseline) apply(r *testResult) {
+	lines := strings.SplitAfter(r.Output, "\n")
+	// failed records, for each matched known failure, whether one of
+	// its test points failed.
+	failed := map[*knownFailure]bool{}
+	marked := false
+	for i := range r.Results {
+		result := &r.Results[i]
+		if result.Directive != "" {
+			continue
+		}
+		kf := b.match(r.Name, *result)
+		if kf == nil {
+			continue
+		}
+		failed[kf] = failed[kf] || !result.Ok
+		if result.Ok {
+			continue
+		}
+		marked = true
+		result.Directive = directiveTODO
+		result.KnownFailure = kf.Reason
+		if result.Line < len(lines) {
+			line := strings.TrimRight(lines[result.Line], "\n")
+			lines[result.Line] = fmt.Sprintf("%s # TODO known failure: %s\n", line, kf.Reason)
+		}
+	}
+	for i := range b.KnownFailures {
+		kf := &b.KnownFailures[i]
+		if f, ok := failed[kf]; ok && !f {
+			r.UnexpectedPasses = append(r.UnexpectedPasses, kf.name())
+		}
+	}
+	if !marked {
+		return
+	}
+	r.Output = strings.Join(lines, "")
+	if r.Error == "" {
+		r.Status = tapStatus(r.Results)
+	}
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+
+	"github.com/opencontainers/runtime-tools/validation/util"
+)
+
+// gitCommit will be the hash that the binary was built from
+// and will be populated by the Makefile
+var gitCommit = ""
+
+// version will be populated by the Makefile, read from
+// VERSION file of the source code.
+var version = ""
+
+func main() {
+	// When invoked as "<name>.t" (for example through the symlinks
+	// created by "make validation-executables"), run that single test
+	// and write its TAP to stdout, like the former per-test executables.
+	if name, ok := strings.CutSuffix(filepath.Base(os.Args[0]), ".t"); ok {
+		test, found := util.LookupTest(name)
+		if !found {
+			util.Fatal(fmt.Errorf("unknown validation test %q", name))
+		}
+		test.Run()
+		return
+	}
+
+	app := cli.NewApp()
+	app.Name = "runtime-tools-suite"
+	if gitCommit != "" {
+		app.Version = fmt.Sprintf("%s, commit: %s", version, gitCommit)
+	} else {
+		app.Version = version
+	}
+	app.Usage = "Run the OCI runtime validation suite"
+	app.Flags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "run",
+			Usage: "only run the tests whose name matches the regular expression",
+		},
+		cli.BoolFlag{
+			Name:  "list",
+			Usage: "list the tests instead of running them",
+		},
+		cli.IntFlag{
+			Name:  "parallel",
+			Value: 1,
+			Usage: "maximum number of tests to run at the same time",
+		},
+		cli.DurationFlag{
+			Name:  "timeout",
+			Value: defaultTimeout,
+			Usage: "timeout for each test, 0 to disable (the default is scaled by RUNTIME_TOOLS_TIMEOUT_SCALE)",
+		},
+		cli.StringFlag{
+			Name:  "format",
+			Value: "tap",
+			Usage: "report format (tap, junit, or json)",
+		},
+		cli.StringFlag{
+			Name:  "baseline",
+			Usage: "JSON file listing known failures, which are reported as TODO instead of failing the suite",
+		},
+		cli.StringFlag{
+			Name:  "output",
+			Usage: "write the report to this file instead of stdout",
+		},
+		cli.StringFlag{
+			Name:   "runtime",
+			Usage:  "runtime command to test",
+			EnvVar: "RUNTIME",
+		},
+		cli.BoolFlag{
+			Name:   "rootless",
+			Usage:  "test a runtime running without root privileges",
+			EnvVar: "RUNTIME_TOOLS_ROOTLESS",
+		},
+		cli.StringFlag{
+			Name:   "runtime-profile",
+			Usage:  "JSON file with the runtime command, global arguments, environment and wrapper",
+			EnvVar: "RUNTIME_PROFILE",
+		},
+	}
+	app.Action = runSuite
+
+	if err := app.Run(os.Args); err != nil {
+		logrus.Fatal(err)
+	}
+}
+
+func runSuite(context *cli.Context) error {
+	tests := util.RegisteredTests()
+	if context.IsSet("run") {
+		re, err := regexp.Compile(context.String("run"))
+		if err != nil {
+			return fmt.Errorf("invalid --run value: %v", err)
+		}
+		var selected []util.Test
+		for _, test := range tests {
+			if re.MatchString(test.Name) {
+				selected = append(selected, test)
+			}
+		}
+		tests = selected
+	}
+
+	if context.Bool("list") {
+		for _, test := range tests {
+			fmt.Println(test.Name)
+		}
+		return nil
+	}
+
+	writeReport, ok := reportWriters[context.String("format")]
+	if !ok {
+		return fmt.Errorf("unknown report format %q", context.String("format"))
+	}
+	parallel := context.Int("parallel")
+	if parallel < 1 {
+		return fmt.Errorf("invalid --parallel value: %d", parallel)
+	}
+
+	var known *baseline
+	if path := context.String("baseline"); path != "" {
+		b, err := loadBaseline(path)
+		if err != nil {
+			return err
+		}
+		known = b
+	}
+
+	// The tests read the runtime settings from the environment.
+	if profile := context.String("runtime-profile"); profile != "" {
+		if _, err := util.LoadRuntimeProfile(profile); err != nil {
+			return err
+		}
+		os.Setenv("RUNTIME_PROFILE", profile)
+	}
+	if runtime := context.String("runtime"); runtime != "" {
+		os.Setenv("RUNTIME", runtime)
+	}
+	if context.Bool("rootless") {
+		os.Setenv("RUNTIME_TOOLS_ROOTLESS", "true")
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	timeout := context.Duration("timeout")
+	if !context.IsSet("timeout") {
+		timeout = util.ScaleTimeout(timeout)
+	}
+	results := runTests(exe, tests, parallel, timeout)
+	if known != nil {
+		for _, r := range results {
+			known.apply(r)
+			for _, name := range r.UnexpectedPasses {
+				logrus.Warnf("%s: known failure %q passed, update the baseline", r.Name, name)
+			}
+		}
+	}
+
+	var w io.Writer = os.Stdout
+	if output := context.String("output"); output != "" {
+		f, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	if err := writeReport(w, results); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, r := range results {
+		if r.Status == statusFail {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d validation tests failed", failed, len(results))
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mndrix/tap-go"
+)
+
+var reportWriters = map[string]func(io.Writer, []*testResult) error{
+	"tap":   writeTAP,
+	"junit": writeJUnit,
+	"json":  writeJSON,
+}
+
+// writeTAP writes one test point per validation test, each preceded by
+// the test's own TAP output as an indented subtest.
+func writeTAP(w io.Writer, results []*testResult) error {
+	t := tap.New()
+	t.Writer = w
+	t.Header(0)
+	for _, r := range results {
+		fmt.Fprintf(w, "# Subtest: %s\n", r.Name)
+		for _, line := range strings.SplitAfter(r.Output, "\n") {
+			if line != "" {
+				fmt.Fprintf(w, "    %s", line)
+			}
+		}
+		if r.Output != "" && !strings.HasSuffix(r.Output, "\n") {
+			fmt.Fprintln(w)
+		}
+
+		if r.Status == statusSkip {
+			t.Skip(1, fmt.Sprintf("%s: %s", r.Name, r.Results[0].Description))
+			continue
+		}
+		t.Ok(r.Status == statusPass, r.Name)
+		diagnostic := map[string]any{
+			"duration": r.Duration.String(),
+		}
+		if len(r.UnexpectedPasses) > 0 {
+			diagnostic["unexpectedPasses"] = r.UnexpectedPasses
+		}
+		if r.Error != "" {
+			diagnostic["error"] = r.Error
+			diagnostic["exitCode"] = r.ExitCode
+			if r.Stderr != "" {
+				diagnostic["stderr"] = r.Stderr
+			}
+		}
+		if err := t.YAML(diagnostic); err != nil {
+			return err
+		}
+	}
+	t.AutoPlan()
+	return nil
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+	SystemErr string          `xml:"system-err,omitempty"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Body    string `xml:",chardata"`
+}
+
+// writeJUnit writes a JUnit XML report with one test suite per validation
+// test and one test case per TAP test point.
+func writeJUnit(w io.Writer, results []*testResult) error {
+	var report junitTestSuites
+	for _, r := range results {
+		suite := junitTestSuite{
+			Name:      r.Name,
+			Time:      fmt.Sprintf("%.3f", r.Duration.Seconds()),
+			SystemErr: r.Stderr,
+		}
+		for _, result := range r.Results {
+			tc := junitTestCase{
+				Name:      fmt.Sprintf("%d - %s", result.Number, result.Description),
+				Classname: r.Name,
+			}
+			switch {
+			case result.Directive == directiveSkip:
+				tc.Skipped = &junitMessage{Message: result.Description}
+				suite.Skipped++
+			case result.KnownFailure != "":
+				tc.Skipped = &junitMessage{Message: "known failure: " + result.KnownFailure}
+				suite.Skipped++
+			case !result.Ok && result.Directive != directiveTODO:
+				tc.Failure = &junitMessage{Message: result.Description, Body: result.Diagnostic}
+				suite.Failures++
+			}
+			suite.TestCases = append(suite.TestCases, tc)
+		}
+		if r.Error != "" {
+			suite.TestCases = append(suite.TestCases, junitTestCase{
+				Name:      r.Name,
+				Classname: r.Name,
+				Error:     &junitMessage{Message: r.Error},
+			})
+			suite.Errors++
+		}
+		suite.Tests = len(suite.TestCases)
+		report.Suites = append(report.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+// writeJSON writes the results and a summary as a JSON document.
+func writeJSON(w io.Writer, results []*testResult) error {
+	summary := map[string]int{
+		statusPass:         0,
+		statusFail:         0,
+		statusSkip:         0,
+		"knownFailures":    0,
+		"unexpectedPasses": 0,
+	}
+	for _, r := range results {
+		summary[r.Status]++
+		summary["unexpectedPasses"] += len(r.UnexpectedPasses)
+		for _, result := range r.Results {
+			if result.KnownFailure != "" {
+				summary["knownFailures"]++
+			}
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "\t")
+	return encoder.Encode(struct {
+		Summary map[string]int `json:"summary"`
+		Tests   []*testResult  `json:"tests"`
+	}{summary, results})
+}
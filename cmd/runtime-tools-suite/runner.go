@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/opencontainers/runtime-tools/validation/util"
+)
+
+const defaultTimeout = 10 * time.Minute
+
+// Test statuses.
+const (
+	statusPass = "pass"
+	statusFail = "fail"
+	statusSkip = "skip"
+)
+
+// testResult is the outcome of running one validation test.
+type testResult struct {
+	Name     string        `json:"name"`
+	Status   string        `json:"status"`
+	Duration time.Duration `json:"durationNs"`
+	ExitCode int           `json:"exitCode"`
+	Error    string        `json:"error,omitempty"`
+	Results  []tapResult   `json:"results"`
+	// UnexpectedPasses names the known failures of the baseline whose
+	// test points all passed.
+	UnexpectedPasses []string `json:"unexpectedPasses,omitempty"`
+	Output           string   `json:"output"`
+	Stderr           string   `json:"stderr,omitempty"`
+}
+
+// runTests runs tests with at most parallel of them at the same time, and
+// returns their results in the same order as tests.
+func runTests(exe string, tests []util.Test, parallel int, timeout time.Duration) []*testResult {
+	results := make([]*testResult, len(tests))
+	indexes := make(chan int)
+
+	var wg sync.WaitGroup
+	for i := 0; i < parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				results[index] = runTest(exe, tests[index], timeout)
+			}
+		}()
+	}
+	for index := range tests {
+		indexes <- index
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// runTest runs a single test in a child process, so that tests calling
+// os.Exit or writing TAP to stdout do not interfere with each other.
+// The child is this executable invoked as "<name>.t".
+func runTest(exe string, test util.Test, timeout time.Duration) *testResult {
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, exe)
+	cmd.Args = []string{test.Name + ".t"}
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// Runtime processes started by the test may keep the pipes open after
+	// the test itself has been killed.
+	cmd.WaitDelay = 10 * time.Second
+
+	start := time.Now()
+	err := cmd.Run()
+	r := &testResult{
+		Name:     test.Name,
+		Duration: time.Since(start),
+		Results:  parseTAP(strings.NewReader(stdout.String())),
+		Output:   stdout.String(),
+		Stderr:   stderr.String(),
+	}
+
+	var exitErr *exec.ExitError
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		r.Status = statusFail
+		r.ExitCode = -1
+		r.Error = fmt.Sprintf("timed out after %s", timeout)
+	case errors.As(err, &exitErr):
+		r.Status = statusFail
+		r.ExitCode = exitErr.ExitCode()
+		r.Error = err.Error()
+	case err != nil:
+		r.Status = statusFail
+		r.ExitCode = -1
+		r.Error = err.Error()
+	default:
+		r.Status = tapStatus(r.Results)
+		if len(r.Results) == 0 {
+			r.Error = "no TAP results"
+		}
+	}
+
+	return r
+}
+
+// tapStatus summarizes the results of a test which exited successfully.
+func tapStatus(results []tapResult) string {
+	if len(results) == 0 {
+		return statusFail
+	}
+	skipped := 0
+	for _, result := range results {
+		if !result.Ok && result.Directive != directiveTODO {
+			return statusFail
+		}
+		if result.Directive == directiveSkip {
+			skipped++
+		}
+	}
+	if skipped == len(results) {
+		return statusSkip
+	}
+	return statusPass
+}
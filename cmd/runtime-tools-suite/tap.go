@@ -0,0 +1,30 @@
+package main
+
+import (
+	"io"
+
+	"github.com/opencontainers/runtime-tools/validation/util"
+)
+
+// TAP directives.
+const (
+	directiveSkip = util.TAPDirectiveSkip
+	directiveTODO = util.TAPDirectiveTODO
+)
+
+// tapResult is a single test point of a TAP stream.
+type tapResult struct {
+	util.TAPResult
+	// KnownFailure is the reason given by the baseline for a test
+	// point which is expected to fail.
+	KnownFailure string `json:"knownFailure,omitempty"`
+}
+
+// parseTAP parses the test points of the TAP stream in r.
+func parseTAP(r io.Reader) []tapResult {
+	var results []tapResult
+	for _, result := range util.ParseTAP(r) {
+		results = append(results, tapResult{TAPResult: result})
+	}
+	return results
+}
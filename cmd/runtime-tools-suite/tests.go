@@ -0,0 +1,68 @@
+package main
+
+// Every validation test registers itself with util.RegisterTest when its
+// package is initialized.
+import (
+	_ "github.com/opencontainers/runtime-tools/validation/config_updates_without_affect"
+	_ "github.com/opencontainers/runtime-tools/validation/create"
+	_ "github.com/opencontainers/runtime-tools/validation/default"
+	_ "github.com/opencontainers/runtime-tools/validation/delete"
+	_ "github.com/opencontainers/runtime-tools/validation/delete_only_create_resources"
+	_ "github.com/opencontainers/runtime-tools/validation/delete_resources"
+	_ "github.com/opencontainers/runtime-tools/validation/hooks"
+	_ "github.com/opencontainers/runtime-tools/validation/hooks_stdin"
+	_ "github.com/opencontainers/runtime-tools/validation/hostname"
+	_ "github.com/opencontainers/runtime-tools/validation/kill"
+	_ "github.com/opencontainers/runtime-tools/validation/kill_no_effect"
+	_ "github.com/opencontainers/runtime-tools/validation/killsig"
+	_ "github.com/opencontainers/runtime-tools/validation/linux_cgroups_blkio"
+	_ "github.com/opencontainers/runtime-tools/validation/linux_cgroups_cpus"
+	_ "github.com/opencontainers/runtime-tools/validation/linux_cgroups_devices"
+	_ "github.com/opencontainers/runtime-tools/validation/linux_cgroups_hugetlb"
+	_ "github.com/opencontainers/runtime-tools/validation/linux_cgroups_memory"
+	_ "github.com/opencontainers/runtime-tools/validation/linux_cgroups_network"
+	_ "github.com/opencontainers/runtime-tools/validation/linux_cgroups_pids"
+	_ "github.com/opencontainers/runtime-tools/validation/linux_cgroups_rdma"
+	_ "github.com/opencontainers/runtime-tools/validation/linux_cgroups_relative_blkio"
+	_ "github.com/opencontainers/runtime-tools/validation/linux_cgroups_relative_cpus"
+	_ "github.com/opencontainers/runtime-tools/validation/linux_cgroups_relative_devices"
+	_ "github.com/opencontainers/runtime-tools/validation/linux_cgroups_relative_hugetlb"
+	_ "github.com/opencontainers/runtime-tools/validation/linux_cgroups_relative_memory"
+	_ "github.com/opencontainers/runtime-tools/validation/linux_cgroups_relative_network"
+	_ "github.com/opencontainers/runtime-tools/validation/linux_cgroups_relative_pids"
+	_ "github.com/opencontainers/runtime-tools/validation/linux_devices"
+	_ "github.com/opencontainers/runtime-tools/validation/linux_intelrdt"
+	_ "github.com/opencontainers/runtime-tools/validation/linux_masked_paths"
+	_ "github.com/opencontainers/runtime-tools/validation/linux_mount_label"
+	_ "github.com/opencontainers/runtime-tools/validation/linux_ns_itype"
+	_ "github.com/opencontainers/runtime-tools/validation/linux_ns_nopath"
+	_ "github.com/opencontainers/runtime-tools/validation/linux_ns_path"
+	_ "github.com/opencontainers/runtime-tools/validation/linux_ns_path_type"
+	_ "github.com/opencontainers/runtime-tools/validation/linux_process_apparmor_profile"
+	_ "github.com/opencontainers/runtime-tools/validation/linux_readonly_paths"
+	_ "github.com/opencontainers/runtime-tools/validation/linux_rootfs_propagation"
+	_ "github.com/opencontainers/runtime-tools/validation/linux_seccomp"
+	_ "github.com/opencontainers/runtime-tools/validation/linux_sysctl"
+	_ "github.com/opencontainers/runtime-tools/validation/linux_time_offsets"
+	_ "github.com/opencontainers/runtime-tools/validation/linux_uid_mappings"
+	_ "github.com/opencontainers/runtime-tools/validation/misc_props"
+	_ "github.com/opencontainers/runtime-tools/validation/mounts"
+	_ "github.com/opencontainers/runtime-tools/validation/pidfile"
+	_ "github.com/opencontainers/runtime-tools/validation/poststart"
+	_ "github.com/opencontainers/runtime-tools/validation/poststart_fail"
+	_ "github.com/opencontainers/runtime-tools/validation/poststop"
+	_ "github.com/opencontainers/runtime-tools/validation/poststop_fail"
+	_ "github.com/opencontainers/runtime-tools/validation/prestart"
+	_ "github.com/opencontainers/runtime-tools/validation/prestart_fail"
+	_ "github.com/opencontainers/runtime-tools/validation/process"
+	_ "github.com/opencontainers/runtime-tools/validation/process_capabilities"
+	_ "github.com/opencontainers/runtime-tools/validation/process_capabilities_fail"
+	_ "github.com/opencontainers/runtime-tools/validation/process_oom_score_adj"
+	_ "github.com/opencontainers/runtime-tools/validation/process_rlimits"
+	_ "github.com/opencontainers/runtime-tools/validation/process_rlimits_fail"
+	_ "github.com/opencontainers/runtime-tools/validation/process_terminal"
+	_ "github.com/opencontainers/runtime-tools/validation/process_user"
+	_ "github.com/opencontainers/runtime-tools/validation/root_readonly_true"
+	_ "github.com/opencontainers/runtime-tools/validation/start"
+	_ "github.com/opencontainers/runtime-tools/validation/state"
+)
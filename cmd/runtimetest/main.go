@@ -13,6 +13,7 @@ import (
 	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/mndrix/tap-go"
 	"github.com/moby/sys/capability"
@@ -23,6 +24,7 @@ import (
 	"github.com/moby/sys/mountinfo"
 	rfc2119 "github.com/opencontainers/runtime-tools/error"
 	"github.com/opencontainers/runtime-tools/specerror"
+	"github.com/opencontainers/runtime-tools/validation/util"
 	"github.com/opencontainers/selinux/go-selinux/label"
 
 	"golang.org/x/sys/unix"
@@ -46,6 +48,10 @@ const PrGetNoNewPrivs = 39
 
 const specConfig = "config.json"
 
+// timeOffsetTolerance bounds the time the runtime may take to start the
+// container after the host clocks were read.
+const timeOffsetTolerance = time.Minute
+
 var (
 	defaultFS = map[string]string{
 		"/proc":    "proc",
@@ -223,6 +229,46 @@ func (c *complianceTester) validateProcess(spec *rspec.Spec) error {
 	return nil
 }
 
+func (c *complianceTester) validateTerminal(spec *rspec.Spec) error {
+	if spec.Process == nil || !spec.Process.Terminal {
+		c.harness.Skip(1, "process.terminal not set")
+		return nil
+	}
+
+	for fd, name := range []string{"stdin", "stdout", "stderr"} {
+		_, err := unix.IoctlGetTermios(fd, unix.TCGETS)
+		c.harness.Ok(err == nil, fmt.Sprintf("%s is a terminal", name))
+		if err != nil {
+			_ = c.harness.YAML(map[string]string{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	if spec.Process.ConsoleSize == nil {
+		c.harness.Skip(1, "process.consoleSize not set")
+		return nil
+	}
+	ws, err := unix.IoctlGetWinsize(1, unix.TIOCGWINSZ)
+	if err != nil {
+		c.harness.Fail("has expected console size")
+		_ = c.harness.YAML(map[string]string{
+			"error": err.Error(),
+		})
+		return nil
+	}
+	c.harness.Ok(uint(ws.Row) == spec.Process.ConsoleSize.Height && uint(ws.Col) == spec.Process.ConsoleSize.Width, "has expected console size")
+	_ = c.harness.YAML(map[string]any{
+		"expected": spec.Process.ConsoleSize,
+		"actual": map[string]uint16{
+			"height": ws.Row,
+			"width":  ws.Col,
+		},
+	})
+
+	return nil
+}
+
 func (c *complianceTester) validateLinuxProcess(spec *rspec.Spec) error {
 	if spec.Process == nil {
 		c.harness.Skip(1, "process not set")
@@ -365,6 +411,7 @@ func (c *complianceTester) validateRlimits(spec *rspec.Spec) error {
 		_ = c.harness.YAML(map[string]any{
 			"level":     rfcError.Level.String(),
 			"reference": rfcError.Reference,
+			"code":      specerror.PosixProcRlimitsSoftMatchCur.String(),
 			"type":      r.Type,
 			"expected":  r.Soft,
 			"actual":    rlimit.Cur,
@@ -377,6 +424,7 @@ func (c *complianceTester) validateRlimits(spec *rspec.Spec) error {
 		_ = c.harness.YAML(map[string]any{
 			"level":     rfcError.Level.String(),
 			"reference": rfcError.Reference,
+			"code":      specerror.PosixProcRlimitsHardMatchMax.String(),
 			"type":      r.Type,
 			"expected":  r.Hard,
 			"actual":    rlimit.Max,
@@ -523,6 +571,7 @@ func (c *complianceTester) validateRootFS(spec *rspec.Spec) error {
 		_ = c.harness.YAML(map[string]string{
 			"level":     rfcError.Level.String(),
 			"reference": rfcError.Reference,
+			"code":      specerror.RootReadonlyImplement.String(),
 		})
 	} else if !writable {
 		c.harness.Skip(1, "root.readonly is false but the root filesystem is still not writable")
@@ -634,6 +683,7 @@ func (c *complianceTester) validateDefaultFS(spec *rspec.Spec) error {
 		_ = c.harness.YAML(map[string]string{
 			"level":     rfcError.Level.String(),
 			"reference": rfcError.Reference,
+			"code":      specerror.DefaultFilesystems.String(),
 			"mount":     fs,
 			"expected":  fstype,
 			"actual":    mountsMap[fs],
@@ -680,6 +730,7 @@ func (c *complianceTester) validateDevice(device *rspec.LinuxDevice, condition s
 	_ = c.harness.YAML(map[string]string{
 		"level":     rfcError.Level.String(),
 		"reference": rfcError.Reference,
+		"code":      condition.String(),
 		"path":      device.Path,
 	})
 	if !exists {
@@ -712,6 +763,7 @@ func (c *complianceTester) validateDevice(device *rspec.LinuxDevice, condition s
 	_ = c.harness.YAML(map[string]string{
 		"level":     rfcError.Level.String(),
 		"reference": rfcError.Reference,
+		"code":      condition.String(),
 		"path":      device.Path,
 		"expected":  expectedType,
 		"actual":    devType,
@@ -731,6 +783,7 @@ func (c *complianceTester) validateDevice(device *rspec.LinuxDevice, condition s
 		_ = c.harness.YAML(map[string]any{
 			"level":     rfcError.Level.String(),
 			"reference": rfcError.Reference,
+			"code":      condition.String(),
 			"path":      device.Path,
 			"expected":  device.Major,
 			"actual":    major,
@@ -742,6 +795,7 @@ func (c *complianceTester) validateDevice(device *rspec.LinuxDevice, condition s
 		_ = c.harness.YAML(map[string]any{
 			"level":     rfcError.Level.String(),
 			"reference": rfcError.Reference,
+			"code":      condition.String(),
 			"path":      device.Path,
 			"expected":  device.Minor,
 			"actual":    minor,
@@ -760,17 +814,13 @@ func (c *complianceTester) validateDevice(device *rspec.LinuxDevice, condition s
 		_ = c.harness.YAML(map[string]any{
 			"level":     rfcError.Level.String(),
 			"reference": rfcError.Reference,
+			"code":      condition.String(),
 			"path":      device.Path,
 			"expected":  expectedPerm,
 			"actual":    actualPerm,
 		})
 	}
 
-	if description == "/dev/console (default device)" {
-		c.harness.Todo().Fail("we need the major/minor from the controlling TTY")
-		return nil
-	}
-
 	if device.UID == nil {
 		c.harness.Skip(1, fmt.Sprintf("%s has an unconfigured user ID", description))
 	} else {
@@ -781,6 +831,7 @@ func (c *complianceTester) validateDevice(device *rspec.LinuxDevice, condition s
 		_ = c.harness.YAML(map[string]any{
 			"level":     rfcError.Level.String(),
 			"reference": rfcError.Reference,
+			"code":      condition.String(),
 			"path":      device.Path,
 			"expected":  *device.UID,
 			"actual":    fStat.Uid,
@@ -797,6 +848,7 @@ func (c *complianceTester) validateDevice(device *rspec.LinuxDevice, condition s
 		_ = c.harness.YAML(map[string]any{
 			"level":     rfcError.Level.String(),
 			"reference": rfcError.Reference,
+			"code":      condition.String(),
 			"path":      device.Path,
 			"expected":  *device.GID,
 			"actual":    fStat.Gid,
@@ -824,6 +876,7 @@ func (c *complianceTester) validateDefaultSymlinks(spec *rspec.Spec) error {
 		_ = c.harness.YAML(map[string]string{
 			"level":     rfcError.Level.String(),
 			"reference": rfcError.Reference,
+			"code":      specerror.DefaultRuntimeLinuxSymlinks.String(),
 			"path":      symlink,
 		})
 		if !exists {
@@ -842,6 +895,7 @@ func (c *complianceTester) validateDefaultSymlinks(spec *rspec.Spec) error {
 		_ = c.harness.YAML(map[string]any{
 			"level":     rfcError.Level.String(),
 			"reference": rfcError.Reference,
+			"code":      specerror.DefaultRuntimeLinuxSymlinks.String(),
 			"path":      symlink,
 			"mode":      fi.Mode(),
 		})
@@ -864,6 +918,7 @@ func (c *complianceTester) validateDefaultSymlinks(spec *rspec.Spec) error {
 		_ = c.harness.YAML(map[string]string{
 			"level":     rfcError.Level.String(),
 			"reference": rfcError.Reference,
+			"code":      specerror.DefaultRuntimeLinuxSymlinks.String(),
 			"path":      symlink,
 			"expected":  dest,
 			"actual":    realDest,
@@ -875,10 +930,17 @@ func (c *complianceTester) validateDefaultSymlinks(spec *rspec.Spec) error {
 
 func (c *complianceTester) validateDefaultDevices(spec *rspec.Spec) error {
 	if spec.Process != nil && spec.Process.Terminal {
+		// /dev/console is a bind mount of the pseudoterminal slave,
+		// which is also our standard input.
+		var stdin unix.Stat_t
+		if err := unix.Fstat(0, &stdin); err != nil {
+			return err
+		}
 		defaultDevices = append(defaultDevices, rspec.LinuxDevice{
-			Path: "/dev/console",
-			Type: "c",
-			// FIXME: get the major/minor from the controlling TTY
+			Path:  "/dev/console",
+			Type:  "c",
+			Major: int64(unix.Major(stdin.Rdev)),
+			Minor: int64(unix.Minor(stdin.Rdev)),
 		})
 	}
 
@@ -995,6 +1057,7 @@ func (c *complianceTester) validateOOMScoreAdj(spec *rspec.Spec) error {
 		_ = c.harness.YAML(map[string]any{
 			"level":     rfcError.Level.String(),
 			"reference": rfcError.Reference,
+			"code":      specerror.LinuxProcOomScoreAdjSet.String(),
 			"expected":  expected,
 			"actual":    actual,
 		})
@@ -1087,6 +1150,114 @@ func (c *complianceTester) validateGIDMappings(spec *rspec.Spec) error {
 	return c.validateIDMappings(spec.Linux.GIDMappings, "/proc/self/gid_map", "linux.gidMappings")
 }
 
+// getTimeNamespaceOffsets parses /proc/self/timens_offsets.  "man 7
+// time_namespaces" explains the format: <clock-id> <offset-secs> <offset-nanosecs>,
+// where the clock is reported either by name or by its numeric clock ID.
+func getTimeNamespaceOffsets(path string) (map[string]rspec.LinuxTimeOffset, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	offsets := make(map[string]rspec.LinuxTimeOffset)
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		if err := s.Err(); err != nil {
+			return nil, err
+		}
+
+		fields := strings.Fields(s.Text())
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("invalid format in %v", path)
+		}
+
+		clock := fields[0]
+		switch clock {
+		case strconv.Itoa(unix.CLOCK_MONOTONIC):
+			clock = "monotonic"
+		case strconv.Itoa(unix.CLOCK_BOOTTIME):
+			clock = "boottime"
+		}
+		secs, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		nanosecs, err := strconv.ParseUint(fields[2], 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		offsets[clock] = rspec.LinuxTimeOffset{Secs: secs, Nanosecs: uint32(nanosecs)}
+	}
+
+	return offsets, nil
+}
+
+func (c *complianceTester) validateTimeOffsets(spec *rspec.Spec) error {
+	if spec.Linux == nil || len(spec.Linux.TimeOffsets) == 0 {
+		c.harness.Skip(1, "linux.timeOffsets not set")
+		return nil
+	}
+
+	offsets, err := getTimeNamespaceOffsets("/proc/self/timens_offsets")
+	if os.IsNotExist(err) {
+		c.harness.Skip(1, "/proc/self/timens_offsets is not available (time namespaces are not supported by the kernel)")
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	clockIDs := map[string]int32{
+		"monotonic": unix.CLOCK_MONOTONIC,
+		"boottime":  unix.CLOCK_BOOTTIME,
+	}
+
+	for clock, expected := range spec.Linux.TimeOffsets {
+		actual, ok := offsets[clock]
+		c.harness.Ok(ok && actual == expected, fmt.Sprintf("has expected %s time offset", clock))
+		_ = c.harness.YAML(map[string]any{
+			"clock":    clock,
+			"expected": expected,
+			"actual":   actual,
+		})
+
+		clockID, ok := clockIDs[clock]
+		if !ok {
+			continue
+		}
+		description := fmt.Sprintf("CLOCK_%s reflects the configured time offset", strings.ToUpper(clock))
+		hostEnv := util.TimeOffsetHostClockEnv + strings.ToUpper(clock)
+		hostValue := os.Getenv(hostEnv)
+		if hostValue == "" {
+			c.harness.Skip(1, fmt.Sprintf("%s not set, the host clock is unknown", hostEnv))
+			continue
+		}
+		hostNanos, err := strconv.ParseInt(hostValue, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s %q: %w", hostEnv, hostValue, err)
+		}
+		var ts unix.Timespec
+		if err := unix.ClockGettime(clockID, &ts); err != nil {
+			return err
+		}
+		// The host clock was read just before the container was created,
+		// so the container clock is ahead of it by the offset plus the
+		// time the runtime took to start the container.
+		offset := time.Duration(expected.Secs)*time.Second + time.Duration(expected.Nanosecs)
+		elapsed := time.Duration(ts.Nano()-hostNanos) - offset
+		c.harness.Ok(elapsed >= 0 && elapsed <= timeOffsetTolerance, description)
+		_ = c.harness.YAML(map[string]any{
+			"clock":     clock,
+			"offset":    offset.String(),
+			"host":      time.Duration(hostNanos).String(),
+			"container": time.Duration(ts.Nano()).String(),
+			"tolerance": timeOffsetTolerance.String(),
+		})
+	}
+
+	return nil
+}
+
 func mountMatch(configMount rspec.Mount, sysMount *mountinfo.Info) error {
 	sys := rspec.Mount{
 		Destination: sysMount.Mountpoint,
@@ -1189,6 +1360,7 @@ func (c *complianceTester) validatePosixMounts(spec *rspec.Spec) error {
 		_ = c.harness.YAML(map[string]any{
 			"level":       rfcError.Level.String(),
 			"reference":   rfcError.Reference,
+			"code":        specerror.MountsInOrder.String(),
 			"config":      configMount,
 			"indexConfig": i,
 			"indexSystem": configSys[i],
@@ -1298,6 +1470,7 @@ func run(context *cli.Context) error {
 		c.validateDefaultDevices,
 		c.validateLinuxDevices,
 		c.validateLinuxProcess,
+		c.validateTerminal,
 		c.validateMaskedPaths,
 		c.validateOOMScoreAdj,
 		c.validateSeccomp,
@@ -1306,6 +1479,7 @@ func run(context *cli.Context) error {
 		c.validateSysctls,
 		c.validateUIDMappings,
 		c.validateGIDMappings,
+		c.validateTimeOffsets,
 		c.validateMountLabel,
 		c.validateApparmorProfile,
 	}
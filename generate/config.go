@@ -130,6 +130,13 @@ func (g *Generator) initConfigLinuxResourcesPids() {
 	}
 }
 
+func (g *Generator) initConfigLinuxResourcesRdma() {
+	g.initConfigLinuxResources()
+	if g.Config.Linux.Resources.Rdma == nil {
+		g.Config.Linux.Resources.Rdma = map[string]rspec.LinuxRdma{}
+	}
+}
+
 func (g *Generator) initConfigLinuxResourcesUnified() {
 	g.initConfigLinuxResources()
 	if g.Config.Linux.Resources.Unified == nil {
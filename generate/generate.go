@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/moby/sys/capability"
@@ -633,6 +634,30 @@ func (g *Generator) SetLinuxIntelRdtL3CacheSchema(schema string) {
 	g.Config.Linux.IntelRdt.L3CacheSchema = schema
 }
 
+// SetLinuxIntelRdtMemBwSchema sets g.Config.Linux.IntelRdt.MemBwSchema
+func (g *Generator) SetLinuxIntelRdtMemBwSchema(schema string) {
+	g.initConfigLinuxIntelRdt()
+	g.Config.Linux.IntelRdt.MemBwSchema = schema
+}
+
+// SetLinuxIntelRdtSchemata sets g.Config.Linux.IntelRdt.Schemata
+func (g *Generator) SetLinuxIntelRdtSchemata(schemata []string) {
+	g.initConfigLinuxIntelRdt()
+	g.Config.Linux.IntelRdt.Schemata = schemata
+}
+
+// AddLinuxIntelRdtSchemata adds a line into g.Config.Linux.IntelRdt.Schemata
+func (g *Generator) AddLinuxIntelRdtSchemata(schema string) {
+	g.initConfigLinuxIntelRdt()
+	g.Config.Linux.IntelRdt.Schemata = append(g.Config.Linux.IntelRdt.Schemata, schema)
+}
+
+// SetLinuxIntelRdtEnableMonitoring sets g.Config.Linux.IntelRdt.EnableMonitoring
+func (g *Generator) SetLinuxIntelRdtEnableMonitoring(enable bool) {
+	g.initConfigLinuxIntelRdt()
+	g.Config.Linux.IntelRdt.EnableMonitoring = enable
+}
+
 // SetLinuxTimeOffset sets g.Config.Linux.TimeOffsets[clock]
 func (g *Generator) SetLinuxTimeOffset(clock string, offset rspec.LinuxTimeOffset) {
 	g.initConfigLinuxTimeOffsets()
@@ -819,6 +844,12 @@ func (g *Generator) SetLinuxResourcesCPUQuota(quota int64) {
 	g.Config.Linux.Resources.CPU.Quota = &quota
 }
 
+// SetLinuxResourcesCPUBurst sets g.Config.Linux.Resources.CPU.Burst.
+func (g *Generator) SetLinuxResourcesCPUBurst(burst uint64) {
+	g.InitConfigLinuxResourcesCPU()
+	g.Config.Linux.Resources.CPU.Burst = &burst
+}
+
 // SetLinuxResourcesCPUPeriod sets g.Config.Linux.Resources.CPU.Period.
 func (g *Generator) SetLinuxResourcesCPUPeriod(period uint64) {
 	g.InitConfigLinuxResourcesCPU()
@@ -837,6 +868,12 @@ func (g *Generator) SetLinuxResourcesCPURealtimePeriod(period uint64) {
 	g.Config.Linux.Resources.CPU.RealtimePeriod = &period
 }
 
+// SetLinuxResourcesCPUIdle sets g.Config.Linux.Resources.CPU.Idle.
+func (g *Generator) SetLinuxResourcesCPUIdle(idle int64) {
+	g.InitConfigLinuxResourcesCPU()
+	g.Config.Linux.Resources.CPU.Idle = &idle
+}
+
 // SetLinuxResourcesCPUCpus sets g.Config.Linux.Resources.CPU.Cpus.
 func (g *Generator) SetLinuxResourcesCPUCpus(cpus string) {
 	g.InitConfigLinuxResourcesCPU()
@@ -880,6 +917,20 @@ func (g *Generator) DropLinuxResourcesHugepageLimit(pageSize string) {
 	}
 }
 
+// AddLinuxResourcesRdma adds or sets g.Config.Linux.Resources.Rdma[device].
+func (g *Generator) AddLinuxResourcesRdma(device string, rdma rspec.LinuxRdma) {
+	g.initConfigLinuxResourcesRdma()
+	g.Config.Linux.Resources.Rdma[device] = rdma
+}
+
+// DropLinuxResourcesRdma drops a device from g.Config.Linux.Resources.Rdma.
+func (g *Generator) DropLinuxResourcesRdma(device string) {
+	if g.Config == nil || g.Config.Linux == nil || g.Config.Linux.Resources == nil {
+		return
+	}
+	delete(g.Config.Linux.Resources.Rdma, device)
+}
+
 // SetLinuxResourcesUnified sets the g.Config.Linux.Resources.Unified.
 func (g *Generator) SetLinuxResourcesUnified(unified map[string]string) {
 	g.initConfigLinuxResourcesUnified()
@@ -944,6 +995,37 @@ func (g *Generator) SetLinuxResourcesMemoryDisableOOMKiller(disable bool) {
 	g.Config.Linux.Resources.Memory.DisableOOMKiller = &disable
 }
 
+// SetLinuxResourcesMemoryUseHierarchy sets g.Config.Linux.Resources.Memory.UseHierarchy.
+func (g *Generator) SetLinuxResourcesMemoryUseHierarchy(useHierarchy bool) {
+	g.initConfigLinuxResourcesMemory()
+	g.Config.Linux.Resources.Memory.UseHierarchy = &useHierarchy
+}
+
+// SetLinuxResourcesMemoryCheckBeforeUpdate sets g.Config.Linux.Resources.Memory.CheckBeforeUpdate.
+func (g *Generator) SetLinuxResourcesMemoryCheckBeforeUpdate(checkBeforeUpdate bool) {
+	g.initConfigLinuxResourcesMemory()
+	g.Config.Linux.Resources.Memory.CheckBeforeUpdate = &checkBeforeUpdate
+}
+
+// SetLinuxResourcesMemoryHigh sets the cgroup v2 memory.high knob through
+// g.Config.Linux.Resources.Unified. A negative value means "max".
+func (g *Generator) SetLinuxResourcesMemoryHigh(high int64) {
+	g.AddLinuxResourcesUnified("memory.high", unifiedMemoryValue(high))
+}
+
+// SetLinuxResourcesMemoryMin sets the cgroup v2 memory.min knob through
+// g.Config.Linux.Resources.Unified. A negative value means "max".
+func (g *Generator) SetLinuxResourcesMemoryMin(min int64) {
+	g.AddLinuxResourcesUnified("memory.min", unifiedMemoryValue(min))
+}
+
+func unifiedMemoryValue(value int64) string {
+	if value < 0 {
+		return "max"
+	}
+	return strconv.FormatInt(value, 10)
+}
+
 // SetLinuxResourcesNetworkClassID sets g.Config.Linux.Resources.Network.ClassID.
 func (g *Generator) SetLinuxResourcesNetworkClassID(classid uint32) {
 	g.initConfigLinuxResourcesNetwork()
@@ -1168,6 +1250,55 @@ func (g *Generator) SetupPrivileged(privileged bool) {
 	}
 }
 
+// SetupRootless adjusts g.Config for a runtime running without root
+// privileges: it adds a user namespace with the given ID mappings, drops
+// the cgroup resources, and removes uid= and gid= mount options for IDs
+// which are not mapped.  When uidMappings or gidMappings is empty, the
+// container's root is mapped to the current effective user or group ID.
+func (g *Generator) SetupRootless(uidMappings, gidMappings []rspec.LinuxIDMapping) {
+	if len(uidMappings) == 0 {
+		uidMappings = []rspec.LinuxIDMapping{{HostID: uint32(os.Geteuid()), ContainerID: 0, Size: 1}}
+	}
+	if len(gidMappings) == 0 {
+		gidMappings = []rspec.LinuxIDMapping{{HostID: uint32(os.Getegid()), ContainerID: 0, Size: 1}}
+	}
+
+	g.initConfigLinux()
+	g.AddOrReplaceLinuxNamespace("user", "")
+	g.Config.Linux.UIDMappings = slices.Clone(uidMappings)
+	g.Config.Linux.GIDMappings = slices.Clone(gidMappings)
+	// Cgroups can only be set up in a subtree delegated to the user.
+	g.Config.Linux.Resources = nil
+
+	for i, mount := range g.Config.Mounts {
+		var options []string
+		for _, option := range mount.Options {
+			if value, ok := strings.CutPrefix(option, "uid="); ok && !idMapped(uidMappings, value) {
+				continue
+			}
+			if value, ok := strings.CutPrefix(option, "gid="); ok && !idMapped(gidMappings, value) {
+				continue
+			}
+			options = append(options, option)
+		}
+		g.Config.Mounts[i].Options = options
+	}
+}
+
+// idMapped reports whether the container ID id is in mappings.
+func idMapped(mappings []rspec.LinuxIDMapping, id string) bool {
+	n, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return false
+	}
+	for _, m := range mappings {
+		if uint64(m.ContainerID) <= n && n < uint64(m.ContainerID)+uint64(m.Size) {
+			return true
+		}
+	}
+	return false
+}
+
 // ClearProcessCapabilities clear g.Config.Process.Capabilities.
 func (g *Generator) ClearProcessCapabilities() {
 	if g.Config == nil || g.Config.Process == nil || g.Config.Process.Capabilities == nil {
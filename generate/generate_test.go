@@ -6,6 +6,7 @@ import (
 	"runtime"
 	"testing"
 
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
 	rfc2119 "github.com/opencontainers/runtime-tools/error"
 	"github.com/opencontainers/runtime-tools/generate"
 	"github.com/opencontainers/runtime-tools/specerror"
@@ -94,6 +95,39 @@ func TestRemoveMount(t *testing.T) {
 	}
 }
 
+func TestSetupRootless(t *testing.T) {
+	g, err := generate.New("linux")
+	if err != nil {
+		t.Fatal(err)
+	}
+	uidMappings := []rspec.LinuxIDMapping{{HostID: 1000, ContainerID: 0, Size: 1}}
+	gidMappings := []rspec.LinuxIDMapping{{HostID: 1000, ContainerID: 0, Size: 1}, {HostID: 100000, ContainerID: 1, Size: 65536}}
+	g.SetupRootless(uidMappings, gidMappings)
+
+	assert.Contains(t, g.Config.Linux.Namespaces, rspec.LinuxNamespace{Type: rspec.UserNamespace})
+	assert.Equal(t, uidMappings, g.Config.Linux.UIDMappings)
+	assert.Equal(t, gidMappings, g.Config.Linux.GIDMappings)
+	assert.Nil(t, g.Config.Linux.Resources)
+	for _, mount := range g.Mounts() {
+		if mount.Destination == "/dev/pts" {
+			// gid 5 is mapped by the second GID mapping.
+			assert.Contains(t, mount.Options, "gid=5")
+		}
+	}
+
+	g, err = generate.New("linux")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.SetupRootless(uidMappings, nil)
+	assert.Len(t, g.Config.Linux.GIDMappings, 1)
+	for _, mount := range g.Mounts() {
+		if mount.Destination == "/dev/pts" {
+			assert.NotContains(t, mount.Options, "gid=5")
+		}
+	}
+}
+
 func TestEnvCaching(t *testing.T) {
 	// Start with empty ENV and add a few
 	g, err := generate.New("windows")
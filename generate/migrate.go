@@ -0,0 +1,236 @@
+package generate
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// MigrationAction is the kind of a MigrationChange.
+type MigrationAction string
+
+const (
+	// MigrationMove moves a value to another property.
+	MigrationMove MigrationAction = "move"
+	// MigrationRemove drops a property.
+	MigrationRemove MigrationAction = "remove"
+	// MigrationNormalize rewrites a value into its canonical form.
+	MigrationNormalize MigrationAction = "normalize"
+	// MigrationUpdate sets a property to a new value.
+	MigrationUpdate MigrationAction = "update"
+)
+
+// MigrationChange records a change made by Migrate.
+type MigrationChange struct {
+	Action MigrationAction `json:"action"`
+	// Path locates the changed property in the configuration.
+	Path string `json:"path"`
+	// NewPath locates the property a value was moved to.
+	NewPath string `json:"newPath,omitempty"`
+	Old     any    `json:"old,omitempty"`
+	New     any    `json:"new,omitempty"`
+	// Warning is set when the change may affect the container.
+	Warning string `json:"warning,omitempty"`
+}
+
+// Migrate rewrites the configuration for the runtime-spec release to,
+// replacing deprecated properties and normalizing values, and returns
+// the changes it made.  Only upgrades up to rspec.Version are supported.
+func (g *Generator) Migrate(to string) ([]MigrationChange, error) {
+	target, err := semver.Parse(to)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target version %q: %w", to, err)
+	}
+	if target.GT(semver.MustParse(rspec.Version)) {
+		return nil, fmt.Errorf("cannot migrate to %s, the latest runtime-spec release known to runtime-tools is %s", to, rspec.Version)
+	}
+	from, err := semver.Parse(g.Config.Version)
+	if err != nil {
+		return nil, fmt.Errorf("cannot migrate from ociVersion %q: %w", g.Config.Version, err)
+	}
+	if target.LT(from) {
+		return nil, fmt.Errorf("cannot migrate from %s down to %s", g.Config.Version, to)
+	}
+
+	if err := g.checkRlimits(); err != nil {
+		return nil, err
+	}
+
+	var changes []MigrationChange
+	if target.GTE(semver.Version{Major: 1, Minor: 0, Patch: 2}) {
+		changes = append(changes, g.migratePrestartHooks()...)
+	}
+	if target.GTE(semver.Version{Major: 1, Minor: 1, Patch: 0}) {
+		changes = append(changes, g.migrateKernelMemory()...)
+	}
+	changes = append(changes, g.migrateMountDestinations()...)
+	changes = append(changes, g.migrateCapabilities()...)
+	changes = append(changes, g.migrateRlimits()...)
+
+	if g.Config.Version != to {
+		changes = append(changes, MigrationChange{
+			Action: MigrationUpdate,
+			Path:   "ociVersion",
+			Old:    g.Config.Version,
+			New:    to,
+		})
+		g.Config.Version = to
+	}
+	return changes, nil
+}
+
+// migratePrestartHooks moves the deprecated prestart hooks to the
+// createRuntime hooks, which are called at the same point of the
+// lifecycle.  Prestart hooks are called first, so they are prepended.
+func (g *Generator) migratePrestartHooks() []MigrationChange {
+	if g.Config.Hooks == nil || len(g.Config.Hooks.Prestart) == 0 { //nolint:staticcheck // Ignore SA1019: g.Config.Hooks.Prestart is deprecated
+		return nil
+	}
+	prestart := g.Config.Hooks.Prestart //nolint:staticcheck // Ignore SA1019: g.Config.Hooks.Prestart is deprecated
+	g.Config.Hooks.CreateRuntime = append(slices.Clone(prestart), g.Config.Hooks.CreateRuntime...)
+	g.Config.Hooks.Prestart = nil //nolint:staticcheck // Ignore SA1019: g.Config.Hooks.Prestart is deprecated
+	return []MigrationChange{{
+		Action:  MigrationMove,
+		Path:    "hooks.prestart",
+		NewPath: "hooks.createRuntime",
+		Old:     prestart,
+	}}
+}
+
+// migrateKernelMemory drops the kernel memory limit, which is NOT
+// RECOMMENDED since 1.1.0 and is not supported with cgroup v2.
+func (g *Generator) migrateKernelMemory() []MigrationChange {
+	if g.Config.Linux == nil || g.Config.Linux.Resources == nil || g.Config.Linux.Resources.Memory == nil {
+		return nil
+	}
+	memory := g.Config.Linux.Resources.Memory
+	if memory.Kernel == nil { //nolint:staticcheck // Ignore SA1019: memory.Kernel is deprecated
+		return nil
+	}
+	kernel := *memory.Kernel //nolint:staticcheck // Ignore SA1019: memory.Kernel is deprecated
+	memory.Kernel = nil      //nolint:staticcheck // Ignore SA1019: memory.Kernel is deprecated
+	return []MigrationChange{{
+		Action:  MigrationRemove,
+		Path:    "linux.resources.memory.kernel",
+		Old:     kernel,
+		Warning: "the kernel memory limit is no longer applied",
+	}}
+}
+
+// migrateMountDestinations makes relative Linux mount destinations,
+// which are interpreted as relative to "/", absolute.
+func (g *Generator) migrateMountDestinations() []MigrationChange {
+	if g.Config.Linux == nil {
+		return nil
+	}
+	var changes []MigrationChange
+	for i, mount := range g.Config.Mounts {
+		if strings.HasPrefix(mount.Destination, "/") {
+			continue
+		}
+		destination := path.Join("/", mount.Destination)
+		g.Config.Mounts[i].Destination = destination
+		changes = append(changes, MigrationChange{
+			Action: MigrationNormalize,
+			Path:   fmt.Sprintf("mounts[%d].destination", i),
+			Old:    mount.Destination,
+			New:    destination,
+		})
+	}
+	return changes
+}
+
+// migrateCapabilities writes capabilities in upper case with the CAP_
+// prefix, and drops duplicates.
+func (g *Generator) migrateCapabilities() []MigrationChange {
+	if g.Config.Process == nil || g.Config.Process.Capabilities == nil {
+		return nil
+	}
+	caps := g.Config.Process.Capabilities
+	var changes []MigrationChange
+	for _, set := range []struct {
+		name string
+		caps *[]string
+	}{
+		{"bounding", &caps.Bounding},
+		{"effective", &caps.Effective},
+		{"inheritable", &caps.Inheritable},
+		{"permitted", &caps.Permitted},
+		{"ambient", &caps.Ambient},
+	} {
+		var normalized []string
+		for _, c := range *set.caps {
+			c = strings.ToUpper(strings.TrimSpace(c))
+			if !strings.HasPrefix(c, "CAP_") {
+				c = "CAP_" + c
+			}
+			if !slices.Contains(normalized, c) {
+				normalized = append(normalized, c)
+			}
+		}
+		if !slices.Equal(normalized, *set.caps) {
+			changes = append(changes, MigrationChange{
+				Action: MigrationNormalize,
+				Path:   "process.capabilities." + set.name,
+				Old:    *set.caps,
+				New:    normalized,
+			})
+			*set.caps = normalized
+		}
+	}
+	return changes
+}
+
+// rlimitType writes an rlimit type in upper case with the RLIMIT_ prefix.
+func rlimitType(t string) string {
+	t = strings.ToUpper(strings.TrimSpace(t))
+	if !strings.HasPrefix(t, "RLIMIT_") {
+		t = "RLIMIT_" + t
+	}
+	return t
+}
+
+// checkRlimits fails on rlimits whose types are the same once
+// normalized, since runtimes must reject duplicated types: picking one of
+// them would hide an invalid configuration.
+func (g *Generator) checkRlimits() error {
+	if g.Config.Process == nil {
+		return nil
+	}
+	seen := map[string]string{}
+	for _, rlimit := range g.Config.Process.Rlimits {
+		t := rlimitType(rlimit.Type)
+		if previous, ok := seen[t]; ok {
+			return fmt.Errorf("process.rlimits has duplicated types %q and %q, which runtimes reject", previous, rlimit.Type)
+		}
+		seen[t] = rlimit.Type
+	}
+	return nil
+}
+
+// migrateRlimits writes rlimit types in upper case with the RLIMIT_
+// prefix.  checkRlimits has rejected duplicated types.
+func (g *Generator) migrateRlimits() []MigrationChange {
+	if g.Config.Process == nil || len(g.Config.Process.Rlimits) == 0 {
+		return nil
+	}
+	rlimits := g.Config.Process.Rlimits
+	normalized := slices.Clone(rlimits)
+	for i := range normalized {
+		normalized[i].Type = rlimitType(normalized[i].Type)
+	}
+	if slices.Equal(normalized, rlimits) {
+		return nil
+	}
+	g.Config.Process.Rlimits = normalized
+	return []MigrationChange{{
+		Action: MigrationNormalize,
+		Path:   "process.rlimits",
+		Old:    rlimits,
+		New:    normalized,
+	}}
+}
@@ -0,0 +1,109 @@
+package generate_test
+
+import (
+	"slices"
+	"testing"
+
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/opencontainers/runtime-tools/generate"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrate(t *testing.T) {
+	kernel := int64(1 << 20)
+	config := &rspec.Spec{
+		Version: "1.0.1",
+		Process: &rspec.Process{
+			Capabilities: &rspec.LinuxCapabilities{
+				Bounding: []string{"chown", "CAP_CHOWN", "cap_kill"},
+			},
+			Rlimits: []rspec.POSIXRlimit{
+				{Type: "nofile", Hard: 10, Soft: 10},
+				{Type: "RLIMIT_CORE", Hard: 20, Soft: 20},
+			},
+		},
+		Mounts: []rspec.Mount{{Destination: "proc", Type: "proc"}},
+		Hooks: &rspec.Hooks{
+			Prestart:      []rspec.Hook{{Path: "/bin/prestart"}},
+			CreateRuntime: []rspec.Hook{{Path: "/bin/create-runtime"}},
+		},
+		Linux: &rspec.Linux{
+			Resources: &rspec.LinuxResources{Memory: &rspec.LinuxMemory{Kernel: &kernel}},
+		},
+	}
+	g := generate.NewFromSpec(config)
+
+	changes, err := g.Migrate("1.2.0")
+	assert.NoError(t, err)
+
+	var paths []string
+	for _, change := range changes {
+		paths = append(paths, change.Path)
+	}
+	assert.Equal(t, []string{
+		"hooks.prestart",
+		"linux.resources.memory.kernel",
+		"mounts[0].destination",
+		"process.capabilities.bounding",
+		"process.rlimits",
+		"ociVersion",
+	}, paths)
+
+	assert.Equal(t, "1.2.0", config.Version)
+	assert.Nil(t, config.Hooks.Prestart) //nolint:staticcheck // Ignore SA1019: config.Hooks.Prestart is deprecated
+	assert.Equal(t, []rspec.Hook{{Path: "/bin/prestart"}, {Path: "/bin/create-runtime"}}, config.Hooks.CreateRuntime)
+	assert.Nil(t, config.Linux.Resources.Memory.Kernel) //nolint:staticcheck // Ignore SA1019: config.Linux.Resources.Memory.Kernel is deprecated
+	assert.Equal(t, "/proc", config.Mounts[0].Destination)
+	assert.Equal(t, []string{"CAP_CHOWN", "CAP_KILL"}, config.Process.Capabilities.Bounding)
+	assert.Equal(t, []rspec.POSIXRlimit{{Type: "RLIMIT_NOFILE", Hard: 10, Soft: 10}, {Type: "RLIMIT_CORE", Hard: 20, Soft: 20}}, config.Process.Rlimits)
+}
+
+func TestMigrateDuplicatedRlimits(t *testing.T) {
+	rlimits := []rspec.POSIXRlimit{
+		{Type: "nofile", Hard: 10, Soft: 10},
+		{Type: "RLIMIT_NOFILE", Hard: 20, Soft: 20},
+	}
+	config := &rspec.Spec{
+		Version: "1.0.1",
+		Process: &rspec.Process{Rlimits: slices.Clone(rlimits)},
+	}
+	g := generate.NewFromSpec(config)
+
+	_, err := g.Migrate("1.2.0")
+	assert.Error(t, err)
+	assert.Equal(t, "1.0.1", config.Version)
+	assert.Equal(t, rlimits, config.Process.Rlimits)
+}
+
+func TestMigrateVersions(t *testing.T) {
+	for _, tt := range []struct {
+		from  string
+		to    string
+		valid bool
+	}{
+		{"1.0.2", "1.0.2", true},
+		{"1.0.2", "1.1.0", true},
+		{"1.1.0", "1.0.2", false},
+		{"1.0.2", "99.0.0", false},
+		{"1.0.2", "invalid", false},
+		{"invalid", "1.1.0", false},
+	} {
+		g := generate.NewFromSpec(&rspec.Spec{Version: tt.from})
+		_, err := g.Migrate(tt.to)
+		assert.Equal(t, tt.valid, err == nil, "migrate from %s to %s: %v", tt.from, tt.to, err)
+	}
+}
+
+func TestMigrateKeepsPrestartBefore102(t *testing.T) {
+	config := &rspec.Spec{
+		Version: "1.0.0",
+		Hooks:   &rspec.Hooks{Prestart: []rspec.Hook{{Path: "/bin/prestart"}}},
+	}
+	g := generate.NewFromSpec(config)
+
+	changes, err := g.Migrate("1.0.1")
+	assert.NoError(t, err)
+	assert.Len(t, changes, 1)
+	assert.Equal(t, "ociVersion", changes[0].Path)
+	assert.Len(t, config.Hooks.Prestart, 1) //nolint:staticcheck // Ignore SA1019: config.Hooks.Prestart is deprecated
+}
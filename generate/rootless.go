@@ -0,0 +1,76 @@
+package generate
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// RootlessIDMappings returns the ID mappings for a rootless container:
+// the container's root is mapped to the current user and group, and the
+// following IDs to the first range allotted to the user in /etc/subuid
+// and /etc/subgid, if any.
+func RootlessIDMappings() (uidMappings, gidMappings []rspec.LinuxIDMapping, err error) {
+	uid, gid := os.Geteuid(), os.Getegid()
+	name := ""
+	if u, err := user.LookupId(strconv.Itoa(uid)); err == nil {
+		name = u.Username
+	}
+
+	uidMappings = []rspec.LinuxIDMapping{{HostID: uint32(uid), ContainerID: 0, Size: 1}}
+	start, size, err := subIDRange("/etc/subuid", name, uid)
+	if err != nil {
+		return nil, nil, err
+	}
+	if size > 0 {
+		uidMappings = append(uidMappings, rspec.LinuxIDMapping{HostID: start, ContainerID: 1, Size: size})
+	}
+
+	gidMappings = []rspec.LinuxIDMapping{{HostID: uint32(gid), ContainerID: 0, Size: 1}}
+	start, size, err = subIDRange("/etc/subgid", name, uid)
+	if err != nil {
+		return nil, nil, err
+	}
+	if size > 0 {
+		gidMappings = append(gidMappings, rspec.LinuxIDMapping{HostID: start, ContainerID: 1, Size: size})
+	}
+
+	return uidMappings, gidMappings, nil
+}
+
+// subIDRange returns the first range of a subordinate ID file for the
+// user name or ID, or a zero size if there is none.
+func subIDRange(path string, name string, id int) (start uint32, size uint32, err error) {
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Split(strings.TrimSpace(scanner.Text()), ":")
+		if len(fields) != 3 || (fields[0] != name && fields[0] != strconv.Itoa(id)) {
+			continue
+		}
+		start, err := strconv.ParseUint(fields[1], 10, 32)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid range %q in %s: %w", scanner.Text(), path, err)
+		}
+		size, err := strconv.ParseUint(fields[2], 10, 32)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid range %q in %s: %w", scanner.Text(), path, err)
+		}
+		return uint32(start), uint32(size), nil
+	}
+	return 0, 0, scanner.Err()
+}
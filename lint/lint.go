@@ -0,0 +1,114 @@
+// Package lint reports configurations which are valid according to the
+// runtime-spec, but weaken the isolation of containers.
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// Severity is how much a finding weakens the isolation of the container.
+type Severity int
+
+const (
+	// Low represents a finding worth reviewing.
+	Low Severity = iota
+	// Medium represents a finding which removes a layer of defense.
+	Medium
+	// High represents a finding which gives the container control over
+	// the host, or makes it easy to escape.
+	High
+)
+
+var severities = []string{"low", "medium", "high"}
+
+// String returns the name of s.
+func (s Severity) String() string {
+	if s < Low || s > High {
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+	return severities[s]
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// ParseSeverity returns the severity named s.
+func ParseSeverity(s string) (Severity, error) {
+	for i, name := range severities {
+		if strings.EqualFold(s, name) {
+			return Severity(i), nil
+		}
+	}
+	return Low, fmt.Errorf("%q is not a valid severity (low, medium or high)", s)
+}
+
+// SuppressAnnotationPrefix prefixes the rule ID in the annotation keys
+// suppressing rules.  The value of the annotation should record why the
+// rule does not apply.
+const SuppressAnnotationPrefix = "com.github.opencontainers.runtime-tools.lint.suppress."
+
+// Rule is a check of the security posture of a configuration.
+type Rule struct {
+	ID          string   `json:"id"`
+	Severity    Severity `json:"severity"`
+	Description string   `json:"description"`
+	check       func(spec *rspec.Spec) []string
+}
+
+// Annotation returns the annotation key suppressing r.
+func (r Rule) Annotation() string {
+	return SuppressAnnotationPrefix + r.ID
+}
+
+// Finding is a problem found by a rule.
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s: %s", f.Severity, f.Rule, f.Message)
+}
+
+// Rules returns the rules, sorted by ID.
+func Rules() []Rule {
+	list := make([]Rule, len(rules))
+	copy(list, rules)
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+// LookupRule returns the rule with the given ID.
+func LookupRule(id string) (Rule, bool) {
+	for _, rule := range rules {
+		if rule.ID == id {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// Lint checks spec against the rules which are not suppressed by its
+// annotations, and returns the findings of at least severity min.
+func Lint(spec *rspec.Spec, min Severity) []Finding {
+	var findings []Finding
+	for _, rule := range Rules() {
+		if rule.Severity < min {
+			continue
+		}
+		if _, ok := spec.Annotations[rule.Annotation()]; ok {
+			continue
+		}
+		for _, message := range rule.check(spec) {
+			findings = append(findings, Finding{Rule: rule.ID, Severity: rule.Severity, Message: message})
+		}
+	}
+	return findings
+}
@@ -0,0 +1,105 @@
+package lint_test
+
+import (
+	"testing"
+
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/opencontainers/runtime-tools/generate"
+	"github.com/opencontainers/runtime-tools/lint"
+	"github.com/stretchr/testify/assert"
+)
+
+// hardened returns a linux configuration which passes every rule.
+func hardened(t *testing.T) *rspec.Spec {
+	g, err := generate.New("linux")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.Config.Process.NoNewPrivileges = true
+	g.Config.Process.User.UID = 1000
+	g.Config.Linux.MaskedPaths = lint.DefaultMaskedPaths
+	g.Config.Linux.ReadonlyPaths = lint.DefaultReadonlyPaths
+	return g.Config
+}
+
+func rules(findings []lint.Finding) []string {
+	var ids []string
+	for _, f := range findings {
+		ids = append(ids, f.Rule)
+	}
+	return ids
+}
+
+func TestLint(t *testing.T) {
+	all := int64(0)
+	for _, c := range []struct {
+		name     string
+		modify   func(spec *rspec.Spec)
+		expected []string
+	}{
+		{"hardened", func(spec *rspec.Spec) {}, nil},
+		{"capabilities", func(spec *rspec.Spec) {
+			spec.Process.Capabilities.Bounding = append(spec.Process.Capabilities.Bounding, "CAP_SYS_ADMIN", "net_admin")
+		}, []string{"dangerous-capability", "dangerous-capability"}},
+		{"no seccomp", func(spec *rspec.Spec) { spec.Linux.Seccomp = nil }, []string{"no-seccomp"}},
+		{"seccomp allow", func(spec *rspec.Spec) { spec.Linux.Seccomp.DefaultAction = rspec.ActAllow }, []string{"seccomp-default-allow"}},
+		{"masked paths", func(spec *rspec.Spec) { spec.Linux.MaskedPaths = []string{"/proc/kcore"} }, []string{"missing-masked-paths"}},
+		{"readonly /proc", func(spec *rspec.Spec) { spec.Linux.ReadonlyPaths = []string{"/proc"} }, nil},
+		{"root", func(spec *rspec.Spec) { spec.Process.User.UID = 0 }, []string{"root-without-userns"}},
+		{"root in userns", func(spec *rspec.Spec) {
+			spec.Process.User.UID = 0
+			spec.Linux.Namespaces = append(spec.Linux.Namespaces, rspec.LinuxNamespace{Type: rspec.UserNamespace})
+		}, nil},
+		{"new privileges", func(spec *rspec.Spec) { spec.Process.NoNewPrivileges = false }, []string{"new-privileges"}},
+		{"writable sysfs", func(spec *rspec.Spec) {
+			spec.Mounts = append(spec.Mounts, rspec.Mount{Destination: "/host/sys", Type: "bind", Source: "/sys/fs", Options: []string{"rbind"}})
+		}, []string{"writable-sysfs"}},
+		{"writable /proc/sys", func(spec *rspec.Spec) {
+			spec.Linux.ReadonlyPaths = []string{"/proc/bus", "/proc/fs", "/proc/irq", "/proc/sysrq-trigger"}
+		}, []string{"missing-readonly-paths", "writable-proc-sys"}},
+		{"host namespace", func(spec *rspec.Spec) { spec.Linux.Namespaces[1].Path = "/proc/1/ns/net" }, []string{"host-namespace"}},
+		{"devices", func(spec *rspec.Spec) {
+			spec.Linux.Resources.Devices = append(spec.Linux.Resources.Devices, rspec.LinuxDeviceCgroup{Allow: true, Type: "a", Major: nil}, rspec.LinuxDeviceCgroup{Allow: true, Type: "c", Major: &all})
+		}, []string{"devices-allow-all"}},
+	} {
+		spec := hardened(t)
+		c.modify(spec)
+		assert.Equal(t, c.expected, rules(lint.Lint(spec, lint.Low)), c.name)
+	}
+}
+
+func TestLintSuppress(t *testing.T) {
+	spec := hardened(t)
+	spec.Linux.Seccomp = nil
+	spec.Process.NoNewPrivileges = false
+	spec.Annotations = map[string]string{"com.github.opencontainers.runtime-tools.lint.suppress.no-seccomp": "the workload is sandboxed by gVisor"}
+
+	assert.Equal(t, []string{"new-privileges"}, rules(lint.Lint(spec, lint.Low)))
+	assert.Nil(t, lint.Lint(spec, lint.High))
+}
+
+func TestRules(t *testing.T) {
+	seen := map[string]bool{}
+	for _, rule := range lint.Rules() {
+		assert.False(t, seen[rule.ID], "duplicate rule %s", rule.ID)
+		seen[rule.ID] = true
+		assert.NotEmpty(t, rule.Description, rule.ID)
+		// Suppression annotations follow the reverse domain notation the
+		// spec recommends.
+		assert.Regexp(t, `^[A-Za-z]{2,6}(\.[A-Za-z0-9-]{1,63})+$`, rule.Annotation())
+
+		found, ok := lint.LookupRule(rule.ID)
+		assert.True(t, ok)
+		assert.Equal(t, rule.ID, found.ID)
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	for _, s := range []lint.Severity{lint.Low, lint.Medium, lint.High} {
+		parsed, err := lint.ParseSeverity(s.String())
+		assert.NoError(t, err)
+		assert.Equal(t, s, parsed)
+	}
+	_, err := lint.ParseSeverity("critical")
+	assert.Error(t, err)
+}
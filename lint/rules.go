@@ -0,0 +1,318 @@
+package lint
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// dangerousCapabilities are the capabilities which let a process
+// escape the container or control the host.
+var dangerousCapabilities = []string{
+	"CAP_BPF",
+	"CAP_DAC_READ_SEARCH",
+	"CAP_MAC_ADMIN",
+	"CAP_MAC_OVERRIDE",
+	"CAP_NET_ADMIN",
+	"CAP_PERFMON",
+	"CAP_SYS_ADMIN",
+	"CAP_SYS_BOOT",
+	"CAP_SYS_MODULE",
+	"CAP_SYS_PTRACE",
+	"CAP_SYS_RAWIO",
+	"CAP_SYS_TIME",
+	"CAP_SYSLOG",
+}
+
+// DefaultMaskedPaths are the paths runtimes conventionally mask, as in
+// the configurations of `runc spec`.  generate.New sets no masked paths,
+// as the paths depend on the kernel of the host, so its configurations
+// are reported until they add these paths.
+var DefaultMaskedPaths = []string{
+	"/proc/acpi",
+	"/proc/asound",
+	"/proc/kcore",
+	"/proc/keys",
+	"/proc/latency_stats",
+	"/proc/timer_list",
+	"/proc/timer_stats",
+	"/proc/sched_debug",
+	"/proc/scsi",
+	"/sys/firmware",
+}
+
+// DefaultReadonlyPaths are the paths runtimes conventionally make
+// read-only, as in the configurations of `runc spec`.  Like masked
+// paths, generate.New sets none of them.
+var DefaultReadonlyPaths = []string{
+	"/proc/bus",
+	"/proc/fs",
+	"/proc/irq",
+	"/proc/sys",
+	"/proc/sysrq-trigger",
+}
+
+var rules = []Rule{
+	{
+		ID:          "dangerous-capability",
+		Severity:    High,
+		Description: "The process has a capability which lets it escape the container or control the host, such as CAP_SYS_ADMIN, CAP_NET_ADMIN or CAP_SYS_PTRACE.",
+		check:       checkDangerousCapabilities,
+	},
+	{
+		ID:          "no-seccomp",
+		Severity:    High,
+		Description: "linux.seccomp is not set, so the process can make any system call.",
+		check:       checkNoSeccomp,
+	},
+	{
+		ID:          "seccomp-default-allow",
+		Severity:    Medium,
+		Description: "The seccomp profile allows the system calls it does not list, so new system calls of the kernel are allowed.",
+		check:       checkSeccompDefaultAllow,
+	},
+	{
+		ID:          "missing-masked-paths",
+		Severity:    Medium,
+		Description: "linux.maskedPaths lacks some of the paths runtimes conventionally mask, which leak information about the host.",
+		check:       checkMissingMaskedPaths,
+	},
+	{
+		ID:          "missing-readonly-paths",
+		Severity:    Medium,
+		Description: "linux.readonlyPaths lacks some of the paths runtimes conventionally make read-only, which configure the host.",
+		check:       checkMissingReadonlyPaths,
+	},
+	{
+		ID:          "root-without-userns",
+		Severity:    High,
+		Description: "The process runs as uid 0 without a user namespace, so it is root on the host.",
+		check:       checkRootWithoutUserNamespace,
+	},
+	{
+		ID:          "new-privileges",
+		Severity:    Medium,
+		Description: "process.noNewPrivileges is not set, so setuid and file capability executables can raise the privileges of the process.",
+		check:       checkNewPrivileges,
+	},
+	{
+		ID:          "writable-sysfs",
+		Severity:    High,
+		Description: "sysfs is mounted writable, so the process can configure the devices and kernel of the host.",
+		check:       checkWritableSysfs,
+	},
+	{
+		ID:          "writable-proc-sys",
+		Severity:    High,
+		Description: "/proc/sys is writable, so the process can set the sysctls of the host which are not namespaced.",
+		check:       checkWritableProcSys,
+	},
+	{
+		ID:          "host-namespace",
+		Severity:    Medium,
+		Description: "A namespace is joined by path, which may be a namespace of the host or of another container.",
+		check:       checkHostNamespaces,
+	},
+	{
+		ID:          "devices-allow-all",
+		Severity:    High,
+		Description: "The device cgroup allows access to all devices.",
+		check:       checkDevicesAllowAll,
+	},
+}
+
+func checkDangerousCapabilities(spec *rspec.Spec) (messages []string) {
+	if spec.Process == nil || spec.Process.Capabilities == nil {
+		return
+	}
+	caps := spec.Process.Capabilities
+	for _, c := range dangerousCapabilities {
+		var sets []string
+		for _, set := range []struct {
+			name string
+			caps []string
+		}{
+			{"bounding", caps.Bounding},
+			{"effective", caps.Effective},
+			{"permitted", caps.Permitted},
+			{"ambient", caps.Ambient},
+		} {
+			if slices.ContainsFunc(set.caps, func(s string) bool { return normalizeCapability(s) == c }) {
+				sets = append(sets, set.name)
+			}
+		}
+		if len(sets) > 0 {
+			messages = append(messages, fmt.Sprintf("%s is in the %s capabilities", c, strings.Join(sets, ", ")))
+		}
+	}
+	return
+}
+
+// normalizeCapability returns c in upper case with the CAP_ prefix.
+func normalizeCapability(c string) string {
+	c = strings.ToUpper(c)
+	if !strings.HasPrefix(c, "CAP_") {
+		c = "CAP_" + c
+	}
+	return c
+}
+
+func checkNoSeccomp(spec *rspec.Spec) []string {
+	if spec.Linux == nil || spec.Linux.Seccomp != nil {
+		return nil
+	}
+	return []string{"linux.seccomp is not set"}
+}
+
+func checkSeccompDefaultAllow(spec *rspec.Spec) []string {
+	if spec.Linux == nil || spec.Linux.Seccomp == nil {
+		return nil
+	}
+	switch action := spec.Linux.Seccomp.DefaultAction; action {
+	case rspec.ActAllow, rspec.ActLog:
+		return []string{fmt.Sprintf("linux.seccomp.defaultAction is %s", action)}
+	}
+	return nil
+}
+
+func checkMissingMaskedPaths(spec *rspec.Spec) []string {
+	if spec.Linux == nil {
+		return nil
+	}
+	return missingPaths("linux.maskedPaths", spec.Linux.MaskedPaths, DefaultMaskedPaths)
+}
+
+func checkMissingReadonlyPaths(spec *rspec.Spec) []string {
+	if spec.Linux == nil {
+		return nil
+	}
+	return missingPaths("linux.readonlyPaths", spec.Linux.ReadonlyPaths, DefaultReadonlyPaths)
+}
+
+// missingPaths returns a message listing the paths of defaults which
+// are neither in paths nor below one of them.
+func missingPaths(name string, paths []string, defaults []string) []string {
+	var missing []string
+	for _, p := range defaults {
+		if !slices.ContainsFunc(paths, func(covering string) bool { return pathWithin(p, covering) }) {
+			missing = append(missing, p)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s lacks %s", name, strings.Join(missing, ", "))}
+}
+
+// pathWithin reports whether p is dir or below it.
+func pathWithin(p string, dir string) bool {
+	p, dir = path.Clean(p), path.Clean(dir)
+	return p == dir || dir == "/" || strings.HasPrefix(p, dir+"/")
+}
+
+// hasNamespace reports whether spec creates or joins a namespace of
+// type t.
+func hasNamespace(spec *rspec.Spec, t rspec.LinuxNamespaceType) bool {
+	return slices.ContainsFunc(spec.Linux.Namespaces, func(ns rspec.LinuxNamespace) bool { return ns.Type == t })
+}
+
+func checkRootWithoutUserNamespace(spec *rspec.Spec) []string {
+	if spec.Linux == nil || spec.Process == nil || spec.Process.User.UID != 0 {
+		return nil
+	}
+	if hasNamespace(spec, rspec.UserNamespace) {
+		return nil
+	}
+	return []string{"process.user.uid is 0 and linux.namespaces has no user namespace"}
+}
+
+func checkNewPrivileges(spec *rspec.Spec) []string {
+	if spec.Linux == nil || spec.Process == nil || spec.Process.NoNewPrivileges {
+		return nil
+	}
+	return []string{"process.noNewPrivileges is not set"}
+}
+
+// readonly reports whether mount has a read-only option.
+func readonly(mount rspec.Mount) bool {
+	return slices.Contains(mount.Options, "ro") || slices.Contains(mount.Options, "rro")
+}
+
+// readonlyPath reports whether p is in or below linux.readonlyPaths.
+func readonlyPath(spec *rspec.Spec, p string) bool {
+	return slices.ContainsFunc(spec.Linux.ReadonlyPaths, func(dir string) bool { return pathWithin(p, dir) })
+}
+
+func checkWritableSysfs(spec *rspec.Spec) (messages []string) {
+	if spec.Linux == nil {
+		return
+	}
+	for i, mount := range spec.Mounts {
+		sysfs := mount.Type == "sysfs"
+		bind := mount.Type == "bind" || slices.Contains(mount.Options, "bind") || slices.Contains(mount.Options, "rbind")
+		if !sysfs && !(bind && pathWithin(mount.Source, "/sys")) {
+			continue
+		}
+		if readonly(mount) || readonlyPath(spec, path.Join("/", mount.Destination)) {
+			continue
+		}
+		messages = append(messages, fmt.Sprintf("mounts[%d] mounts %s writable at %s", i, mount.Source, mount.Destination))
+	}
+	return
+}
+
+func checkWritableProcSys(spec *rspec.Spec) []string {
+	if spec.Linux == nil || readonlyPath(spec, "/proc/sys") {
+		return nil
+	}
+	var proc bool
+	for _, mount := range spec.Mounts {
+		destination := path.Join("/", mount.Destination)
+		switch {
+		case destination == "/proc/sys" && readonly(mount):
+			return nil
+		case destination == "/proc" && mount.Type == "proc":
+			proc = !readonly(mount)
+		}
+	}
+	if !proc {
+		return nil
+	}
+	return []string{"/proc/sys is neither in linux.readonlyPaths nor mounted read-only"}
+}
+
+func checkHostNamespaces(spec *rspec.Spec) (messages []string) {
+	if spec.Linux == nil {
+		return
+	}
+	for _, ns := range spec.Linux.Namespaces {
+		if ns.Path != "" {
+			messages = append(messages, fmt.Sprintf("the %s namespace joins %s", ns.Type, ns.Path))
+		}
+	}
+	return
+}
+
+func checkDevicesAllowAll(spec *rspec.Spec) (messages []string) {
+	if spec.Linux == nil || spec.Linux.Resources == nil {
+		return
+	}
+	for i, device := range spec.Linux.Resources.Devices {
+		if device.Allow && (device.Type == "" || device.Type == "a") && device.Major == nil && device.Minor == nil {
+			messages = append(messages, fmt.Sprintf("linux.resources.devices[%d] allows %q access to all devices", i, deviceAccess(device.Access)))
+		}
+	}
+	return
+}
+
+// deviceAccess returns the access of a device cgroup rule, "rwm" when
+// it is not set.
+func deviceAccess(access string) string {
+	if access == "" {
+		return "rwm"
+	}
+	return access
+}
@@ -0,0 +1,171 @@
+// Package resctrl implements helpers for inspecting the resctrl
+// pseudo-filesystem used to configure Intel RDT.
+package resctrl
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/opencontainers/runtime-tools/specerror"
+)
+
+// DefaultMountPath is the conventional mount point of resctrl.
+var DefaultMountPath = "/sys/fs/resctrl"
+
+// reservedNames are entries of the resctrl root directory which cannot
+// be used as the name of a resource group.
+var reservedNames = []string{"info", "mon_data", "mon_groups"}
+
+// Resctrl represents a mounted resctrl pseudo-filesystem.
+type Resctrl struct {
+	MountPath string
+}
+
+// FindResctrl gets the resctrl mountpoint from /proc/self/mountinfo.
+func FindResctrl() (*Resctrl, error) {
+	f, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		text := scanner.Text()
+		fields := strings.Split(text, " ")
+		// Safe as mountinfo encodes mountpoints with spaces as \040.
+		index := strings.Index(text, " - ")
+		if index < 0 || len(fields) < 5 {
+			continue
+		}
+		postSeparatorFields := strings.Split(text[index+3:], " ")
+		if postSeparatorFields[0] == "resctrl" {
+			return &Resctrl{MountPath: fields[4]}, nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return nil, specerror.NewError(specerror.IntelRdtNoMountedResctrlError, fmt.Errorf("resctrl is not mounted"), rspec.Version)
+}
+
+// ValidateClosID checks whether closID can be used as the name of a
+// resctrl resource group.
+func ValidateClosID(closID string) error {
+	if closID == "" {
+		return nil
+	}
+	if closID == "." || closID == ".." || strings.ContainsAny(closID, "/\x00") {
+		return fmt.Errorf("closID %q is not a valid directory name", closID)
+	}
+	if slices.Contains(reservedNames, closID) {
+		return fmt.Errorf("closID %q is reserved by the resctrl pseudo-filesystem", closID)
+	}
+	return nil
+}
+
+// groupPath returns the directory of the resource group closID.  The
+// default group is the root of the resctrl mount.
+func (r *Resctrl) groupPath(closID string) (string, error) {
+	if err := ValidateClosID(closID); err != nil {
+		return "", err
+	}
+	return filepath.Join(r.MountPath, closID), nil
+}
+
+// GetSchemata gets the parsed schemata file of the resource group closID.
+func (r *Resctrl) GetSchemata(closID string) ([]Schema, error) {
+	path, err := r.groupPath(closID)
+	if err != nil {
+		return nil, err
+	}
+	contents, err := os.ReadFile(filepath.Join(path, "schemata"))
+	if err != nil {
+		return nil, err
+	}
+	return ParseSchemata(string(contents))
+}
+
+// GetTasks gets the IDs of the tasks assigned to the resource group closID.
+func (r *Resctrl) GetTasks(closID string) ([]int, error) {
+	path, err := r.groupPath(closID)
+	if err != nil {
+		return nil, err
+	}
+	contents, err := os.ReadFile(filepath.Join(path, "tasks"))
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []int
+	for _, line := range strings.Split(strings.TrimSpace(string(contents)), "\n") {
+		if line == "" {
+			continue
+		}
+		pid, err := strconv.Atoi(strings.TrimSpace(line))
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, pid)
+	}
+	return tasks, nil
+}
+
+// GetMonitoringGroups gets the names of the monitoring groups created
+// within the resource group closID.
+func (r *Resctrl) GetMonitoringGroups(closID string) ([]string, error) {
+	path, err := r.groupPath(closID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(filepath.Join(path, "mon_groups"))
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			groups = append(groups, entry.Name())
+		}
+	}
+	return groups, nil
+}
+
+// GetIntelRdtData gets the Intel RDT configuration of the resource group
+// closID.  Cache schemas are reported as l3CacheSchema, bandwidth schemas
+// as memBwSchema, and every line of the schemata file in schemata.
+func (r *Resctrl) GetIntelRdtData(closID string) (*rspec.LinuxIntelRdt, error) {
+	schemas, err := r.GetSchemata(closID)
+	if err != nil {
+		return nil, err
+	}
+
+	rdt := &rspec.LinuxIntelRdt{ClosID: closID}
+	var l3, mb []string
+	for _, schema := range schemas {
+		rdt.Schemata = append(rdt.Schemata, schema.String())
+		switch schema.Resource {
+		case "L3", "L3CODE", "L3DATA":
+			l3 = append(l3, schema.String())
+		case "MB":
+			mb = append(mb, schema.String())
+		}
+	}
+	rdt.L3CacheSchema = strings.Join(l3, "\n")
+	rdt.MemBwSchema = strings.Join(mb, "\n")
+
+	if groups, err := r.GetMonitoringGroups(closID); err == nil && len(groups) > 0 {
+		rdt.EnableMonitoring = true
+	}
+
+	return rdt, nil
+}
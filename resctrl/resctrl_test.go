@@ -0,0 +1,119 @@
+package resctrl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSchema(t *testing.T) {
+	for _, tt := range []struct {
+		line     string
+		expected string
+		valid    bool
+	}{
+		{line: "L3:0=ff;1=ff", expected: "L3:0=ff;1=ff", valid: true},
+		{line: "    L3:0=0ff;1=0x0ff", expected: "L3:0=ff;1=ff", valid: true},
+		{line: "MB:0=70;1=100", expected: "MB:0=70;1=100", valid: true},
+		{line: "L3CODE:0=f", expected: "L3CODE:0=f", valid: true},
+		{line: "L3:1=f;0=f0", expected: "L3:0=f0;1=f", valid: true},
+		{line: "L3", valid: false},
+		{line: "L3:", valid: false},
+		{line: ":0=ff", valid: false},
+		{line: "l3:0=ff", valid: false},
+		{line: "L3:0=fg", valid: false},
+		{line: "L3:0=0", valid: false},
+		{line: "L3:a=ff", valid: false},
+		{line: "L3:0=ff;0=f", valid: false},
+		{line: "L3:0ff", valid: false},
+		{line: "MB:0=ff", valid: false},
+	} {
+		schema, err := ParseSchema(tt.line)
+		if !tt.valid {
+			assert.Error(t, err, tt.line)
+			continue
+		}
+		if assert.NoError(t, err, tt.line) {
+			assert.Equal(t, tt.expected, schema.String())
+		}
+	}
+}
+
+func TestValidateClosID(t *testing.T) {
+	for _, closID := range []string{"", "ocitest", "a.b"} {
+		assert.NoError(t, ValidateClosID(closID), closID)
+	}
+	for _, closID := range []string{".", "..", "a/b", "info", "mon_groups", "mon_data"} {
+		assert.Error(t, ValidateClosID(closID), closID)
+	}
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFakeResctrl(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "schemata"), "    L3:0=7ff;1=7ff\n    MB:0=100;1=100\n")
+	writeFile(t, filepath.Join(root, "ocitest", "schemata"), "    L3:0=00f;1=7ff\n    MB:0= 50;1=100\n")
+	writeFile(t, filepath.Join(root, "ocitest", "tasks"), "1234\n5678\n")
+	if err := os.MkdirAll(filepath.Join(root, "ocitest", "mon_groups", "container"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	rc := &Resctrl{MountPath: root}
+
+	tasks, err := rc.GetTasks("ocitest")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1234, 5678}, tasks)
+
+	rdt, err := rc.GetIntelRdtData("ocitest")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "L3:0=f;1=7ff", rdt.L3CacheSchema)
+		assert.Equal(t, "MB:0=50;1=100", rdt.MemBwSchema)
+		assert.Equal(t, []string{"L3:0=f;1=7ff", "MB:0=50;1=100"}, rdt.Schemata)
+		assert.True(t, rdt.EnableMonitoring)
+	}
+
+	schemas, err := rc.GetSchemata("ocitest")
+	if assert.NoError(t, err) {
+		expected, err := ParseSchema("L3:0=f")
+		assert.NoError(t, err)
+		assert.True(t, schemas[0].Contains(expected))
+		expected, err = ParseSchema("L3:0=ff")
+		assert.NoError(t, err)
+		assert.False(t, schemas[0].Contains(expected))
+	}
+
+	rdt, err = rc.GetIntelRdtData("")
+	if assert.NoError(t, err) {
+		assert.False(t, rdt.EnableMonitoring)
+	}
+
+	_, err = rc.GetTasks("missing")
+	assert.True(t, os.IsNotExist(err))
+	_, err = rc.GetTasks("../ocitest")
+	assert.Error(t, err)
+}
+
+func TestHostResctrl(t *testing.T) {
+	rc, err := FindResctrl()
+	if err != nil {
+		t.Skipf("resctrl is not mounted: %v", err)
+	}
+
+	if _, err := rc.GetSchemata(""); err != nil {
+		t.Errorf("unexpected error reading the default group schemata: %v", err)
+	}
+	if _, err := rc.GetTasks(""); err != nil {
+		t.Errorf("unexpected error reading the default group tasks: %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+package resctrl
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CacheResources are the resctrl resources whose domain values are
+// capacity bitmasks (CBM), written in hexadecimal.
+var CacheResources = []string{"L3", "L3CODE", "L3DATA", "L2", "L2CODE", "L2DATA"}
+
+// BandwidthResources are the resctrl resources whose domain values are
+// bandwidth allocations, written in decimal (percentages, or MBps when
+// the MBA software controller is enabled).
+var BandwidthResources = []string{"MB", "SMBA"}
+
+// Schema represents a single line of a resctrl schemata file, e.g.
+// "L3:0=ff;1=ff".
+type Schema struct {
+	// Resource is the resource name, e.g. "L3" or "MB".
+	Resource string
+	// Domains maps each cache/domain ID to its value.
+	Domains map[uint64]uint64
+}
+
+// IsKnownResource returns whether the resource name is one of
+// CacheResources or BandwidthResources.
+func IsKnownResource(resource string) bool {
+	return isCacheResource(resource) || isBandwidthResource(resource)
+}
+
+func isCacheResource(resource string) bool {
+	return slices.Contains(CacheResources, resource)
+}
+
+func isBandwidthResource(resource string) bool {
+	return slices.Contains(BandwidthResources, resource)
+}
+
+// ParseSchema parses a single schemata line of the form
+// "<resource>:<id0>=<value0>;<id1>=<value1>;...".  Cache resources take
+// hexadecimal capacity bitmasks and bandwidth resources take decimal
+// values; values of unknown resources are parsed as decimal.
+func ParseSchema(line string) (Schema, error) {
+	line = strings.TrimSpace(line)
+	parts := strings.SplitN(line, ":", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return Schema{}, fmt.Errorf("schema %q is not in the form <resource>:<id>=<value>[;<id>=<value>...]", line)
+	}
+
+	resource := strings.TrimSpace(parts[0])
+	for _, c := range resource {
+		if (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return Schema{}, fmt.Errorf("schema %q has an invalid resource name %q", line, resource)
+		}
+	}
+
+	base := 10
+	if isCacheResource(resource) {
+		base = 16
+	}
+
+	schema := Schema{
+		Resource: resource,
+		Domains:  make(map[uint64]uint64),
+	}
+	domains := strings.TrimSpace(parts[1])
+	if domains == "" {
+		return Schema{}, fmt.Errorf("schema %q does not specify any domain", line)
+	}
+	for _, domain := range strings.Split(domains, ";") {
+		kv := strings.SplitN(domain, "=", 2)
+		if len(kv) != 2 {
+			return Schema{}, fmt.Errorf("schema %q has an invalid domain %q", line, domain)
+		}
+		id, err := strconv.ParseUint(strings.TrimSpace(kv[0]), 10, 32)
+		if err != nil {
+			return Schema{}, fmt.Errorf("schema %q has an invalid domain ID %q", line, kv[0])
+		}
+		if _, ok := schema.Domains[id]; ok {
+			return Schema{}, fmt.Errorf("schema %q specifies domain %d more than once", line, id)
+		}
+		value := strings.TrimSpace(kv[1])
+		if base == 16 {
+			value = strings.TrimPrefix(strings.TrimPrefix(value, "0x"), "0X")
+		}
+		v, err := strconv.ParseUint(value, base, 64)
+		if err != nil {
+			return Schema{}, fmt.Errorf("schema %q has an invalid value %q for domain %d", line, kv[1], id)
+		}
+		if base == 16 && v == 0 {
+			return Schema{}, fmt.Errorf("schema %q has an empty capacity bitmask for domain %d", line, id)
+		}
+		schema.Domains[id] = v
+	}
+
+	return schema, nil
+}
+
+// ParseSchemata parses newline-separated schemata lines, skipping empty
+// lines.
+func ParseSchemata(schemata string) ([]Schema, error) {
+	var schemas []Schema
+	for _, line := range strings.Split(schemata, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		schema, err := ParseSchema(line)
+		if err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, schema)
+	}
+	return schemas, nil
+}
+
+// String returns the schema in the canonical schemata syntax.
+func (s Schema) String() string {
+	ids := make([]uint64, 0, len(s.Domains))
+	for id := range s.Domains {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	domains := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if isCacheResource(s.Resource) {
+			domains = append(domains, fmt.Sprintf("%d=%x", id, s.Domains[id]))
+		} else {
+			domains = append(domains, fmt.Sprintf("%d=%d", id, s.Domains[id]))
+		}
+	}
+	return fmt.Sprintf("%s:%s", s.Resource, strings.Join(domains, ";"))
+}
+
+// Contains returns whether every domain set in other is set to the same
+// value in s.  The kernel reports all domains of a resource in the
+// schemata file, so a configured schema only needs to be a subset.
+func (s Schema) Contains(other Schema) bool {
+	if s.Resource != other.Resource {
+		return false
+	}
+	for id, v := range other.Domains {
+		if actual, ok := s.Domains[id]; !ok || actual != v {
+			return false
+		}
+	}
+	return true
+}
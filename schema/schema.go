@@ -0,0 +1,17 @@
+// Package schema embeds the JSON Schemas for the OCI Runtime Command Line
+// Interface.
+package schema
+
+import "embed"
+
+// FS holds the socket-*.json schemas of this directory.
+//
+//go:embed socket-*.json
+var FS embed.FS
+
+const (
+	// SocketTerminalRequest is the name of the terminal request schema in FS.
+	SocketTerminalRequest = "socket-terminal-request.json"
+	// SocketResponse is the name of the response schema in FS.
+	SocketResponse = "socket-response.json"
+)
@@ -21,7 +21,7 @@ var containerFormatRef = func(version string) (reference string, err error) {
 }
 
 func init() {
-	register(ConfigInRootBundleDir, rfc2119.Must, containerFormatRef)
-	register(ConfigConstName, rfc2119.Must, containerFormatRef)
-	register(ArtifactsInSingleDir, rfc2119.Must, containerFormatRef)
+	register(ConfigInRootBundleDir, "ConfigInRootBundleDir", rfc2119.Must, containerFormatRef, "This REQUIRED file MUST reside in the root of the bundle directory")
+	register(ConfigConstName, "ConfigConstName", rfc2119.Must, containerFormatRef, "This REQUIRED file MUST be named `config.json`.")
+	register(ArtifactsInSingleDir, "ArtifactsInSingleDir", rfc2119.Must, containerFormatRef, "When supplied, while these artifacts MUST all be present in a single directory on the local filesystem, that directory itself is not part of the bundle.")
 }
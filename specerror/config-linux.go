@@ -62,6 +62,50 @@ const (
 	MaskedPathsAbs
 	// ReadonlyPathsAbs represents "readonlyPaths (array of strings, OPTIONAL) will set the provided paths as readonly inside the container. The values MUST be absolute paths in the container namespace."
 	ReadonlyPathsAbs
+	// RdmaHcaHandlesOrHcaObjectsExist represents "You MUST specify at least one of the `hcaHandles` or `hcaObjects` in a given entry, and MAY specify both."
+	RdmaHcaHandlesOrHcaObjectsExist
+	// NetDevicesAvailable represents "`netDevices` (object, OPTIONAL) - A set of network devices that MUST be made available in the container."
+	NetDevicesAvailable
+	// NetDevicesCheckMove represents "The runtime MUST check if moving the network interface to the container namespace is possible."
+	NetDevicesCheckMove
+	// NetDevicesNameExistError represents "If a network device with the specified name already exists in the container namespace, the runtime MUST generate an error, unless the user has provided a template by appending `%d` to the new name."
+	NetDevicesNameExistError
+	// NetDevicesNameTemplateAllow represents "In that case, the runtime MUST allow the move, and the kernel will generate a unique name for the interface within the container's network namespace."
+	NetDevicesNameTemplateAllow
+	// NetDevicesPreserveAddresses represents "The runtime MUST preserve existing network interface attributes, including all permanent IP addresses (IFA_F_PERMANENT flag) of any family with global scope (RT_SCOPE_UNIVERSE value) as defined in RFC 3549 Section 2.3.3.2."
+	NetDevicesPreserveAddresses
+	// NetDevicesSetUp represents "The runtime MUST set the network device state to "up" after moving it to the network namespace to allow the container to send and receive network traffic through that device."
+	NetDevicesSetUp
+	// NetDevicesNotManaged represents "The runtime MUST NOT actively manage the interface's lifecycle and configuration *within* the container's network namespace."
+	NetDevicesNotManaged
+	// NetDevicesNotMovedOut represents "The runtime MUST NOT attempt to move the interface out of the namespace before deletion."
+	NetDevicesNotMovedOut
+	// SeccListenerSocketType represents "This socket MUST use `AF_UNIX` domain and `SOCK_STREAM` type."
+	SeccListenerSocketType
+	// SeccListenerOneStatePerConn represents "The runtime MUST send exactly one container process state per connection."
+	SeccListenerOneStatePerConn
+	// SeccListenerConnNotReused represents "The connection MUST NOT be reused and it MUST be closed after sending a seccomp state."
+	SeccListenerConnNotReused
+	// SeccListenerSendFailError represents "If sending to this socket fails, the runtime MUST generate an error."
+	SeccListenerSendFailError
+	// SeccListenerMetadataWithoutPath represents "This field MUST NOT be set if `listenerPath` is not set."
+	SeccListenerMetadataWithoutPath
+	// SeccProcessStateJSON represents "The container runtime MUST send the container process state over the UNIX socket as regular payload serialized in JSON and file descriptors MUST be sent using `SCM_RIGHTS`."
+	SeccProcessStateJSON
+	// SeccProcessStateFdsFirstMsg represents "If more than one `sendmsg(2)` is used, the file descriptors MUST be sent only in the first call."
+	SeccProcessStateFdsFirstMsg
+	// PersonalityDomainRequired represents "`domain` (string, REQUIRED) - the execution domain."
+	PersonalityDomainRequired
+	// CPUBurstNotLargerThanQuota represents "If specified, this value MUST be no larger than any positive `quota` (runtimes MAY generate an error)."
+	CPUBurstNotLargerThanQuota
+	// IntelRdtL3CacheSchemaPrefix represents "The value SHOULD start with `L3:` and SHOULD NOT contain newlines."
+	IntelRdtL3CacheSchemaPrefix
+	// IntelRdtMemBwSchemaPrefix represents "The value MUST start with `MB:` and MUST NOT contain newlines."
+	IntelRdtMemBwSchemaPrefix
+	// MemoryKernelNotRecommended represents "`kernel` (int64, OPTIONAL, NOT RECOMMENDED) - sets hard limit for kernel memory"
+	MemoryKernelNotRecommended
+	// MemoryKernelTCPNotRecommended represents "`kernelTCP` (int64, OPTIONAL, NOT RECOMMENDED) - sets hard limit for kernel TCP buffer memory"
+	MemoryKernelTCPNotRecommended
 )
 
 var (
@@ -74,12 +118,21 @@ var (
 	userNamespaceMappingsRef = func(version string) (reference string, err error) {
 		return fmt.Sprintf(referenceTemplate, version, "config-linux.md#user-namespace-mappings"), nil
 	}
+	timeOffsetRef = func(version string) (reference string, err error) {
+		return fmt.Sprintf(referenceTemplate, version, "config-linux.md#offset-for-time-namespace"), nil
+	}
 	devicesRef = func(version string) (reference string, err error) {
 		return fmt.Sprintf(referenceTemplate, version, "config-linux.md#devices"), nil
 	}
 	defaultDevicesRef = func(version string) (reference string, err error) {
 		return fmt.Sprintf(referenceTemplate, version, "config-linux.md#default-devices"), nil
 	}
+	netDevicesRef = func(version string) (reference string, err error) {
+		return fmt.Sprintf(referenceTemplate, version, "config-linux.md#network-devices"), nil
+	}
+	netDevicesLifecycleRef = func(version string) (reference string, err error) {
+		return fmt.Sprintf(referenceTemplate, version, "config-linux.md#namespace-lifecycle-and-container-termination"), nil
+	}
 	cgroupsPathRef = func(version string) (reference string, err error) {
 		return fmt.Sprintf(referenceTemplate, version, "config-linux.md#cgroups-path"), nil
 	}
@@ -89,46 +142,95 @@ var (
 	blockIoRef = func(version string) (reference string, err error) {
 		return fmt.Sprintf(referenceTemplate, version, "config-linux.md#block-io"), nil
 	}
+	rdmaRef = func(version string) (reference string, err error) {
+		return fmt.Sprintf(referenceTemplate, version, "config-linux.md#rdma"), nil
+	}
 	intelrdtRef = func(version string) (reference string, err error) {
 		return fmt.Sprintf(referenceTemplate, version, "config-linux.md#intelrdt"), nil
 	}
+	cpuRef = func(version string) (reference string, err error) {
+		return fmt.Sprintf(referenceTemplate, version, "config-linux.md#cpu"), nil
+	}
+	memoryRef = func(version string) (reference string, err error) {
+		return fmt.Sprintf(referenceTemplate, version, "config-linux.md#memory"), nil
+	}
+	sysctlRef = func(version string) (reference string, err error) {
+		return fmt.Sprintf(referenceTemplate, version, "config-linux.md#sysctl"), nil
+	}
+	unifiedRef = func(version string) (reference string, err error) {
+		return fmt.Sprintf(referenceTemplate, version, "config-linux.md#unified"), nil
+	}
+	controlGroupsRef = func(version string) (reference string, err error) {
+		return fmt.Sprintf(referenceTemplate, version, "config-linux.md#control-groups"), nil
+	}
 	seccompRef = func(version string) (reference string, err error) {
 		return fmt.Sprintf(referenceTemplate, version, "config-linux.md#seccomp"), nil
 	}
+	containerProcessStateRef = func(version string) (reference string, err error) {
+		return fmt.Sprintf(referenceTemplate, version, "config-linux.md#the-container-process-state"), nil
+	}
 	maskedPathsRef = func(version string) (reference string, err error) {
 		return fmt.Sprintf(referenceTemplate, version, "config-linux.md#masked-paths"), nil
 	}
 	readonlyPathsRef = func(version string) (reference string, err error) {
 		return fmt.Sprintf(referenceTemplate, version, "config-linux.md#readonly-paths"), nil
 	}
+	mountLabelRef = func(version string) (reference string, err error) {
+		return fmt.Sprintf(referenceTemplate, version, "config-linux.md#mount-label"), nil
+	}
+	personalityRef = func(version string) (reference string, err error) {
+		return fmt.Sprintf(referenceTemplate, version, "config-linux.md#personality"), nil
+	}
 )
 
 func init() {
-	register(DefaultFilesystems, rfc2119.Should, defaultFilesystemsRef)
-	register(NSPathAbs, rfc2119.Must, namespacesRef)
-	register(NSProcInPath, rfc2119.Must, namespacesRef)
-	register(NSPathMatchTypeError, rfc2119.Must, namespacesRef)
-	register(NSNewNSWithoutPath, rfc2119.Must, namespacesRef)
-	register(NSInheritWithoutType, rfc2119.Must, namespacesRef)
-	register(NSErrorOnDup, rfc2119.Must, namespacesRef)
-	register(UserNSMapOwnershipRO, rfc2119.Should, userNamespaceMappingsRef)
-	register(DevicesAvailable, rfc2119.Must, devicesRef)
-	register(DevicesFileNotMatch, rfc2119.Must, devicesRef)
-	register(DevicesMajMinRequired, rfc2119.Required, devicesRef)
-	register(DevicesErrorOnDup, rfc2119.Should, devicesRef)
-	register(DefaultDevices, rfc2119.Must, defaultDevicesRef)
-	register(CgroupsPathAbsOrRel, rfc2119.Must, cgroupsPathRef)
-	register(CgroupsAbsPathRelToMount, rfc2119.Must, cgroupsPathRef)
-	register(CgroupsPathAttach, rfc2119.Must, cgroupsPathRef)
-	register(CgroupsPathError, rfc2119.Must, cgroupsPathRef)
-	register(DevicesApplyInOrder, rfc2119.Must, deviceWhitelistRef)
-	register(BlkIOWeightOrLeafWeightExist, rfc2119.Must, blockIoRef)
-	register(IntelRdtPIDWrite, rfc2119.Must, intelrdtRef)
-	register(IntelRdtNoMountedResctrlError, rfc2119.Must, intelrdtRef)
-	register(NotManipResctrlWithoutIntelRdt, rfc2119.Must, intelrdtRef)
-	register(IntelRdtL3CacheSchemaWrite, rfc2119.Must, intelrdtRef)
-	register(IntelRdtL3CacheSchemaNotWrite, rfc2119.Must, intelrdtRef)
-	register(SeccSyscallsNamesRequired, rfc2119.Must, seccompRef)
-	register(MaskedPathsAbs, rfc2119.Must, maskedPathsRef)
-	register(ReadonlyPathsAbs, rfc2119.Must, readonlyPathsRef)
+	register(DefaultFilesystems, "DefaultFilesystems", rfc2119.Should, defaultFilesystemsRef, "The following filesystems SHOULD be made available in each container's filesystem:")
+	register(NSPathAbs, "NSPathAbs", rfc2119.Must, namespacesRef, "This value MUST be an absolute path in the runtime mount namespace.")
+	register(NSProcInPath, "NSProcInPath", rfc2119.Must, namespacesRef, "The runtime MUST place the container process in the namespace associated with that `path`.")
+	register(NSPathMatchTypeError, "NSPathMatchTypeError", rfc2119.Must, namespacesRef, "The runtime MUST generate an error if `path` is not associated with a namespace of type `type`.")
+	register(NSNewNSWithoutPath, "NSNewNSWithoutPath", rfc2119.Must, namespacesRef, "If `path` is not specified, the runtime MUST create a new container namespace of type `type`.")
+	register(NSInheritWithoutType, "NSInheritWithoutType", rfc2119.Must, namespacesRef, "If a namespace type is not specified in the `namespaces` array, the container MUST inherit the runtime namespace of that type.")
+	register(NSErrorOnDup, "NSErrorOnDup", rfc2119.Must, namespacesRef, "If a `namespaces` field contains duplicated namespaces with same `type`, the runtime MUST generate an error.")
+	register(UserNSMapOwnershipRO, "UserNSMapOwnershipRO", rfc2119.Should, userNamespaceMappingsRef, "The runtime SHOULD NOT modify the ownership of referenced filesystems to realize the mapping.")
+	register(DevicesAvailable, "DevicesAvailable", rfc2119.Must, devicesRef, "devices (array of objects, OPTIONAL) lists devices that MUST be available in the container.")
+	register(DevicesFileNotMatch, "DevicesFileNotMatch", rfc2119.Must, devicesRef, "If a file already exists at `path` that does not match the requested device, the runtime MUST generate an error.")
+	register(DevicesMajMinRequired, "DevicesMajMinRequired", rfc2119.Required, devicesRef, "`major, minor` (int64, REQUIRED unless `type` is `p`) - major, minor numbers for the device.")
+	register(DevicesErrorOnDup, "DevicesErrorOnDup", rfc2119.Should, devicesRef, "The same `type`, `major` and `minor` SHOULD NOT be used for multiple devices.")
+	register(DefaultDevices, "DefaultDevices", rfc2119.Must, defaultDevicesRef, "In addition to any devices configured with this setting, the runtime MUST also supply default devices.")
+	register(CgroupsPathAbsOrRel, "CgroupsPathAbsOrRel", rfc2119.Must, cgroupsPathRef, "The value of `cgroupsPath` MUST be either an absolute path or a relative path.")
+	register(CgroupsAbsPathRelToMount, "CgroupsAbsPathRelToMount", rfc2119.Must, cgroupsPathRef, "In the case of an absolute path (starting with `/`), the runtime MUST take the path to be relative to the cgroups mount point.")
+	register(CgroupsPathAttach, "CgroupsPathAttach", rfc2119.Must, cgroupsPathRef, "If the value is specified, the runtime MUST consistently attach to the same place in the cgroups hierarchy given the same value of `cgroupsPath`.")
+	register(CgroupsPathError, "CgroupsPathError", rfc2119.Must, cgroupsPathRef, "Runtimes MAY consider certain `cgroupsPath` values to be invalid, and MUST generate an error if this is the case.")
+	register(DevicesApplyInOrder, "DevicesApplyInOrder", rfc2119.Must, deviceWhitelistRef, "The runtime MUST apply entries in the listed order.")
+	register(BlkIOWeightOrLeafWeightExist, "BlkIOWeightOrLeafWeightExist", rfc2119.Must, blockIoRef, "You MUST specify at least one of `weight` or `leafWeight` in a given entry, and MAY specify both.")
+	register(IntelRdtPIDWrite, "IntelRdtPIDWrite", rfc2119.Must, intelrdtRef, "If `intelRdt` is set, the runtime MUST write the container process ID to the `<container-id>/tasks` file in a mounted `resctrl` pseudo-filesystem, using the container ID from `start` and creating the `container-id` directory if necessary.")
+	register(IntelRdtNoMountedResctrlError, "IntelRdtNoMountedResctrlError", rfc2119.Must, intelrdtRef, "If no mounted `resctrl` pseudo-filesystem is available in the runtime mount namespace, the runtime MUST generate an error.")
+	register(NotManipResctrlWithoutIntelRdt, "NotManipResctrlWithoutIntelRdt", rfc2119.Must, intelrdtRef, "If `intelRdt` is not set, the runtime MUST NOT manipulate any `resctrl` pseudo-filesystems.")
+	register(IntelRdtL3CacheSchemaWrite, "IntelRdtL3CacheSchemaWrite", rfc2119.Must, intelrdtRef, "If `l3CacheSchema` is set, runtimes MUST write the value to the `schemata` file in the `<container-id>` directory discussed in `intelRdt`.")
+	register(IntelRdtL3CacheSchemaNotWrite, "IntelRdtL3CacheSchemaNotWrite", rfc2119.Must, intelrdtRef, "If `l3CacheSchema` is not set, runtimes MUST NOT write to `schemata` files in any `resctrl` pseudo-filesystems.")
+	register(SeccSyscallsNamesRequired, "SeccSyscallsNamesRequired", rfc2119.Must, seccompRef, "`names` MUST contain at least one entry.")
+	register(MaskedPathsAbs, "MaskedPathsAbs", rfc2119.Must, maskedPathsRef, "maskedPaths (array of strings, OPTIONAL) will mask over the provided paths inside the container so that they cannot be read. The values MUST be absolute paths in the container namespace.")
+	register(ReadonlyPathsAbs, "ReadonlyPathsAbs", rfc2119.Must, readonlyPathsRef, "readonlyPaths (array of strings, OPTIONAL) will set the provided paths as readonly inside the container. The values MUST be absolute paths in the container namespace.")
+	register(RdmaHcaHandlesOrHcaObjectsExist, "RdmaHcaHandlesOrHcaObjectsExist", rfc2119.Must, rdmaRef, "You MUST specify at least one of the `hcaHandles` or `hcaObjects` in a given entry, and MAY specify both.")
+	register(NetDevicesAvailable, "NetDevicesAvailable", rfc2119.Must, netDevicesRef, "`netDevices` (object, OPTIONAL) - A set of network devices that MUST be made available in the container.")
+	register(NetDevicesCheckMove, "NetDevicesCheckMove", rfc2119.Must, netDevicesRef, "The runtime MUST check if moving the network interface to the container namespace is possible.")
+	register(NetDevicesNameExistError, "NetDevicesNameExistError", rfc2119.Must, netDevicesRef, "If a network device with the specified name already exists in the container namespace, the runtime MUST generate an error, unless the user has provided a template by appending `%d` to the new name.")
+	register(NetDevicesNameTemplateAllow, "NetDevicesNameTemplateAllow", rfc2119.Must, netDevicesRef, "In that case, the runtime MUST allow the move, and the kernel will generate a unique name for the interface within the container's network namespace.")
+	register(NetDevicesPreserveAddresses, "NetDevicesPreserveAddresses", rfc2119.Must, netDevicesRef, "The runtime MUST preserve existing network interface attributes, including all permanent IP addresses (IFA_F_PERMANENT flag) of any family with global scope (RT_SCOPE_UNIVERSE value) as defined in RFC 3549 Section 2.3.3.2.")
+	register(NetDevicesSetUp, "NetDevicesSetUp", rfc2119.Must, netDevicesRef, "The runtime MUST set the network device state to \"up\" after moving it to the network namespace to allow the container to send and receive network traffic through that device.")
+	register(NetDevicesNotManaged, "NetDevicesNotManaged", rfc2119.MustNot, netDevicesLifecycleRef, "The runtime MUST NOT actively manage the interface's lifecycle and configuration *within* the container's network namespace.")
+	register(NetDevicesNotMovedOut, "NetDevicesNotMovedOut", rfc2119.MustNot, netDevicesLifecycleRef, "The runtime MUST NOT attempt to move the interface out of the namespace before deletion.")
+	register(SeccListenerSocketType, "SeccListenerSocketType", rfc2119.Must, seccompRef, "This socket MUST use `AF_UNIX` domain and `SOCK_STREAM` type.")
+	register(SeccListenerOneStatePerConn, "SeccListenerOneStatePerConn", rfc2119.Must, seccompRef, "The runtime MUST send exactly one container process state per connection.")
+	register(SeccListenerConnNotReused, "SeccListenerConnNotReused", rfc2119.MustNot, seccompRef, "The connection MUST NOT be reused and it MUST be closed after sending a seccomp state.")
+	register(SeccListenerSendFailError, "SeccListenerSendFailError", rfc2119.Must, seccompRef, "If sending to this socket fails, the runtime MUST generate an error.")
+	register(SeccListenerMetadataWithoutPath, "SeccListenerMetadataWithoutPath", rfc2119.MustNot, seccompRef, "This field MUST NOT be set if `listenerPath` is not set.")
+	register(SeccProcessStateJSON, "SeccProcessStateJSON", rfc2119.Must, containerProcessStateRef, "The container runtime MUST send the container process state over the UNIX socket as regular payload serialized in JSON and file descriptors MUST be sent using `SCM_RIGHTS`.")
+	register(SeccProcessStateFdsFirstMsg, "SeccProcessStateFdsFirstMsg", rfc2119.Must, containerProcessStateRef, "If more than one `sendmsg(2)` is used, the file descriptors MUST be sent only in the first call.")
+	register(PersonalityDomainRequired, "PersonalityDomainRequired", rfc2119.Required, personalityRef, "`domain` (string, REQUIRED) - the execution domain.")
+	register(CPUBurstNotLargerThanQuota, "CPUBurstNotLargerThanQuota", rfc2119.Must, cpuRef, "If specified, this value MUST be no larger than any positive `quota` (runtimes MAY generate an error).")
+	register(IntelRdtL3CacheSchemaPrefix, "IntelRdtL3CacheSchemaPrefix", rfc2119.Should, intelrdtRef, "The value SHOULD start with `L3:` and SHOULD NOT contain newlines.")
+	register(IntelRdtMemBwSchemaPrefix, "IntelRdtMemBwSchemaPrefix", rfc2119.Must, intelrdtRef, "The value MUST start with `MB:` and MUST NOT contain newlines.")
+	register(MemoryKernelNotRecommended, "MemoryKernelNotRecommended", rfc2119.ShouldNot, memoryRef, "`kernel` (int64, OPTIONAL, NOT RECOMMENDED) - sets hard limit for kernel memory")
+	register(MemoryKernelTCPNotRecommended, "MemoryKernelTCPNotRecommended", rfc2119.ShouldNot, memoryRef, "`kernelTCP` (int64, OPTIONAL, NOT RECOMMENDED) - sets hard limit for kernel TCP buffer memory")
 }
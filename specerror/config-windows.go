@@ -26,7 +26,7 @@ var (
 )
 
 func init() {
-	register(WindowsLayerFoldersRequired, rfc2119.Must, layerfoldersRef)
-	register(WindowsHyperVPresent, rfc2119.Must, hypervRef)
-	register(WindowsHyperVOmit, rfc2119.Must, hypervRef)
+	register(WindowsLayerFoldersRequired, "WindowsLayerFoldersRequired", rfc2119.Must, layerfoldersRef, "`layerFolders` MUST contain at least one entry.")
+	register(WindowsHyperVPresent, "WindowsHyperVPresent", rfc2119.Must, hypervRef, "If present, the container MUST be run with Hyper-V isolation.")
+	register(WindowsHyperVOmit, "WindowsHyperVOmit", rfc2119.Must, hypervRef, "If omitted, the container MUST be run as a Windows Server container.")
 }
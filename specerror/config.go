@@ -94,6 +94,50 @@ const (
 	ExtensibilityIgnoreUnknownProp
 	// ValidValues represents "Runtimes that are reading or processing this configuration file MUST generate an error when invalid or unsupported values are encountered."
 	ValidValues
+	// MountsIDMapUseMappings represents "If `uidMappings` or `gidMappings` are specified for the mount, the runtime MUST use those values for the mount's mapping."
+	MountsIDMapUseMappings
+	// MountsIDMapWithoutUserNSError represents "If there are no `uidMappings` and `gidMappings` specified and the container isn't using user namespaces, an error MUST be returned."
+	MountsIDMapWithoutUserNSError
+	// MountsIDMapNotPassedToMount represents "This option SHOULD NOT be passed to the underlying `mount(2)` call."
+	MountsIDMapNotPassedToMount
+	// PosixMountsIDMappingsOptions represents "If specified, the `options` field of the `mounts` structure SHOULD contain either `idmap` or `ridmap` to specify whether the mapping should be applied recursively for `rbind` mounts, as well as to ensure that older runtimes will not silently ignore this field."
+	PosixMountsIDMappingsOptions
+	// PosixMountsUIDMappingsWithGIDMappings represents "If specified, it MUST be specified along with `gidMappings`."
+	PosixMountsUIDMappingsWithGIDMappings
+	// PosixMountsGIDMappingsWithUIDMappings represents "If specified, it MUST be specified along with `uidMappings`."
+	PosixMountsGIDMappingsWithUIDMappings
+	// LinuxProcSchedulerPolicyRequired represents "`policy` (string, REQUIRED) represents the scheduling policy."
+	LinuxProcSchedulerPolicyRequired
+	// LinuxProcIOPriorityClassRequired represents "`class` (string, REQUIRED) specifies the I/O scheduling class."
+	LinuxProcIOPriorityClassRequired
+	// LinuxProcIOPriorityPriorityRequired represents "`priority` (int, REQUIRED) specifies the priority level within the class."
+	LinuxProcIOPriorityPriorityRequired
+	// LinuxProcExecCPUAffinityFinalUnchanged represents "If omitted or empty, runtime SHOULD NOT change process' CPU affinity after the process is moved to container's cgroup, and the final affinity is determined by the Linux kernel."
+	LinuxProcExecCPUAffinityFinalUnchanged
+	// PrestartBeforeCreateRuntime represents "The `prestart` hooks MUST be called before the `createRuntime` hooks."
+	PrestartBeforeCreateRuntime
+	// CreateRuntimeTiming represents "The `createRuntime` hooks MUST be called as part of the `create` operation after the runtime environment has been created (according to the configuration in config.json) but before the `pivot_root` or any equivalent operation has been executed."
+	CreateRuntimeTiming
+	// CreateRuntimePathInRuntimeNS represents "The `createRuntime` hooks' path MUST resolve in the runtime namespace."
+	CreateRuntimePathInRuntimeNS
+	// CreateRuntimeInRuntimeNS represents "The `createRuntime` hooks MUST be executed in the runtime namespace."
+	CreateRuntimeInRuntimeNS
+	// CreateContainerTiming represents "The `createContainer` hooks MUST be called as part of the `create` operation after the runtime environment has been created (according to the configuration in config.json) but before the `pivot_root` or any equivalent operation has been executed."
+	CreateContainerTiming
+	// CreateContainerAfterCreateRuntime represents "The `createContainer` hooks MUST be called after the `createRuntime` hooks."
+	CreateContainerAfterCreateRuntime
+	// CreateContainerPathInRuntimeNS represents "The `createContainer` hooks' path MUST resolve in the runtime namespace."
+	CreateContainerPathInRuntimeNS
+	// CreateContainerInContainerNS represents "The `createContainer` hooks MUST be executed in the container namespace."
+	CreateContainerInContainerNS
+	// StartContainerTiming represents "The `startContainer` hooks MUST be called before the user-specified process is executed as part of the `start` operation."
+	StartContainerTiming
+	// StartContainerPathInContainerNS represents "The `startContainer` hooks' path MUST resolve in the container namespace."
+	StartContainerPathInContainerNS
+	// StartContainerInContainerNS represents "The `startContainer` hooks MUST be executed in the container namespace."
+	StartContainerInContainerNS
+	// MountsDestLinuxAbs represents "Linux: This value SHOULD be an absolute path."
+	MountsDestLinuxAbs
 )
 
 var (
@@ -112,9 +156,24 @@ var (
 	posixProcessRef = func(version string) (reference string, err error) {
 		return fmt.Sprintf(referenceTemplate, version, "config.md#posix-process"), nil
 	}
+	userRef = func(version string) (reference string, err error) {
+		return fmt.Sprintf(referenceTemplate, version, "config.md#user"), nil
+	}
+	linuxMountOptionsRef = func(version string) (reference string, err error) {
+		return fmt.Sprintf(referenceTemplate, version, "config.md#linux-mount-options"), nil
+	}
+	posixMountsRef = func(version string) (reference string, err error) {
+		return fmt.Sprintf(referenceTemplate, version, "config.md#posix-platform-mounts"), nil
+	}
 	linuxProcessRef = func(version string) (reference string, err error) {
 		return fmt.Sprintf(referenceTemplate, version, "config.md#linux-process"), nil
 	}
+	hostnameRef = func(version string) (reference string, err error) {
+		return fmt.Sprintf(referenceTemplate, version, "config.md#hostname"), nil
+	}
+	domainnameRef = func(version string) (reference string, err error) {
+		return fmt.Sprintf(referenceTemplate, version, "config.md#domainname"), nil
+	}
 	platformSpecificConfigurationRef = func(version string) (reference string, err error) {
 		return fmt.Sprintf(referenceTemplate, version, "config.md#platform-specific-configuration"), nil
 	}
@@ -130,6 +189,15 @@ var (
 	poststopRef = func(version string) (reference string, err error) {
 		return fmt.Sprintf(referenceTemplate, version, "config.md#poststop"), nil
 	}
+	createRuntimeRef = func(version string) (reference string, err error) {
+		return fmt.Sprintf(referenceTemplate, version, "config.md#createruntime-hooks"), nil
+	}
+	createContainerRef = func(version string) (reference string, err error) {
+		return fmt.Sprintf(referenceTemplate, version, "config.md#createcontainer-hooks"), nil
+	}
+	startContainerRef = func(version string) (reference string, err error) {
+		return fmt.Sprintf(referenceTemplate, version, "config.md#startcontainer-hooks"), nil
+	}
 	annotationsRef = func(version string) (reference string, err error) {
 		return fmt.Sprintf(referenceTemplate, version, "config.md#annotations"), nil
 	}
@@ -142,47 +210,69 @@ var (
 )
 
 func init() {
-	register(SpecVersionInSemVer, rfc2119.Must, specificationVersionRef)
-	register(RootOnWindowsRequired, rfc2119.Required, rootRef)
-	register(RootOnHyperVNotSet, rfc2119.Must, rootRef)
-	register(RootOnNonWindowsRequired, rfc2119.Required, rootRef)
-	register(RootPathOnWindowsGUID, rfc2119.Must, rootRef)
-	register(RootPathOnPosixConvention, rfc2119.Should, rootRef)
-	register(RootPathExist, rfc2119.Must, rootRef)
-	register(RootReadonlyImplement, rfc2119.Must, rootRef)
-	register(RootReadonlyOnWindowsFalse, rfc2119.Must, rootRef)
-	register(MountsInOrder, rfc2119.Must, mountsRef)
-	register(MountsDestAbs, rfc2119.Must, mountsRef)
-	register(MountsDestOnWindowsNotNested, rfc2119.Must, mountsRef)
-	register(MountsOptionsOnWindowsROSupport, rfc2119.Must, mountsRef)
-	register(ProcRequiredAtStart, rfc2119.Required, processRef)
-	register(ProcConsoleSizeIgnore, rfc2119.Must, processRef)
-	register(ProcCwdAbs, rfc2119.Must, processRef)
-	register(ProcArgsOneEntryRequired, rfc2119.Required, processRef)
-	register(PosixProcRlimitsTypeGenError, rfc2119.Must, posixProcessRef)
-	register(PosixProcRlimitsTypeGet, rfc2119.Must, posixProcessRef)
-	register(PosixProcRlimitsTypeValueError, rfc2119.Should, posixProcessRef)
-	register(PosixProcRlimitsSoftMatchCur, rfc2119.Must, posixProcessRef)
-	register(PosixProcRlimitsHardMatchMax, rfc2119.Must, posixProcessRef)
-	register(PosixProcRlimitsErrorOnDup, rfc2119.Must, posixProcessRef)
-	register(LinuxProcCapError, rfc2119.Must, linuxProcessRef)
-	register(LinuxProcOomScoreAdjSet, rfc2119.Must, linuxProcessRef)
-	register(LinuxProcOomScoreAdjNotSet, rfc2119.Must, linuxProcessRef)
-	register(PlatformSpecConfOnWindowsSet, rfc2119.Must, platformSpecificConfigurationRef)
-	register(PosixHooksPathAbs, rfc2119.Must, posixPlatformHooksRef)
-	register(PosixHooksTimeoutPositive, rfc2119.Must, posixPlatformHooksRef)
-	register(PosixHooksCalledInOrder, rfc2119.Must, posixPlatformHooksRef)
-	register(PosixHooksStateToStdin, rfc2119.Must, posixPlatformHooksRef)
-	register(PrestartTiming, rfc2119.Must, prestartRef)
-	register(PoststartTiming, rfc2119.Must, poststartRef)
-	register(PoststopTiming, rfc2119.Must, poststopRef)
-	register(AnnotationsKeyValueMap, rfc2119.Must, annotationsRef)
-	register(AnnotationsKeyString, rfc2119.Must, annotationsRef)
-	register(AnnotationsKeyRequired, rfc2119.Must, annotationsRef)
-	register(AnnotationsKeyReversedDomain, rfc2119.Should, annotationsRef)
-	register(AnnotationsKeyReservedNS, rfc2119.Must, annotationsRef)
-	register(AnnotationsKeyIgnoreUnknown, rfc2119.Must, annotationsRef)
-	register(AnnotationsValueString, rfc2119.Must, annotationsRef)
-	register(ExtensibilityIgnoreUnknownProp, rfc2119.Must, extensibilityRef)
-	register(ValidValues, rfc2119.Must, validValuesRef)
+	register(SpecVersionInSemVer, "SpecVersionInSemVer", rfc2119.Must, specificationVersionRef, "`ociVersion` (string, REQUIRED) MUST be in SemVer v2.0.0 format and specifies the version of the Open Container Initiative Runtime Specification with which the bundle complies.")
+	register(RootOnWindowsRequired, "RootOnWindowsRequired", rfc2119.Required, rootRef, "On Windows, for Windows Server Containers, this field is REQUIRED.")
+	register(RootOnHyperVNotSet, "RootOnHyperVNotSet", rfc2119.Must, rootRef, "For Hyper-V Containers, this field MUST NOT be set.")
+	register(RootOnNonWindowsRequired, "RootOnNonWindowsRequired", rfc2119.Required, rootRef, "On all other platforms, this field is REQUIRED.")
+	register(RootPathOnWindowsGUID, "RootPathOnWindowsGUID", rfc2119.Must, rootRef, "On Windows, `path` MUST be a volume GUID path.")
+	register(RootPathOnPosixConvention, "RootPathOnPosixConvention", rfc2119.Should, rootRef, "The value SHOULD be the conventional `rootfs`.")
+	register(RootPathExist, "RootPathExist", rfc2119.Must, rootRef, "A directory MUST exist at the path declared by the field.")
+	register(RootReadonlyImplement, "RootReadonlyImplement", rfc2119.Must, rootRef, "`readonly` (bool, OPTIONAL) If true then the root filesystem MUST be read-only inside the container, defaults to false.")
+	register(RootReadonlyOnWindowsFalse, "RootReadonlyOnWindowsFalse", rfc2119.Must, rootRef, "* On Windows, this field MUST be omitted or false.")
+	register(MountsInOrder, "MountsInOrder", rfc2119.Must, mountsRef, "The runtime MUST mount entries in the listed order.")
+	register(MountsDestAbs, "MountsDestAbs", rfc2119.Must, mountsRef, "Destination of mount point: path inside container. This value MUST be an absolute path.")
+	register(MountsDestOnWindowsNotNested, "MountsDestOnWindowsNotNested", rfc2119.Must, mountsRef, "Windows: one mount destination MUST NOT be nested within another mount (e.g., c:\\\\foo and c:\\\\foo\\\\bar).")
+	register(MountsOptionsOnWindowsROSupport, "MountsOptionsOnWindowsROSupport", rfc2119.Must, mountsRef, "Windows: runtimes MUST support `ro`, mounting the filesystem read-only when `ro` is given.")
+	register(ProcRequiredAtStart, "ProcRequiredAtStart", rfc2119.Required, processRef, "This property is REQUIRED when `start` is called.")
+	register(ProcConsoleSizeIgnore, "ProcConsoleSizeIgnore", rfc2119.Must, processRef, "Runtimes MUST ignore `consoleSize` if `terminal` is `false` or unset.")
+	register(ProcCwdAbs, "ProcCwdAbs", rfc2119.Must, processRef, "cwd (string, REQUIRED) is the working directory that will be set for the executable. This value MUST be an absolute path.")
+	register(ProcArgsOneEntryRequired, "ProcArgsOneEntryRequired", rfc2119.Required, processRef, "This specification extends the IEEE standard in that at least one entry is REQUIRED, and that entry is used with the same semantics as `execvp`'s *file*.")
+	register(PosixProcRlimitsTypeGenError, "PosixProcRlimitsTypeGenError", rfc2119.Must, posixProcessRef, "The runtime MUST generate an error for any values which cannot be mapped to a relevant kernel interface.")
+	register(PosixProcRlimitsTypeGet, "PosixProcRlimitsTypeGet", rfc2119.Must, posixProcessRef, "For each entry in `rlimits`, a `getrlimit(3)` on `type` MUST succeed.")
+	register(PosixProcRlimitsTypeValueError, "PosixProcRlimitsTypeValueError", rfc2119.Should, posixProcessRef, "valid values are defined in the ... man page")
+	register(PosixProcRlimitsSoftMatchCur, "PosixProcRlimitsSoftMatchCur", rfc2119.Must, posixProcessRef, "`rlim.rlim_cur` MUST match the configured value.")
+	register(PosixProcRlimitsHardMatchMax, "PosixProcRlimitsHardMatchMax", rfc2119.Must, posixProcessRef, "`rlim.rlim_max` MUST match the configured value.")
+	register(PosixProcRlimitsErrorOnDup, "PosixProcRlimitsErrorOnDup", rfc2119.Must, posixProcessRef, "If `rlimits` contains duplicated entries with same `type`, the runtime MUST generate an error.")
+	register(LinuxProcCapError, "LinuxProcCapError", rfc2119.Must, linuxProcessRef, "Any value which cannot be mapped to a relevant kernel interface MUST cause an error.")
+	register(LinuxProcOomScoreAdjSet, "LinuxProcOomScoreAdjSet", rfc2119.Must, linuxProcessRef, "If `oomScoreAdj` is set, the runtime MUST set `oom_score_adj` to the given value.")
+	register(LinuxProcOomScoreAdjNotSet, "LinuxProcOomScoreAdjNotSet", rfc2119.Must, linuxProcessRef, "If `oomScoreAdj` is not set, the runtime MUST NOT change the value of `oom_score_adj`.")
+	register(PlatformSpecConfOnWindowsSet, "PlatformSpecConfOnWindowsSet", rfc2119.Must, platformSpecificConfigurationRef, "This MUST be set if the target platform of this spec is `windows`.")
+	register(PosixHooksPathAbs, "PosixHooksPathAbs", rfc2119.Must, posixPlatformHooksRef, "This specification extends the IEEE standard in that `path` MUST be absolute.")
+	register(PosixHooksTimeoutPositive, "PosixHooksTimeoutPositive", rfc2119.Must, posixPlatformHooksRef, "If set, `timeout` MUST be greater than zero.")
+	register(PosixHooksCalledInOrder, "PosixHooksCalledInOrder", rfc2119.Must, posixPlatformHooksRef, "Hooks MUST be called in the listed order.")
+	register(PosixHooksStateToStdin, "PosixHooksStateToStdin", rfc2119.Must, posixPlatformHooksRef, "The state of the container MUST be passed to hooks over stdin so that they may do work appropriate to the current state of the container.")
+	register(PrestartTiming, "PrestartTiming", rfc2119.Must, prestartRef, "The pre-start hooks MUST be called after the `start` operation is called but before the user-specified program command is executed.")
+	register(PoststartTiming, "PoststartTiming", rfc2119.Must, poststartRef, "The post-start hooks MUST be called after the user-specified process is executed but before the `start` operation returns.")
+	register(PoststopTiming, "PoststopTiming", rfc2119.Must, poststopRef, "The post-stop hooks MUST be called after the container is deleted but before the `delete` operation returns.")
+	register(AnnotationsKeyValueMap, "AnnotationsKeyValueMap", rfc2119.Must, annotationsRef, "Annotations MUST be a key-value map.")
+	register(AnnotationsKeyString, "AnnotationsKeyString", rfc2119.Must, annotationsRef, "Keys MUST be strings.")
+	register(AnnotationsKeyRequired, "AnnotationsKeyRequired", rfc2119.Must, annotationsRef, "Keys MUST NOT be an empty string.")
+	register(AnnotationsKeyReversedDomain, "AnnotationsKeyReversedDomain", rfc2119.Should, annotationsRef, "Keys SHOULD be named using a reverse domain notation - e.g. `com.example.myKey`.")
+	register(AnnotationsKeyReservedNS, "AnnotationsKeyReservedNS", rfc2119.Must, annotationsRef, "Keys using the `org.opencontainers` namespace are reserved and MUST NOT be used by subsequent specifications.")
+	register(AnnotationsKeyIgnoreUnknown, "AnnotationsKeyIgnoreUnknown", rfc2119.Must, annotationsRef, "Implementations that are reading/processing this configuration file MUST NOT generate an error if they encounter an unknown annotation key.")
+	register(AnnotationsValueString, "AnnotationsValueString", rfc2119.Must, annotationsRef, "Values MUST be strings.")
+	register(ExtensibilityIgnoreUnknownProp, "ExtensibilityIgnoreUnknownProp", rfc2119.Must, extensibilityRef, "Runtimes that are reading or processing this configuration file MUST NOT generate an error if they encounter an unknown property.")
+	register(ValidValues, "ValidValues", rfc2119.Must, validValuesRef, "Runtimes that are reading or processing this configuration file MUST generate an error when invalid or unsupported values are encountered.")
+	register(MountsIDMapUseMappings, "MountsIDMapUseMappings", rfc2119.Must, linuxMountOptionsRef, "If `uidMappings` or `gidMappings` are specified for the mount, the runtime MUST use those values for the mount's mapping.")
+	register(MountsIDMapWithoutUserNSError, "MountsIDMapWithoutUserNSError", rfc2119.Must, linuxMountOptionsRef, "If there are no `uidMappings` and `gidMappings` specified and the container isn't using user namespaces, an error MUST be returned.")
+	register(MountsIDMapNotPassedToMount, "MountsIDMapNotPassedToMount", rfc2119.ShouldNot, linuxMountOptionsRef, "This option SHOULD NOT be passed to the underlying `mount(2)` call.")
+	register(PosixMountsIDMappingsOptions, "PosixMountsIDMappingsOptions", rfc2119.Should, posixMountsRef, "If specified, the `options` field of the `mounts` structure SHOULD contain either `idmap` or `ridmap` to specify whether the mapping should be applied recursively for `rbind` mounts, as well as to ensure that older runtimes will not silently ignore this field.")
+	register(PosixMountsUIDMappingsWithGIDMappings, "PosixMountsUIDMappingsWithGIDMappings", rfc2119.Must, posixMountsRef, "If specified, it MUST be specified along with `gidMappings`.")
+	register(PosixMountsGIDMappingsWithUIDMappings, "PosixMountsGIDMappingsWithUIDMappings", rfc2119.Must, posixMountsRef, "If specified, it MUST be specified along with `uidMappings`.")
+	register(LinuxProcSchedulerPolicyRequired, "LinuxProcSchedulerPolicyRequired", rfc2119.Required, linuxProcessRef, "`policy` (string, REQUIRED) represents the scheduling policy.")
+	register(LinuxProcIOPriorityClassRequired, "LinuxProcIOPriorityClassRequired", rfc2119.Required, linuxProcessRef, "`class` (string, REQUIRED) specifies the I/O scheduling class.")
+	register(LinuxProcIOPriorityPriorityRequired, "LinuxProcIOPriorityPriorityRequired", rfc2119.Required, linuxProcessRef, "`priority` (int, REQUIRED) specifies the priority level within the class.")
+	register(LinuxProcExecCPUAffinityFinalUnchanged, "LinuxProcExecCPUAffinityFinalUnchanged", rfc2119.ShouldNot, linuxProcessRef, "If omitted or empty, runtime SHOULD NOT change process' CPU affinity after the process is moved to container's cgroup, and the final affinity is determined by the Linux kernel.")
+	register(PrestartBeforeCreateRuntime, "PrestartBeforeCreateRuntime", rfc2119.Must, prestartRef, "The `prestart` hooks MUST be called before the `createRuntime` hooks.")
+	register(CreateRuntimeTiming, "CreateRuntimeTiming", rfc2119.Must, createRuntimeRef, "The `createRuntime` hooks MUST be called as part of the `create` operation after the runtime environment has been created (according to the configuration in config.json) but before the `pivot_root` or any equivalent operation has been executed.")
+	register(CreateRuntimePathInRuntimeNS, "CreateRuntimePathInRuntimeNS", rfc2119.Must, createRuntimeRef, "The `createRuntime` hooks' path MUST resolve in the runtime namespace.")
+	register(CreateRuntimeInRuntimeNS, "CreateRuntimeInRuntimeNS", rfc2119.Must, createRuntimeRef, "The `createRuntime` hooks MUST be executed in the runtime namespace.")
+	register(CreateContainerTiming, "CreateContainerTiming", rfc2119.Must, createContainerRef, "The `createContainer` hooks MUST be called as part of the `create` operation after the runtime environment has been created (according to the configuration in config.json) but before the `pivot_root` or any equivalent operation has been executed.")
+	register(CreateContainerAfterCreateRuntime, "CreateContainerAfterCreateRuntime", rfc2119.Must, createContainerRef, "The `createContainer` hooks MUST be called after the `createRuntime` hooks.")
+	register(CreateContainerPathInRuntimeNS, "CreateContainerPathInRuntimeNS", rfc2119.Must, createContainerRef, "The `createContainer` hooks' path MUST resolve in the runtime namespace.")
+	register(CreateContainerInContainerNS, "CreateContainerInContainerNS", rfc2119.Must, createContainerRef, "The `createContainer` hooks MUST be executed in the container namespace.")
+	register(StartContainerTiming, "StartContainerTiming", rfc2119.Must, startContainerRef, "The `startContainer` hooks MUST be called before the user-specified process is executed as part of the `start` operation.")
+	register(StartContainerPathInContainerNS, "StartContainerPathInContainerNS", rfc2119.Must, startContainerRef, "The `startContainer` hooks' path MUST resolve in the container namespace.")
+	register(StartContainerInContainerNS, "StartContainerInContainerNS", rfc2119.Must, startContainerRef, "The `startContainer` hooks MUST be executed in the container namespace.")
+	register(MountsDestLinuxAbs, "MountsDestLinuxAbs", rfc2119.Should, mountsRef, "Linux: This value SHOULD be an absolute path.")
 }
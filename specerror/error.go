@@ -4,6 +4,8 @@ package specerror
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/hashicorp/go-multierror"
 	rfc2119 "github.com/opencontainers/runtime-tools/error"
@@ -20,10 +22,30 @@ const (
 	NonError Code = 0x1a001 + iota
 	// NonRFCError represents that an error is not a rfc2119 error
 	NonRFCError
+	// PolicyViolation represents a violation of a user-defined policy
+	// rule, which Error.Rule names.
+	PolicyViolation
 )
 
-type errorTemplate struct {
-	Level     rfc2119.Level
+// String returns the code in hexadecimal, as it is reported in TAP
+// diagnostics and matched by conformance baselines.
+func (code Code) String() string {
+	return fmt.Sprintf("%#x", int64(code))
+}
+
+// Requirement describes a registered code: a spec requirement, or a lint
+// check which the spec does not mandate.
+type Requirement struct {
+	Code Code
+	// Name is the name of the Code constant, e.g. "ProcCwdAbs".
+	Name  string
+	Level rfc2119.Level
+	// Text quotes the requirement from the spec, or describes the lint
+	// check.
+	Text string
+	// Lint is set for checks which the spec does not mandate.
+	Lint bool
+	// Reference returns the URL of the spec section for a spec version.
 	Reference func(version string) (reference string, err error)
 }
 
@@ -34,6 +56,9 @@ type Error struct {
 
 	// Code is a matchable holds a Code
 	Code Code
+
+	// Rule is the ID of the violated policy rule, for PolicyViolation.
+	Rule string
 }
 
 // LevelErrors represents Errors filtered into fatal and warnings.
@@ -46,14 +71,50 @@ type LevelErrors struct {
 	Error *multierror.Error
 }
 
-var ociErrors = map[Code]errorTemplate{}
+var ociErrors = map[Code]Requirement{}
 
-func register(code Code, level rfc2119.Level, ref func(versiong string) (string, error)) {
+func register(code Code, name string, level rfc2119.Level, ref func(versiong string) (string, error), text string) {
 	if _, ok := ociErrors[code]; ok {
 		panic(fmt.Sprintf("should not regist a same code twice: %v", code))
 	}
 
-	ociErrors[code] = errorTemplate{Level: level, Reference: ref}
+	ociErrors[code] = Requirement{Code: code, Name: name, Level: level, Text: text, Reference: ref}
+}
+
+// Codes returns the registered codes in ascending order.
+func Codes() []Code {
+	codes := make([]Code, 0, len(ociErrors))
+	for code := range ociErrors {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+	return codes
+}
+
+// Lookup returns the requirement registered for code.
+func Lookup(code Code) (Requirement, bool) {
+	requirement, ok := ociErrors[code]
+	return requirement, ok
+}
+
+// LookupName returns the requirement registered with the name of a Code
+// constant, ignoring case.
+func LookupName(name string) (Requirement, bool) {
+	for _, requirement := range ociErrors {
+		if strings.EqualFold(requirement.Name, name) {
+			return requirement, true
+		}
+	}
+	return Requirement{}, false
+}
+
+// Catalog returns the registered requirements ordered by code.
+func Catalog() []Requirement {
+	catalog := make([]Requirement, 0, len(ociErrors))
+	for _, code := range Codes() {
+		catalog = append(catalog, ociErrors[code])
+	}
+	return catalog
 }
 
 // Error returns the error message with specification reference.
@@ -107,6 +168,20 @@ func NewError(code Code, err error, version string) error {
 	}
 }
 
+// NewPolicyError creates an Error for a violation of the user-defined
+// policy rule, at the level of the rule.
+func NewPolicyError(rule string, level rfc2119.Level, reference string, err error) error {
+	return &Error{
+		Err: rfc2119.Error{
+			Level:     level,
+			Reference: reference,
+			Err:       fmt.Errorf("policy rule %s: %w", rule, err),
+		},
+		Code: PolicyViolation,
+		Rule: rule,
+	}
+}
+
 // FindError finds an error from a source error (multiple error) and
 // returns the error code if found.
 // If the source error is nil or empty, return NonError.
@@ -0,0 +1,107 @@
+package specerror
+
+import (
+	"fmt"
+
+	rfc2119 "github.com/opencontainers/runtime-tools/error"
+)
+
+// define error codes
+const (
+	// FeaturesCompileTime represents "Hence, the content of the Features structure SHOULD be determined on the compilation time of the runtime, not on the execution time."
+	FeaturesCompileTime Code = 0x10001 + iota
+	// FeaturesNullNotEmpty represents "The `null` value MUST NOT be confused with an empty value such as `0`, `false`, `""`, `[]`, and `{}`."
+	FeaturesNullNotEmpty
+	// FeaturesOCIVersionMinAccept represents "The runtime MUST accept this value as the `ociVersion` property of `config.json`."
+	FeaturesOCIVersionMinAccept
+	// FeaturesOCIVersionMaxAccept represents "The runtime MUST accept this value as the `ociVersion` property of `config.json`."
+	FeaturesOCIVersionMaxAccept
+	// FeaturesOCIVersionMaxNotLessThanMin represents "The value MUST NOT be less than the value of the `ociVersionMin` property."
+	FeaturesOCIVersionMaxNotLessThanMin
+	// FeaturesNoUndefinedProps represents "The Features structure MUST NOT contain properties that are not defined in this version of the Open Container Initiative Runtime Specification."
+	FeaturesNoUndefinedProps
+	// FeaturesHooksSupport represents "The runtime MUST support the elements in this array as the `hooks` property of `config.json`."
+	FeaturesHooksSupport
+	// FeaturesMountOptionsRecognize represents "The runtime MUST recognize the elements in this array as the `options` of `mounts` objects in `config.json`."
+	FeaturesMountOptionsRecognize
+	// FeaturesMountOptionsNoFSSpecific represents "Linux: this array SHOULD NOT contain filesystem-specific mount options that are passed to the mount(2) syscall as `const void *data`."
+	FeaturesMountOptionsNoFSSpecific
+	// FeaturesAnnotationsKeyValue represents "Annotations MUST be a key-value map that follows the same convention as the Key and Values of the `annotations` property of `config.json`."
+	FeaturesAnnotationsKeyValue
+	// FeaturesLinuxNamespacesRecognize represents "The runtime MUST recognize the elements in this array as the `type` of `linux.namespaces` objects in `config.json`."
+	FeaturesLinuxNamespacesRecognize
+	// FeaturesLinuxCapabilitiesRecognize represents "The runtime MUST recognize the elements in this array in the `process.capabilities` object of `config.json`."
+	FeaturesLinuxCapabilitiesRecognize
+	// FeaturesLinuxSeccompActionsRecognize represents "The runtime MUST recognize the elements in this array in the `syscalls[].action` property of the `linux.seccomp` object in `config.json`."
+	FeaturesLinuxSeccompActionsRecognize
+	// FeaturesLinuxSeccompOperatorsRecognize represents "The runtime MUST recognize the elements in this array in the `syscalls[].args[].op` property of the `linux.seccomp` object in `config.json`."
+	FeaturesLinuxSeccompOperatorsRecognize
+	// FeaturesLinuxSeccompArchsRecognize represents "The runtime MUST recognize the elements in this array in the `architectures` property of the `linux.seccomp` object in `config.json`."
+	FeaturesLinuxSeccompArchsRecognize
+	// FeaturesLinuxSeccompKnownFlagsRecognize represents "The runtime MUST recognize the elements in this array in the `flags` property of the `linux.seccomp` object in `config.json`."
+	FeaturesLinuxSeccompKnownFlagsRecognize
+	// FeaturesLinuxSeccompSupportedFlagsSupport represents "The runtime MUST recognize and support the elements in this array in the `flags` property of the `linux.seccomp` object in `config.json`."
+	FeaturesLinuxSeccompSupportedFlagsSupport
+	// FeaturesLinuxMemoryPolicyModesRecognize represents "The runtime MUST recognize the elements in this array as the `mode` of `linux.memoryPolicy` objects in `config.json`."
+	FeaturesLinuxMemoryPolicyModesRecognize
+	// FeaturesLinuxMemoryPolicyFlagsRecognize represents "The runtime MUST recognize the elements in this in the `flags` property of the `linux.memoryPolicy` object in `config.json`."
+	FeaturesLinuxMemoryPolicyFlagsRecognize
+	// FeaturesLinuxIDMapEnabled represents "In such cases, runtimes MUST still set this value to `true`, to indicate that the runtime recognises the `uidMappings` and `gidMappings` properties."
+	FeaturesLinuxIDMapEnabled
+)
+
+var (
+	featuresRef = func(version string) (reference string, err error) {
+		return fmt.Sprintf(referenceTemplate, version, "features.md#features-structure"), nil
+	}
+	featuresSpecificationVersionRef = func(version string) (reference string, err error) {
+		return fmt.Sprintf(referenceTemplate, version, "features.md#specification-version"), nil
+	}
+	featuresHooksRef = func(version string) (reference string, err error) {
+		return fmt.Sprintf(referenceTemplate, version, "features.md#hooks"), nil
+	}
+	featuresMountOptionsRef = func(version string) (reference string, err error) {
+		return fmt.Sprintf(referenceTemplate, version, "features.md#mount-options"), nil
+	}
+	featuresAnnotationsRef = func(version string) (reference string, err error) {
+		return fmt.Sprintf(referenceTemplate, version, "features.md#annotations"), nil
+	}
+	featuresLinuxNamespacesRef = func(version string) (reference string, err error) {
+		return fmt.Sprintf(referenceTemplate, version, "features-linux.md#namespaces"), nil
+	}
+	featuresLinuxCapabilitiesRef = func(version string) (reference string, err error) {
+		return fmt.Sprintf(referenceTemplate, version, "features-linux.md#capabilities"), nil
+	}
+	featuresLinuxSeccompRef = func(version string) (reference string, err error) {
+		return fmt.Sprintf(referenceTemplate, version, "features-linux.md#seccomp"), nil
+	}
+	featuresLinuxMemoryPolicyRef = func(version string) (reference string, err error) {
+		return fmt.Sprintf(referenceTemplate, version, "features-linux.md#memorypolicy"), nil
+	}
+	featuresLinuxMountExtensionsRef = func(version string) (reference string, err error) {
+		return fmt.Sprintf(referenceTemplate, version, "features-linux.md#mountextensions"), nil
+	}
+)
+
+func init() {
+	register(FeaturesCompileTime, "FeaturesCompileTime", rfc2119.Should, featuresRef, "Hence, the content of the Features structure SHOULD be determined on the compilation time of the runtime, not on the execution time.")
+	register(FeaturesNullNotEmpty, "FeaturesNullNotEmpty", rfc2119.MustNot, featuresRef, "The `null` value MUST NOT be confused with an empty value such as `0`, `false`, `\"\"`, `[]`, and `{}`.")
+	register(FeaturesOCIVersionMinAccept, "FeaturesOCIVersionMinAccept", rfc2119.Must, featuresSpecificationVersionRef, "The runtime MUST accept this value as the `ociVersion` property of `config.json`.")
+	register(FeaturesOCIVersionMaxAccept, "FeaturesOCIVersionMaxAccept", rfc2119.Must, featuresSpecificationVersionRef, "The runtime MUST accept this value as the `ociVersion` property of `config.json`.")
+	register(FeaturesOCIVersionMaxNotLessThanMin, "FeaturesOCIVersionMaxNotLessThanMin", rfc2119.MustNot, featuresSpecificationVersionRef, "The value MUST NOT be less than the value of the `ociVersionMin` property.")
+	register(FeaturesNoUndefinedProps, "FeaturesNoUndefinedProps", rfc2119.MustNot, featuresSpecificationVersionRef, "The Features structure MUST NOT contain properties that are not defined in this version of the Open Container Initiative Runtime Specification.")
+	register(FeaturesHooksSupport, "FeaturesHooksSupport", rfc2119.Must, featuresHooksRef, "The runtime MUST support the elements in this array as the `hooks` property of `config.json`.")
+	register(FeaturesMountOptionsRecognize, "FeaturesMountOptionsRecognize", rfc2119.Must, featuresMountOptionsRef, "The runtime MUST recognize the elements in this array as the `options` of `mounts` objects in `config.json`.")
+	register(FeaturesMountOptionsNoFSSpecific, "FeaturesMountOptionsNoFSSpecific", rfc2119.ShouldNot, featuresMountOptionsRef, "Linux: this array SHOULD NOT contain filesystem-specific mount options that are passed to the mount(2) syscall as `const void *data`.")
+	register(FeaturesAnnotationsKeyValue, "FeaturesAnnotationsKeyValue", rfc2119.Must, featuresAnnotationsRef, "Annotations MUST be a key-value map that follows the same convention as the Key and Values of the `annotations` property of `config.json`.")
+	register(FeaturesLinuxNamespacesRecognize, "FeaturesLinuxNamespacesRecognize", rfc2119.Must, featuresLinuxNamespacesRef, "The runtime MUST recognize the elements in this array as the `type` of `linux.namespaces` objects in `config.json`.")
+	register(FeaturesLinuxCapabilitiesRecognize, "FeaturesLinuxCapabilitiesRecognize", rfc2119.Must, featuresLinuxCapabilitiesRef, "The runtime MUST recognize the elements in this array in the `process.capabilities` object of `config.json`.")
+	register(FeaturesLinuxSeccompActionsRecognize, "FeaturesLinuxSeccompActionsRecognize", rfc2119.Must, featuresLinuxSeccompRef, "The runtime MUST recognize the elements in this array in the `syscalls[].action` property of the `linux.seccomp` object in `config.json`.")
+	register(FeaturesLinuxSeccompOperatorsRecognize, "FeaturesLinuxSeccompOperatorsRecognize", rfc2119.Must, featuresLinuxSeccompRef, "The runtime MUST recognize the elements in this array in the `syscalls[].args[].op` property of the `linux.seccomp` object in `config.json`.")
+	register(FeaturesLinuxSeccompArchsRecognize, "FeaturesLinuxSeccompArchsRecognize", rfc2119.Must, featuresLinuxSeccompRef, "The runtime MUST recognize the elements in this array in the `architectures` property of the `linux.seccomp` object in `config.json`.")
+	register(FeaturesLinuxSeccompKnownFlagsRecognize, "FeaturesLinuxSeccompKnownFlagsRecognize", rfc2119.Must, featuresLinuxSeccompRef, "The runtime MUST recognize the elements in this array in the `flags` property of the `linux.seccomp` object in `config.json`.")
+	register(FeaturesLinuxSeccompSupportedFlagsSupport, "FeaturesLinuxSeccompSupportedFlagsSupport", rfc2119.Must, featuresLinuxSeccompRef, "The runtime MUST recognize and support the elements in this array in the `flags` property of the `linux.seccomp` object in `config.json`.")
+	register(FeaturesLinuxMemoryPolicyModesRecognize, "FeaturesLinuxMemoryPolicyModesRecognize", rfc2119.Must, featuresLinuxMemoryPolicyRef, "The runtime MUST recognize the elements in this array as the `mode` of `linux.memoryPolicy` objects in `config.json`.")
+	register(FeaturesLinuxMemoryPolicyFlagsRecognize, "FeaturesLinuxMemoryPolicyFlagsRecognize", rfc2119.Must, featuresLinuxMemoryPolicyRef, "The runtime MUST recognize the elements in this in the `flags` property of the `linux.memoryPolicy` object in `config.json`.")
+	register(FeaturesLinuxIDMapEnabled, "FeaturesLinuxIDMapEnabled", rfc2119.Must, featuresLinuxMountExtensionsRef, "In such cases, runtimes MUST still set this value to `true`, to indicate that the runtime recognises the `uidMappings` and `gidMappings` properties.")
+}
@@ -0,0 +1,143 @@
+package specerror
+
+import (
+	rfc2119 "github.com/opencontainers/runtime-tools/error"
+)
+
+// Lint codes report configurations which the spec does not forbid, but
+// which kernels or runtimes are known to reject, or which depend on the
+// state of the host.  They reference the spec section of the property
+// they check, and are registered with the MAY level, so that SplitLevel
+// only makes them fatal at the "may" compliance level.
+const (
+	// LintPlatformField represents a property set for a platform which does not support it.
+	LintPlatformField Code = 0x1b001 + iota
+	// LintEnvFormat represents an environment variable which is not in the portable "key=value" form.
+	LintEnvFormat
+	// LintProcessArgsNotExecutable represents a process executable which is not executable in the rootfs.
+	LintProcessArgsNotExecutable
+	// LintHookNotExecutable represents a hook which cannot be found or executed on the host.
+	LintHookNotExecutable
+	// LintApparmorProfileNotFound represents an AppArmor profile which cannot be found.
+	LintApparmorProfileNotFound
+	// LintCapabilityNotPermitted represents an effective or ambient capability which the kernel would not grant, as it is not in the required sets.
+	LintCapabilityNotPermitted
+	// LintRlimitSoftAboveHard represents an rlimit whose soft limit is larger than its hard limit.
+	LintRlimitSoftAboveHard
+	// LintMountTypeUnsupported represents a mount type which the target does not support.
+	LintMountTypeUnsupported
+	// LintIDMappingsWithoutUserNS represents user namespace mappings without a new user namespace.
+	LintIDMappingsWithoutUserNS
+	// LintSysctlNamespace represents a namespaced sysctl without a new namespace to set it in.
+	LintSysctlNamespace
+	// LintDevicesPathDup represents devices which share a path.
+	LintDevicesPathDup
+	// LintMemoryLimitOrder represents memory limits which contradict each other.
+	LintMemoryLimitOrder
+	// LintCPUBandwidthRange represents CPU bandwidth values which the kernel rejects.
+	LintCPUBandwidthRange
+	// LintUnifiedValue represents a cgroup v2 value which the kernel rejects.
+	LintUnifiedValue
+	// LintHostResourceMissing represents a resource which does not exist on the host.
+	LintHostResourceMissing
+	// LintSpecVersionUnknown represents an `ociVersion` which is not a runtime-spec release known to runtime-tools.
+	LintSpecVersionUnknown
+	// LintFieldNotInVersion represents a property which the runtime-spec release declared by `ociVersion` does not define.
+	LintFieldNotInVersion
+	// LintExecutableArchitecture represents an executable built for an architecture the container does not run.
+	LintExecutableArchitecture
+	// LintProcessUserNotFound represents a username which the rootfs does not define, or defines with other ids.
+	LintProcessUserNotFound
+	// LintProcessUserUnmapped represents a process uid or gid which the user namespace mappings do not map.
+	LintProcessUserUnmapped
+	// LintProcessUserGroups represents additionalGids without a group which the rootfs lists the user as a member of.
+	LintProcessUserGroups
+	// LintMountOptionUnknown represents a mount option which is known neither to Linux nor to the filesystem type.
+	LintMountOptionUnknown
+	// LintMountOptionsConflict represents mount options which contradict each other.
+	LintMountOptionsConflict
+	// LintMountSourceMissing represents a bind mount source which does not exist on the host.
+	LintMountSourceMissing
+	// LintMountSourceTypeMismatch represents a bind mount whose source and destination are not both directories or both files.
+	LintMountSourceTypeMismatch
+	// LintMountSourceDangerous represents a bind mount which exposes a dangerous host path writable.
+	LintMountSourceDangerous
+	// LintMaskedPathsBypassed represents a mount which exposes paths protected by maskedPaths or readonlyPaths.
+	LintMaskedPathsBypassed
+	// LintCapabilityNotBounded represents a capability which is not in the bounding set, so the process cannot keep it.
+	LintCapabilityNotBounded
+	// LintCapabilityDuplicate represents a capability listed more than once in a set.
+	LintCapabilityDuplicate
+	// LintCapabilityKernel represents a capability which the target kernel does not have.
+	LintCapabilityKernel
+	// LintAmbientWithoutNoNewPrivileges represents ambient capabilities of a non-root user without noNewPrivileges.
+	LintAmbientWithoutNoNewPrivileges
+	// LintApparmorProfileNotLoaded represents an AppArmor profile which the host kernel has not loaded.
+	LintApparmorProfileNotLoaded
+	// LintSelinuxLabelInvalid represents an SELinux process label which is malformed or which the host policy rejects.
+	LintSelinuxLabelInvalid
+	// LintMountLabelInvalid represents an SELinux mount label which is malformed or which the host policy rejects.
+	LintMountLabelInvalid
+	// LintDeviceCgroupInvalid represents a device cgroup rule whose type or access the kernel rejects.
+	LintDeviceCgroupInvalid
+	// LintCPUIdleValue represents a cpu idle value other than 0 or 1, which the kernel rejects.
+	LintCPUIdleValue
+	// LintUTSNamespace represents a hostname or domainname without a new UTS namespace, so that the runtime's are changed.
+	LintUTSNamespace
+	// LintTimeNamespace represents time offsets without a new time namespace to apply them in.
+	LintTimeNamespace
+)
+
+func registerLint(code Code, name string, ref func(version string) (string, error), text string) {
+	register(code, name, rfc2119.May, ref, text)
+	requirement := ociErrors[code]
+	requirement.Lint = true
+	ociErrors[code] = requirement
+}
+
+// IsLint reports whether code is a lint code rather than a spec
+// requirement.
+func IsLint(code Code) bool {
+	return ociErrors[code].Lint
+}
+
+func init() {
+	registerLint(LintPlatformField, "LintPlatformField", platformSpecificConfigurationRef, "A property set for a platform which does not support it.")
+	registerLint(LintEnvFormat, "LintEnvFormat", processRef, "An environment variable which is not in the portable \"key=value\" form.")
+	registerLint(LintProcessArgsNotExecutable, "LintProcessArgsNotExecutable", processRef, "A process executable which is not executable in the rootfs.")
+	registerLint(LintHookNotExecutable, "LintHookNotExecutable", posixPlatformHooksRef, "A hook which cannot be found or executed on the host.")
+	registerLint(LintApparmorProfileNotFound, "LintApparmorProfileNotFound", linuxProcessRef, "An AppArmor profile which cannot be found.")
+	registerLint(LintCapabilityNotPermitted, "LintCapabilityNotPermitted", linuxProcessRef, "An effective or ambient capability which the kernel would not grant, as it is not in the required sets.")
+	registerLint(LintRlimitSoftAboveHard, "LintRlimitSoftAboveHard", posixProcessRef, "An rlimit whose soft limit is larger than its hard limit.")
+	registerLint(LintMountTypeUnsupported, "LintMountTypeUnsupported", posixMountsRef, "A mount type which the target does not support.")
+	registerLint(LintIDMappingsWithoutUserNS, "LintIDMappingsWithoutUserNS", userNamespaceMappingsRef, "User namespace mappings without a new user namespace.")
+	registerLint(LintSysctlNamespace, "LintSysctlNamespace", sysctlRef, "A namespaced sysctl without a new namespace to set it in.")
+	registerLint(LintDevicesPathDup, "LintDevicesPathDup", devicesRef, "Devices which share a path.")
+	registerLint(LintMemoryLimitOrder, "LintMemoryLimitOrder", memoryRef, "Memory limits which contradict each other.")
+	registerLint(LintCPUBandwidthRange, "LintCPUBandwidthRange", cpuRef, "CPU bandwidth values which the kernel rejects.")
+	registerLint(LintUnifiedValue, "LintUnifiedValue", unifiedRef, "A cgroup v2 value which the kernel rejects.")
+	registerLint(LintHostResourceMissing, "LintHostResourceMissing", controlGroupsRef, "A resource which does not exist on the host.")
+	registerLint(LintSpecVersionUnknown, "LintSpecVersionUnknown", specificationVersionRef, "An `ociVersion` which is not a runtime-spec release known to runtime-tools.")
+	registerLint(LintFieldNotInVersion, "LintFieldNotInVersion", specificationVersionRef, "A property which the runtime-spec release declared by `ociVersion` does not define.")
+	registerLint(LintExecutableArchitecture, "LintExecutableArchitecture", processRef, "An executable built for an architecture the container does not run.")
+	registerLint(LintProcessUserNotFound, "LintProcessUserNotFound", userRef, "A username which the rootfs does not define, or defines with other ids.")
+	registerLint(LintProcessUserUnmapped, "LintProcessUserUnmapped", userNamespaceMappingsRef, "A process uid or gid which the user namespace mappings do not map.")
+	registerLint(LintProcessUserGroups, "LintProcessUserGroups", userRef, "AdditionalGids without a group which the rootfs lists the user as a member of.")
+	registerLint(LintMountOptionUnknown, "LintMountOptionUnknown", linuxMountOptionsRef, "A mount option which is known neither to Linux nor to the filesystem type.")
+	registerLint(LintMountOptionsConflict, "LintMountOptionsConflict", linuxMountOptionsRef, "Mount options which contradict each other.")
+	registerLint(LintMountSourceMissing, "LintMountSourceMissing", mountsRef, "A bind mount source which does not exist on the host.")
+	registerLint(LintMountSourceTypeMismatch, "LintMountSourceTypeMismatch", mountsRef, "A bind mount whose source and destination are not both directories or both files.")
+	registerLint(LintMountSourceDangerous, "LintMountSourceDangerous", mountsRef, "A bind mount which exposes a dangerous host path writable.")
+	registerLint(LintMaskedPathsBypassed, "LintMaskedPathsBypassed", maskedPathsRef, "A mount which exposes paths protected by maskedPaths or readonlyPaths.")
+	registerLint(LintCapabilityNotBounded, "LintCapabilityNotBounded", linuxProcessRef, "A capability which is not in the bounding set, so the process cannot keep it.")
+	registerLint(LintCapabilityDuplicate, "LintCapabilityDuplicate", linuxProcessRef, "A capability listed more than once in a set.")
+	registerLint(LintCapabilityKernel, "LintCapabilityKernel", linuxProcessRef, "A capability which the target kernel does not have.")
+	registerLint(LintAmbientWithoutNoNewPrivileges, "LintAmbientWithoutNoNewPrivileges", linuxProcessRef, "Ambient capabilities of a non-root user without noNewPrivileges.")
+	registerLint(LintApparmorProfileNotLoaded, "LintApparmorProfileNotLoaded", linuxProcessRef, "An AppArmor profile which the host kernel has not loaded.")
+	registerLint(LintSelinuxLabelInvalid, "LintSelinuxLabelInvalid", linuxProcessRef, "An SELinux process label which is malformed or which the host policy rejects.")
+	registerLint(LintMountLabelInvalid, "LintMountLabelInvalid", mountLabelRef, "An SELinux mount label which is malformed or which the host policy rejects.")
+	registerLint(LintDeviceCgroupInvalid, "LintDeviceCgroupInvalid", deviceWhitelistRef, "A device cgroup rule whose type or access the kernel rejects.")
+	registerLint(LintCPUIdleValue, "LintCPUIdleValue", cpuRef, "A cpu idle value other than 0 or 1, which the kernel rejects.")
+	registerLint(LintUTSNamespace, "LintUTSNamespace", hostnameRef, "A hostname or domainname without a new UTS namespace, so that the runtime's are changed.")
+	registerLint(LintTimeNamespace, "LintTimeNamespace", timeOffsetRef, "Time offsets without a new time namespace to apply them in.")
+}
@@ -17,5 +17,5 @@ var devSymbolicLinksRef = func(version string) (reference string, err error) {
 }
 
 func init() {
-	register(DefaultRuntimeLinuxSymlinks, rfc2119.Must, devSymbolicLinksRef)
+	register(DefaultRuntimeLinuxSymlinks, "DefaultRuntimeLinuxSymlinks", rfc2119.Must, devSymbolicLinksRef, "While creating the container (step 2 in the lifecycle), runtimes MUST create default symlinks if the source file exists after processing `mounts`.")
 }
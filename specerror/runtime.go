@@ -60,7 +60,7 @@ const (
 	CreateNewContainer
 	// PropsApplyExceptProcOnCreate represents "All of the properties configured in `config.json` except for `process` MUST be applied."
 	PropsApplyExceptProcOnCreate
-	// ProcArgsApplyUntilStart represents `process.args` MUST NOT be applied until triggered by the `start` operation."
+	// ProcArgsApplyUntilStart represents "`process.args` MUST NOT be applied until triggered by the `start` operation."
 	ProcArgsApplyUntilStart
 	// PropApplyFailGenError represents "If the runtime cannot apply a property as specified in the configuration, it MUST generate an error."
 	PropApplyFailGenError
@@ -133,47 +133,47 @@ var (
 )
 
 func init() {
-	register(EntityOperSameContainer, rfc2119.Must, scopeOfAContainerRef)
-	register(StateIDUniq, rfc2119.Must, stateRef)
-	register(StateNewStatus, rfc2119.Must, stateRef)
-	register(DefaultStateJSONPattern, rfc2119.Must, stateRef)
-	register(EnvCreateImplement, rfc2119.Must, lifecycleRef)
-	register(EnvCreateError, rfc2119.Must, lifecycleRef)
-	register(ProcNotRunAtResRequest, rfc2119.Must, lifecycleRef)
-	register(ConfigUpdatesWithoutAffect, rfc2119.Must, lifecycleRef)
-	register(PrestartHooksInvoke, rfc2119.Must, lifecycleRef)
-	register(PrestartHookFailGenError, rfc2119.Must, lifecycleRef)
-	register(ProcImplement, rfc2119.Must, lifecycleRef)
-	register(PoststartHooksInvoke, rfc2119.Must, lifecycleRef)
-	register(PoststartHookFailGenWarn, rfc2119.Must, lifecycleRef)
-	register(UndoCreateSteps, rfc2119.Must, lifecycleRef)
-	register(PoststopHooksInvoke, rfc2119.Must, lifecycleRef)
-	register(PoststopHookFailGenWarn, rfc2119.Must, lifecycleRef)
-	register(ErrorsLeaveStateUnchange, rfc2119.Must, errorsRef)
-	register(WarnsLeaveFlowUnchange, rfc2119.Must, warningsRef)
-	register(DefaultOperations, rfc2119.Must, operationsRef)
-	register(QueryWithoutIDGenError, rfc2119.Must, queryStateRef)
-	register(QueryNonExistGenError, rfc2119.Must, queryStateRef)
-	register(QueryStateImplement, rfc2119.Must, queryStateRef)
-	register(CreateWithBundlePathAndID, rfc2119.Must, createRef)
-	register(CreateWithUniqueID, rfc2119.Must, createRef)
-	register(CreateNewContainer, rfc2119.Must, createRef)
-	register(PropsApplyExceptProcOnCreate, rfc2119.Must, createRef)
-	register(ProcArgsApplyUntilStart, rfc2119.Must, createRef)
-	register(PropApplyFailGenError, rfc2119.Must, createRef)
-	register(PropApplyFailNotCreate, rfc2119.Must, createRef)
-	register(StartWithoutIDGenError, rfc2119.Must, startRef)
-	register(StartNotCreatedHaveNoEffect, rfc2119.Must, startRef)
-	register(StartNotCreatedGenError, rfc2119.Must, startRef)
-	register(StartProcImplement, rfc2119.Must, startRef)
-	register(StartWithProcUnsetGenError, rfc2119.Must, startRef)
-	register(KillWithoutIDGenError, rfc2119.Must, killRef)
-	register(KillNonCreateRunHaveNoEffect, rfc2119.Must, killRef)
-	register(KillNonCreateRunGenError, rfc2119.Must, killRef)
-	register(KillSignalImplement, rfc2119.Must, killRef)
-	register(DeleteWithoutIDGenError, rfc2119.Must, deleteRef)
-	register(DeleteNonStopHaveNoEffect, rfc2119.Must, deleteRef)
-	register(DeleteNonStopGenError, rfc2119.Must, deleteRef)
-	register(DeleteResImplement, rfc2119.Must, deleteRef)
-	register(DeleteOnlyCreatedRes, rfc2119.Must, deleteRef)
+	register(EntityOperSameContainer, "EntityOperSameContainer", rfc2119.Must, scopeOfAContainerRef, "The entity using a runtime to create a container MUST be able to use the operations defined in this specification against that same container.")
+	register(StateIDUniq, "StateIDUniq", rfc2119.Must, stateRef, "`id` (string, REQUIRED) is the container's ID. This MUST be unique across all containers on this host.")
+	register(StateNewStatus, "StateNewStatus", rfc2119.Must, stateRef, "Additional values MAY be defined by the runtime, however, they MUST be used to represent new runtime states not defined above.")
+	register(DefaultStateJSONPattern, "DefaultStateJSONPattern", rfc2119.Must, stateRef, "When serialized in JSON, the format MUST adhere to the default pattern.")
+	register(EnvCreateImplement, "EnvCreateImplement", rfc2119.Must, lifecycleRef, "The container's runtime environment MUST be created according to the configuration in `config.json`.")
+	register(EnvCreateError, "EnvCreateError", rfc2119.Must, lifecycleRef, "If the runtime is unable to create the environment specified in the `config.json`, it MUST generate an error.")
+	register(ProcNotRunAtResRequest, "ProcNotRunAtResRequest", rfc2119.Must, lifecycleRef, "While the resources requested in the `config.json` MUST be created, the user-specified program (from `process`) MUST NOT be run at this time.")
+	register(ConfigUpdatesWithoutAffect, "ConfigUpdatesWithoutAffect", rfc2119.Must, lifecycleRef, "Any updates to `config.json` after this step MUST NOT affect the container.")
+	register(PrestartHooksInvoke, "PrestartHooksInvoke", rfc2119.Must, lifecycleRef, "The prestart hooks MUST be invoked by the runtime.")
+	register(PrestartHookFailGenError, "PrestartHookFailGenError", rfc2119.Must, lifecycleRef, "If any prestart hook fails, the runtime MUST generate an error, stop the container, and continue the lifecycle at step 9.")
+	register(ProcImplement, "ProcImplement", rfc2119.Must, lifecycleRef, "The runtime MUST run the user-specified program, as specified by `process`.")
+	register(PoststartHooksInvoke, "PoststartHooksInvoke", rfc2119.Must, lifecycleRef, "The poststart hooks MUST be invoked by the runtime.")
+	register(PoststartHookFailGenWarn, "PoststartHookFailGenWarn", rfc2119.Must, lifecycleRef, "If any poststart hook fails, the runtime MUST log a warning, but the remaining hooks and lifecycle continue as if the hook had succeeded.")
+	register(UndoCreateSteps, "UndoCreateSteps", rfc2119.Must, lifecycleRef, "The container MUST be destroyed by undoing the steps performed during create phase (step 2).")
+	register(PoststopHooksInvoke, "PoststopHooksInvoke", rfc2119.Must, lifecycleRef, "The poststop hooks MUST be invoked by the runtime.")
+	register(PoststopHookFailGenWarn, "PoststopHookFailGenWarn", rfc2119.Must, lifecycleRef, "If any poststop hook fails, the runtime MUST log a warning, but the remaining hooks and lifecycle continue as if the hook had succeeded.")
+	register(ErrorsLeaveStateUnchange, "ErrorsLeaveStateUnchange", rfc2119.Must, errorsRef, "Unless otherwise stated, generating an error MUST leave the state of the environment as if the operation were never attempted - modulo any possible trivial ancillary changes such as logging.")
+	register(WarnsLeaveFlowUnchange, "WarnsLeaveFlowUnchange", rfc2119.Must, warningsRef, "Unless otherwise stated, logging a warning does not change the flow of the operation; it MUST continue as if the warning had not been logged.")
+	register(DefaultOperations, "DefaultOperations", rfc2119.Must, operationsRef, "Unless otherwise stated, runtimes MUST support the default operations.")
+	register(QueryWithoutIDGenError, "QueryWithoutIDGenError", rfc2119.Must, queryStateRef, "This operation MUST generate an error if it is not provided the ID of a container.")
+	register(QueryNonExistGenError, "QueryNonExistGenError", rfc2119.Must, queryStateRef, "Attempting to query a container that does not exist MUST generate an error.")
+	register(QueryStateImplement, "QueryStateImplement", rfc2119.Must, queryStateRef, "This operation MUST return the state of a container as specified in the State section.")
+	register(CreateWithBundlePathAndID, "CreateWithBundlePathAndID", rfc2119.Must, createRef, "This operation MUST generate an error if it is not provided a path to the bundle and the container ID to associate with the container.")
+	register(CreateWithUniqueID, "CreateWithUniqueID", rfc2119.Must, createRef, "If the ID provided is not unique across all containers within the scope of the runtime, or is not valid in any other way, the implementation MUST generate an error and a new container MUST NOT be created.")
+	register(CreateNewContainer, "CreateNewContainer", rfc2119.Must, createRef, "This operation MUST create a new container.")
+	register(PropsApplyExceptProcOnCreate, "PropsApplyExceptProcOnCreate", rfc2119.Must, createRef, "All of the properties configured in `config.json` except for `process` MUST be applied.")
+	register(ProcArgsApplyUntilStart, "ProcArgsApplyUntilStart", rfc2119.Must, createRef, "`process.args` MUST NOT be applied until triggered by the `start` operation.")
+	register(PropApplyFailGenError, "PropApplyFailGenError", rfc2119.Must, createRef, "If the runtime cannot apply a property as specified in the configuration, it MUST generate an error.")
+	register(PropApplyFailNotCreate, "PropApplyFailNotCreate", rfc2119.Must, createRef, "If the runtime cannot apply a property as specified in the configuration, a new container MUST NOT be created.")
+	register(StartWithoutIDGenError, "StartWithoutIDGenError", rfc2119.Must, startRef, "`start` operation MUST generate an error if it is not provided the container ID.")
+	register(StartNotCreatedHaveNoEffect, "StartNotCreatedHaveNoEffect", rfc2119.Must, startRef, "Attempting to `start` a container that is not `created` MUST have no effect on the container.")
+	register(StartNotCreatedGenError, "StartNotCreatedGenError", rfc2119.Must, startRef, "Attempting to `start` a container that is not `created` MUST generate an error.")
+	register(StartProcImplement, "StartProcImplement", rfc2119.Must, startRef, "`start` operation MUST run the user-specified program as specified by `process`.")
+	register(StartWithProcUnsetGenError, "StartWithProcUnsetGenError", rfc2119.Must, startRef, "`start` operation MUST generate an error if `process` was not set.")
+	register(KillWithoutIDGenError, "KillWithoutIDGenError", rfc2119.Must, killRef, "`kill` operation MUST generate an error if it is not provided the container ID.")
+	register(KillNonCreateRunHaveNoEffect, "KillNonCreateRunHaveNoEffect", rfc2119.Must, killRef, "Attempting to send a signal to a container that is neither `created` nor `running` MUST have no effect on the container.")
+	register(KillNonCreateRunGenError, "KillNonCreateRunGenError", rfc2119.Must, killRef, "Attempting to send a signal to a container that is neither `created` nor `running` MUST generate an error.")
+	register(KillSignalImplement, "KillSignalImplement", rfc2119.Must, killRef, "`kill` operation MUST send the specified signal to the container process.")
+	register(DeleteWithoutIDGenError, "DeleteWithoutIDGenError", rfc2119.Must, deleteRef, "`delete` operation MUST generate an error if it is not provided the container ID.")
+	register(DeleteNonStopHaveNoEffect, "DeleteNonStopHaveNoEffect", rfc2119.Must, deleteRef, "Attempting to `delete` a container that is not `stopped` MUST have no effect on the container.")
+	register(DeleteNonStopGenError, "DeleteNonStopGenError", rfc2119.Must, deleteRef, "Attempting to `delete` a container that is not `stopped` MUST generate an error.")
+	register(DeleteResImplement, "DeleteResImplement", rfc2119.Must, deleteRef, "Deleting a container MUST delete the resources that were created during the `create` step.")
+	register(DeleteOnlyCreatedRes, "DeleteOnlyCreatedRes", rfc2119.Must, deleteRef, "Note that resources associated with the container, but not created by this container, MUST NOT be deleted.")
 }
@@ -0,0 +1,56 @@
+package capabilities
+
+import (
+	"slices"
+
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// AfterExecve returns the capability sets a process with the sets caps,
+// running as uid, has after execve(2) of an executable without file
+// capabilities nor set-user-ID or set-group-ID bits, as the kernel
+// computes them (capabilities(7)):
+//
+//	P'(ambient)     = P(ambient)
+//	P'(permitted)   = P(inheritable) | P(bounding) | P'(ambient)   for uid 0
+//	                = P'(ambient)                                  otherwise
+//	P'(effective)   = P'(permitted)   for uid 0
+//	                = P'(ambient)     otherwise
+//
+// With noNewPrivileges, uid 0 keeps no more than P(permitted) besides
+// the ambient capabilities.  The bounding and inheritable sets are
+// unchanged.  The sets are returned sorted, without duplicates.
+func AfterExecve(caps rspec.LinuxCapabilities, uid uint32, noNewPrivileges bool) rspec.LinuxCapabilities {
+	after := rspec.LinuxCapabilities{
+		Bounding:    capList(caps.Bounding),
+		Inheritable: capList(caps.Inheritable),
+		Ambient:     capList(caps.Ambient),
+	}
+	if uid != 0 {
+		after.Permitted = slices.Clone(after.Ambient)
+		after.Effective = slices.Clone(after.Ambient)
+		return after
+	}
+
+	permitted := capList(caps.Inheritable, caps.Bounding)
+	if noNewPrivileges {
+		permitted = slices.DeleteFunc(permitted, func(c string) bool { return !slices.Contains(caps.Permitted, c) })
+	}
+	after.Permitted = capList(permitted, caps.Ambient)
+	after.Effective = slices.Clone(after.Permitted)
+	return after
+}
+
+// capList returns the union of lists, sorted.
+func capList(lists ...[]string) []string {
+	list := []string{}
+	for _, l := range lists {
+		for _, c := range l {
+			if !slices.Contains(list, c) {
+				list = append(list, c)
+			}
+		}
+	}
+	slices.Sort(list)
+	return list
+}
@@ -0,0 +1,69 @@
+package capabilities
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// kernelVersions are the Linux releases which introduced the
+// capabilities added after 2.2, from capabilities(7).
+var kernelVersions = map[string]string{
+	"CAP_LEASE":              "2.4",
+	"CAP_AUDIT_WRITE":        "2.6.11",
+	"CAP_AUDIT_CONTROL":      "2.6.11",
+	"CAP_SETFCAP":            "2.6.24",
+	"CAP_MAC_OVERRIDE":       "2.6.25",
+	"CAP_MAC_ADMIN":          "2.6.25",
+	"CAP_SYSLOG":             "2.6.37",
+	"CAP_WAKE_ALARM":         "3.0",
+	"CAP_BLOCK_SUSPEND":      "3.5",
+	"CAP_AUDIT_READ":         "3.16",
+	"CAP_PERFMON":            "5.8",
+	"CAP_BPF":                "5.8",
+	"CAP_CHECKPOINT_RESTORE": "5.9",
+}
+
+// KernelVersion returns the Linux release which introduced the
+// capability c, which must be valid.
+func KernelVersion(c string) string {
+	if version, ok := kernelVersions[c]; ok {
+		return version
+	}
+	return "2.2"
+}
+
+// CapSupportedByKernel reports whether the Linux release version, such
+// as "5.4" or "5.15.0-91-generic", has the valid capability c.
+func CapSupportedByKernel(c string, version string) (bool, error) {
+	target, err := parseKernelVersion(version)
+	if err != nil {
+		return false, err
+	}
+	since, err := parseKernelVersion(KernelVersion(c))
+	if err != nil {
+		return false, err
+	}
+	for i := range target {
+		if target[i] != since[i] {
+			return target[i] > since[i], nil
+		}
+	}
+	return true, nil
+}
+
+// parseKernelVersion returns the major, minor and patch numbers of a
+// kernel release, ignoring its suffix.
+func parseKernelVersion(version string) (numbers [3]int, err error) {
+	release, _, _ := strings.Cut(version, "-")
+	parts := strings.Split(release, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return numbers, fmt.Errorf("invalid kernel version %q", version)
+	}
+	for i, part := range parts {
+		if numbers[i], err = strconv.Atoi(part); err != nil || numbers[i] < 0 {
+			return numbers, fmt.Errorf("invalid kernel version %q", version)
+		}
+	}
+	return numbers, nil
+}
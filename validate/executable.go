@@ -0,0 +1,245 @@
+package validate
+
+import (
+	"bytes"
+	"debug/elf"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"runtime"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/opencontainers/runtime-tools/specerror"
+	"github.com/sirupsen/logrus"
+)
+
+// maxInterpreters bounds the chain of shebang interpreters, as the
+// kernel does.
+const maxInterpreters = 4
+
+// elfArch describes the ELF binaries of a seccomp architecture.
+type elfArch struct {
+	machine elf.Machine
+	class   elf.Class
+	data    elf.Data
+}
+
+var elfArchitectures = map[rspec.Arch]elfArch{
+	rspec.ArchX86:         {elf.EM_386, elf.ELFCLASS32, elf.ELFDATA2LSB},
+	rspec.ArchX86_64:      {elf.EM_X86_64, elf.ELFCLASS64, elf.ELFDATA2LSB},
+	rspec.ArchX32:         {elf.EM_X86_64, elf.ELFCLASS32, elf.ELFDATA2LSB},
+	rspec.ArchARM:         {elf.EM_ARM, elf.ELFCLASS32, elf.ELFDATA2LSB},
+	rspec.ArchAARCH64:     {elf.EM_AARCH64, elf.ELFCLASS64, elf.ELFDATA2LSB},
+	rspec.ArchMIPS:        {elf.EM_MIPS, elf.ELFCLASS32, elf.ELFDATA2MSB},
+	rspec.ArchMIPS64:      {elf.EM_MIPS, elf.ELFCLASS64, elf.ELFDATA2MSB},
+	rspec.ArchMIPS64N32:   {elf.EM_MIPS, elf.ELFCLASS32, elf.ELFDATA2MSB},
+	rspec.ArchMIPSEL:      {elf.EM_MIPS, elf.ELFCLASS32, elf.ELFDATA2LSB},
+	rspec.ArchMIPSEL64:    {elf.EM_MIPS, elf.ELFCLASS64, elf.ELFDATA2LSB},
+	rspec.ArchMIPSEL64N32: {elf.EM_MIPS, elf.ELFCLASS32, elf.ELFDATA2LSB},
+	rspec.ArchPPC:         {elf.EM_PPC, elf.ELFCLASS32, elf.ELFDATA2MSB},
+	rspec.ArchPPC64:       {elf.EM_PPC64, elf.ELFCLASS64, elf.ELFDATA2MSB},
+	rspec.ArchPPC64LE:     {elf.EM_PPC64, elf.ELFCLASS64, elf.ELFDATA2LSB},
+	rspec.ArchS390:        {elf.EM_S390, elf.ELFCLASS32, elf.ELFDATA2MSB},
+	rspec.ArchS390X:       {elf.EM_S390, elf.ELFCLASS64, elf.ELFDATA2MSB},
+	rspec.ArchPARISC:      {elf.EM_PARISC, elf.ELFCLASS32, elf.ELFDATA2MSB},
+	rspec.ArchPARISC64:    {elf.EM_PARISC, elf.ELFCLASS64, elf.ELFDATA2MSB},
+	rspec.ArchRISCV64:     {elf.EM_RISCV, elf.ELFCLASS64, elf.ELFDATA2LSB},
+	rspec.ArchLOONGARCH64: {elf.EM_LOONGARCH, elf.ELFCLASS64, elf.ELFDATA2LSB},
+	rspec.ArchM68K:        {elf.EM_68K, elf.ELFCLASS32, elf.ELFDATA2MSB},
+	rspec.ArchSH:          {elf.EM_SH, elf.ELFCLASS32, elf.ELFDATA2LSB},
+	rspec.ArchSHEB:        {elf.EM_SH, elf.ELFCLASS32, elf.ELFDATA2MSB},
+}
+
+// hostArchitectures are the seccomp architectures whose binaries hosts
+// of a GOARCH can run.
+var hostArchitectures = map[string][]rspec.Arch{
+	"386":      {rspec.ArchX86},
+	"amd64":    {rspec.ArchX86_64, rspec.ArchX86, rspec.ArchX32},
+	"arm":      {rspec.ArchARM},
+	"arm64":    {rspec.ArchAARCH64, rspec.ArchARM},
+	"loong64":  {rspec.ArchLOONGARCH64},
+	"mips":     {rspec.ArchMIPS},
+	"mipsle":   {rspec.ArchMIPSEL},
+	"mips64":   {rspec.ArchMIPS64, rspec.ArchMIPS64N32, rspec.ArchMIPS},
+	"mips64le": {rspec.ArchMIPSEL64, rspec.ArchMIPSEL64N32, rspec.ArchMIPSEL},
+	"ppc64":    {rspec.ArchPPC64, rspec.ArchPPC},
+	"ppc64le":  {rspec.ArchPPC64LE},
+	"riscv64":  {rspec.ArchRISCV64},
+	"s390x":    {rspec.ArchS390X, rspec.ArchS390},
+}
+
+// CheckProcessExecutable checks that process.args[0] resolves in the
+// rootfs to an executable, found with the PATH of process.env if it is
+// a bare name, and that its interpreters exist and its architecture is
+// one the container runs.  It only runs for host-specific validation.
+// A rootfs which is missing or empty may be filled in later, so the
+// executable is then only warned about, as in CheckProcess.
+func (v *Validator) CheckProcessExecutable() (errs error) {
+	logrus.Debugf("check process executable")
+
+	if !v.HostSpecific || v.spec.Process == nil || len(v.spec.Process.Args) == 0 || v.spec.Root == nil {
+		return
+	}
+
+	process := v.spec.Process
+	arg := process.Args[0]
+	root := v.rootfsPath()
+	if entries, err := os.ReadDir(root); err != nil || len(entries) == 0 {
+		// CheckProcess already warns about absolute paths.
+		if !path.IsAbs(arg) {
+			logrus.Warnf("executable %q is not available in rootfs currently", arg)
+		}
+		return
+	}
+
+	var candidates []string
+	if strings.Contains(arg, "/") {
+		candidates = []string{containerPath(process.Cwd, arg)}
+	} else {
+		pathEnv, ok := "", false
+		for _, env := range process.Env {
+			if value, found := strings.CutPrefix(env, "PATH="); found {
+				pathEnv, ok = value, true
+			}
+		}
+		if !ok {
+			errs = multierror.Append(errs, specerror.NewError(specerror.LintProcessArgsNotExecutable, fmt.Errorf("cannot look up %q, process.env has no PATH", arg), v.ruleVersion()))
+			return
+		}
+		for _, dir := range strings.Split(pathEnv, ":") {
+			if dir == "" {
+				dir = "."
+			}
+			candidates = append(candidates, containerPath(process.Cwd, path.Join(dir, arg)))
+		}
+	}
+
+	for _, candidate := range candidates {
+		resolved, err := resolveInRoot(root, candidate)
+		if err != nil {
+			continue
+		}
+		fi, err := os.Stat(resolved)
+		if err != nil || !fi.Mode().IsRegular() || fi.Mode()&0o111 == 0 {
+			continue
+		}
+		return v.checkExecutableFormat(root, candidate, resolved, 0)
+	}
+
+	if len(candidates) == 1 {
+		errs = multierror.Append(errs, specerror.NewError(specerror.LintProcessArgsNotExecutable, fmt.Errorf("arg %q is not an executable in the rootfs", arg), v.ruleVersion()))
+	} else {
+		errs = multierror.Append(errs, specerror.NewError(specerror.LintProcessArgsNotExecutable, fmt.Errorf("arg %q is not found in the PATH of process.env in the rootfs", arg), v.ruleVersion()))
+	}
+	return
+}
+
+// containerPath returns the path p in the container, resolved from cwd
+// if it is relative.
+func containerPath(cwd string, p string) string {
+	if path.IsAbs(p) {
+		return p
+	}
+	return path.Join(cwd, p)
+}
+
+// checkExecutableFormat checks the interpreters and architecture of the
+// executable at name in the container, resolved on the host.
+func (v *Validator) checkExecutableFormat(root string, name string, resolved string, depth int) (errs error) {
+	f, err := os.Open(resolved)
+	if err != nil {
+		errs = multierror.Append(errs, err)
+		return
+	}
+	defer f.Close()
+
+	// The kernel reads the shebang line from the first 256 bytes.
+	header := make([]byte, 256)
+	n, err := io.ReadFull(f, header)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		errs = multierror.Append(errs, err)
+		return
+	}
+	header = header[:n]
+
+	switch {
+	case bytes.HasPrefix(header, []byte("#!")):
+		line, _, _ := bytes.Cut(header[2:], []byte("\n"))
+		fields := strings.Fields(string(line))
+		if len(fields) == 0 {
+			errs = multierror.Append(errs, specerror.NewError(specerror.LintProcessArgsNotExecutable, fmt.Errorf("%q has an empty interpreter line", name), v.ruleVersion()))
+			return
+		}
+		if depth >= maxInterpreters {
+			errs = multierror.Append(errs, specerror.NewError(specerror.LintProcessArgsNotExecutable, fmt.Errorf("%q has too many levels of interpreters", name), v.ruleVersion()))
+			return
+		}
+		return v.checkInterpreter(root, name, containerPath(v.spec.Process.Cwd, fields[0]), depth+1)
+	case bytes.HasPrefix(header, []byte(elf.ELFMAG)):
+		file, err := elf.NewFile(f)
+		if err != nil {
+			errs = multierror.Append(errs, specerror.NewError(specerror.LintProcessArgsNotExecutable, fmt.Errorf("%q is not a valid ELF file: %w", name, err), v.ruleVersion()))
+			return
+		}
+		errs = multierror.Append(errs, v.checkELFArchitecture(name, file))
+		for _, prog := range file.Progs {
+			if prog.Type != elf.PT_INTERP {
+				continue
+			}
+			interpreter, err := io.ReadAll(prog.Open())
+			if err != nil {
+				errs = multierror.Append(errs, err)
+				continue
+			}
+			errs = multierror.Append(errs, v.checkInterpreter(root, name, string(bytes.TrimRight(interpreter, "\x00")), depth+1))
+		}
+	}
+	// Other formats may be run by binfmt_misc handlers of the host.
+	return
+}
+
+// checkInterpreter checks that the interpreter of the executable at
+// name exists in the rootfs, and checks its format in turn.
+func (v *Validator) checkInterpreter(root string, name string, interpreter string, depth int) (errs error) {
+	resolved, err := resolveInRoot(root, interpreter)
+	if err == nil {
+		var fi os.FileInfo
+		fi, err = os.Stat(resolved)
+		if err == nil && (!fi.Mode().IsRegular() || fi.Mode()&0o111 == 0) {
+			err = fmt.Errorf("not an executable")
+		}
+	}
+	if err != nil {
+		errs = multierror.Append(errs, specerror.NewError(specerror.LintProcessArgsNotExecutable, fmt.Errorf("interpreter %q of %q is not available in the rootfs: %w", interpreter, name, err), v.ruleVersion()))
+		return
+	}
+	return v.checkExecutableFormat(root, interpreter, resolved, depth)
+}
+
+// checkELFArchitecture checks that an ELF file was built for one of the
+// target architectures: linux.seccomp.architectures if set, or those the
+// host runs.
+func (v *Validator) checkELFArchitecture(name string, file *elf.File) (errs error) {
+	architectures := hostArchitectures[runtime.GOARCH]
+	if v.spec.Linux != nil && v.spec.Linux.Seccomp != nil && len(v.spec.Linux.Seccomp.Architectures) > 0 {
+		architectures = v.spec.Linux.Seccomp.Architectures
+	}
+	if len(architectures) == 0 {
+		return
+	}
+	for _, arch := range architectures {
+		target, ok := elfArchitectures[arch]
+		if !ok {
+			// The binaries of an unknown architecture cannot be told apart.
+			return
+		}
+		if target.machine == file.Machine && target.class == file.Class && target.data == file.Data {
+			return
+		}
+	}
+	errs = multierror.Append(errs, specerror.NewError(specerror.LintExecutableArchitecture, fmt.Errorf("%q is a %s %s executable, which does not run on the target architectures %v", name, file.Class, file.Machine, architectures), v.ruleVersion()))
+	return
+}
@@ -0,0 +1,446 @@
+package validate
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+	osFilepath "github.com/opencontainers/runtime-tools/filepath"
+	"github.com/opencontainers/runtime-tools/specerror"
+	"github.com/sirupsen/logrus"
+)
+
+// linuxFilesystem describes the options of a Linux filesystem type.
+type linuxFilesystem struct {
+	// options are the filesystem-specific options, with a trailing "="
+	// for those taking a value.
+	options []string
+	// anyOptions is set for filesystems whose options are too many or
+	// too device-specific to be listed, and are not checked.
+	anyOptions bool
+}
+
+var (
+	tmpfsOptions = []string{
+		"size=", "nr_blocks=", "nr_inodes=", "mode=", "uid=", "gid=",
+		"huge=", "mpol=", "noswap", "inode32", "inode64",
+		"quota", "usrquota", "grpquota", "usrquota_block_hardlimit=",
+		"usrquota_inode_hardlimit=", "grpquota_block_hardlimit=",
+		"grpquota_inode_hardlimit=", "casefold", "strict_encoding",
+	}
+
+	cgroupControllers = []string{
+		"blkio", "cpu", "cpuacct", "cpuset", "devices", "freezer",
+		"hugetlb", "memory", "misc", "net_cls", "net_prio", "perf_event",
+		"pids", "rdma",
+	}
+)
+
+// linuxFilesystems are the filesystem types known to Linux, checked
+// when the filesystems of the host are not.  The empty type and "none"
+// are used for bind mounts.
+var linuxFilesystems = map[string]linuxFilesystem{
+	"":            {},
+	"none":        {},
+	"bind":        {},
+	"autofs":      {anyOptions: true},
+	"binfmt_misc": {},
+	"bpf":         {options: []string{"mode=", "uid=", "gid=", "delegate_cmds=", "delegate_maps=", "delegate_progs=", "delegate_attachs="}},
+	"cgroup": {options: append([]string{
+		"all", "none", "name=", "noprefix", "release_agent=",
+		"clone_children", "cpuset_v2_mode", "xattr", "favordynmods",
+	}, cgroupControllers...)},
+	"cgroup2": {options: []string{
+		"nsdelegate", "favordynmods", "memory_localevents",
+		"memory_recursiveprot", "memory_hugetlb_accounting",
+		"pids_localevents",
+	}},
+	"configfs":  {},
+	"debugfs":   {options: []string{"uid=", "gid=", "mode="}},
+	"devpts":    {options: []string{"uid=", "gid=", "mode=", "ptmxmode=", "newinstance", "max="}},
+	"devtmpfs":  {options: tmpfsOptions},
+	"efivarfs":  {},
+	"fusectl":   {},
+	"hugetlbfs": {options: []string{"pagesize=", "size=", "min_size=", "nr_inodes=", "mode=", "uid=", "gid="}},
+	"mqueue":    {},
+	"overlay": {options: []string{
+		"lowerdir=", "lowerdir+=", "datadir+=", "upperdir=", "workdir=",
+		"redirect_dir=", "index=", "uuid=", "nfs_export=", "xino=",
+		"metacopy=", "verity=", "volatile", "userxattr",
+		"default_permissions",
+	}},
+	"proc":       {options: []string{"hidepid=", "gid=", "subset="}},
+	"pstore":     {options: []string{"kmsg_bytes="}},
+	"ramfs":      {options: []string{"mode="}},
+	"securityfs": {},
+	"sysfs":      {},
+	"tmpfs":      {options: tmpfsOptions},
+	"tracefs":    {options: []string{"uid=", "gid=", "mode="}},
+
+	"9p":       {anyOptions: true},
+	"btrfs":    {anyOptions: true},
+	"ceph":     {anyOptions: true},
+	"cifs":     {anyOptions: true},
+	"erofs":    {anyOptions: true},
+	"exfat":    {anyOptions: true},
+	"ext2":     {anyOptions: true},
+	"ext3":     {anyOptions: true},
+	"ext4":     {anyOptions: true},
+	"fuse":     {anyOptions: true},
+	"fuseblk":  {anyOptions: true},
+	"iso9660":  {anyOptions: true},
+	"nfs":      {anyOptions: true},
+	"nfs4":     {anyOptions: true},
+	"ntfs3":    {anyOptions: true},
+	"smb3":     {anyOptions: true},
+	"squashfs": {anyOptions: true},
+	"udf":      {anyOptions: true},
+	"vfat":     {anyOptions: true},
+	"virtiofs": {anyOptions: true},
+	"xfs":      {anyOptions: true},
+	"zfs":      {anyOptions: true},
+}
+
+// linuxMountOptions are the filesystem-independent options of the
+// runtime-spec and of mount(8).
+var linuxMountOptions = []string{
+	"async", "atime", "bind", "defaults", "dev", "diratime", "dirsync",
+	"exec", "iversion", "lazytime", "loud", "mand", "noatime", "nodev",
+	"nodiratime", "noexec", "noiversion", "nolazytime", "nomand",
+	"norelatime", "nostrictatime", "nosuid", "nosymfollow", "private",
+	"ratime", "rbind", "rdev", "rdiratime", "relatime", "remount", "rexec",
+	"rnoatime", "rnodev", "rnodiratime", "rnoexec", "rnorelatime",
+	"rnostrictatime", "rnosuid", "rnosymfollow", "ro", "rprivate",
+	"rrelatime", "rro", "rrw", "rshared", "rslave", "rstrictatime", "rsuid",
+	"rsymfollow", "runbindable", "rw", "shared", "silent", "slave",
+	"strictatime", "suid", "symfollow", "sync", "tmpcopyup", "unbindable",
+	"idmap", "ridmap",
+
+	// Options of mount(8) which do not change the mount.
+	"auto", "noauto", "user", "nouser", "users", "owner", "group",
+	"nofail", "_netdev", "comment=",
+
+	// SELinux contexts.
+	"context=", "fscontext=", "defcontext=", "rootcontext=",
+}
+
+// linuxMountOptionConflicts are sets of options of which a mount should
+// have at most one.
+var linuxMountOptionConflicts = [][]string{
+	{"ro", "rw"},
+	{"suid", "nosuid"},
+	{"dev", "nodev"},
+	{"exec", "noexec"},
+	{"sync", "async"},
+	{"mand", "nomand"},
+	{"atime", "noatime"},
+	{"diratime", "nodiratime"},
+	{"relatime", "norelatime"},
+	{"strictatime", "nostrictatime"},
+	{"noatime", "relatime", "strictatime"},
+	{"lazytime", "nolazytime"},
+	{"iversion", "noiversion"},
+	{"symfollow", "nosymfollow"},
+	{"silent", "loud"},
+	{"rro", "rrw"},
+	{"rsuid", "rnosuid"},
+	{"rdev", "rnodev"},
+	{"rexec", "rnoexec"},
+	{"ratime", "rnoatime"},
+	{"rdiratime", "rnodiratime"},
+	{"rrelatime", "rnorelatime"},
+	{"rstrictatime", "rnostrictatime"},
+	{"rnoatime", "rrelatime", "rstrictatime"},
+	{"rsymfollow", "rnosymfollow"},
+	{"bind", "rbind"},
+	{"idmap", "ridmap"},
+	{"private", "rprivate", "shared", "rshared", "slave", "rslave", "unbindable", "runbindable"},
+}
+
+// linuxMountTypes returns the filesystem types of linuxFilesystems.
+func linuxMountTypes() map[string]bool {
+	types := make(map[string]bool, len(linuxFilesystems))
+	for name := range linuxFilesystems {
+		types[name] = true
+	}
+	return types
+}
+
+// linuxMountType returns the filesystem type of a mount type, without
+// the subtype of FUSE types like "fuse.sshfs".
+func linuxMountType(mountType string) string {
+	name, _, _ := strings.Cut(mountType, ".")
+	return name
+}
+
+// checkLinuxMountOptions checks that the options of mounts[i] are known
+// to Linux or to its filesystem type, and do not contradict each other.
+func (v *Validator) checkLinuxMountOptions(i int, mount rspec.Mount) (errs error) {
+	fs, known := linuxFilesystems[linuxMountType(mount.Type)]
+	for _, option := range mount.Options {
+		if strings.HasPrefix(option, "x-") || mountOptionKnown(linuxMountOptions, option) {
+			continue
+		}
+		if !known || fs.anyOptions || mountOptionKnown(fs.options, option) {
+			continue
+		}
+		err := fmt.Errorf("mounts[%d].options %q is not an option of Linux or of the %q filesystem", i, option, mount.Type)
+		if suggestion := closestMountOption(option, append(slices.Clone(linuxMountOptions), fs.options...)); suggestion != "" {
+			err = fmt.Errorf("mounts[%d].options %q is not an option of Linux or of the %q filesystem, did you mean %q?", i, option, mount.Type, suggestion)
+		}
+		errs = multierror.Append(errs, specerror.NewError(specerror.LintMountOptionUnknown, err, v.ruleVersion()))
+	}
+
+	for _, conflict := range linuxMountOptionConflicts {
+		var found []string
+		for _, option := range conflict {
+			if slices.Contains(mount.Options, option) {
+				found = append(found, option)
+			}
+		}
+		if len(found) > 1 {
+			errs = multierror.Append(errs, specerror.NewError(specerror.LintMountOptionsConflict, fmt.Errorf("mounts[%d].options %q contradict each other", i, found), v.ruleVersion()))
+		}
+	}
+	return
+}
+
+// mountOptionKnown reports whether option is one of options, where
+// options ending with "=" match any value.
+func mountOptionKnown(options []string, option string) bool {
+	for _, known := range options {
+		if strings.HasSuffix(known, "=") {
+			if strings.HasPrefix(option, known) {
+				return true
+			}
+		} else if option == known {
+			return true
+		}
+	}
+	return false
+}
+
+// closestMountOption returns the option of options closest to option,
+// if it is close enough to be a typo.
+func closestMountOption(option string, options []string) string {
+	name, _, hasValue := strings.Cut(option, "=")
+	var candidates []string
+	best := 3
+	for _, known := range options {
+		knownName, knownHasValue := strings.CutSuffix(known, "=")
+		if knownHasValue != hasValue {
+			continue
+		}
+		d := editDistance(name, knownName)
+		if d < best {
+			best, candidates = d, []string{knownName}
+		} else if d == best {
+			candidates = append(candidates, knownName)
+		}
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+	sort.Strings(candidates)
+	return candidates[0]
+}
+
+// editDistance returns the Damerau-Levenshtein distance of a and b, so
+// that swapped letters count as one edit.
+func editDistance(a string, b string) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
+
+// DefaultDangerousMountSources are the host paths which bind mounts
+// should not expose writable to containers, nor any path inside them, as
+// they give control of the host.
+var DefaultDangerousMountSources = []string{
+	"/boot",
+	"/etc",
+	"/proc",
+	"/root",
+	"/sys",
+	"/run/containerd/containerd.sock",
+	"/run/crio/crio.sock",
+	"/run/docker.sock",
+	"/run/podman/podman.sock",
+	"/var/run/docker.sock",
+}
+
+// DefaultDangerousMountDirs are the host directories which bind mounts
+// should not expose writable as a whole.  Paths inside them are not
+// dangerous: container engines bind the volumes and the hosts,
+// hostname and resolv.conf files they keep in their state directories,
+// and devices such as /dev/null or /dev/fuse.
+var DefaultDangerousMountDirs = []string{
+	"/dev",
+	"/var/lib/containerd",
+	"/var/lib/docker",
+	"/var/lib/kubelet",
+}
+
+// isBindMount reports whether mount is a bind mount.
+func isBindMount(mount rspec.Mount) bool {
+	return mount.Type == "bind" || slices.Contains(mount.Options, "bind") || slices.Contains(mount.Options, "rbind")
+}
+
+// CheckMountSources checks, for host-specific validation, that the
+// sources of bind mounts exist on the host with the type of their
+// destination in the rootfs, and that they do not expose
+// v.DangerousMountSources nor v.DangerousMountDirs writable.
+func (v *Validator) CheckMountSources() (errs error) {
+	logrus.Debugf("check mount sources")
+
+	if !v.HostSpecific {
+		return
+	}
+
+	for i, mount := range v.spec.Mounts {
+		if !isBindMount(mount) {
+			continue
+		}
+		// Relative bind sources are relative to the bundle.
+		source := mount.Source
+		if !filepath.IsAbs(source) {
+			source = filepath.Join(v.bundlePath, source)
+		}
+		fi, err := os.Stat(source)
+		if err != nil {
+			errs = multierror.Append(errs, specerror.NewError(specerror.LintMountSourceMissing, fmt.Errorf("mounts[%d].source %q does not exist on the host: %w", i, mount.Source, err), v.ruleVersion()))
+			continue
+		}
+
+		if v.spec.Root != nil {
+			if destination, err := resolveInRoot(v.rootfsPath(), mount.Destination); err == nil {
+				if dfi, err := os.Stat(destination); err == nil && dfi.IsDir() != fi.IsDir() {
+					errs = multierror.Append(errs, specerror.NewError(specerror.LintMountSourceTypeMismatch, fmt.Errorf("mounts[%d].source %q and its destination %q in the rootfs are not both directories or both files", i, mount.Source, mount.Destination), v.ruleVersion()))
+				}
+			}
+		}
+
+		errs = multierror.Append(errs, v.checkMountSourceDangerous(i, mount, source, fi))
+	}
+	return
+}
+
+// checkMountSourceDangerous checks that the bind mounts[i] of source
+// neither is, contains nor is inside one of v.DangerousMountSources, and
+// neither is nor contains one of v.DangerousMountDirs, unless it is
+// read-only.  Read-only mounts of sockets can still be
+// connected to, so they are reported too.
+func (v *Validator) checkMountSourceDangerous(i int, mount rspec.Mount, source string, fi os.FileInfo) (errs error) {
+	readonly := slices.Contains(mount.Options, "ro") || slices.Contains(mount.Options, "rro")
+	if readonly && fi.Mode()&os.ModeSocket == 0 {
+		return
+	}
+
+	sources := []string{filepath.Clean(source)}
+	if resolved, err := filepath.EvalSymlinks(source); err == nil && resolved != sources[0] {
+		sources = append(sources, resolved)
+	}
+	dangerousList := append(slices.Clip(v.DangerousMountSources), v.DangerousMountDirs...)
+	for k, dangerous := range dangerousList {
+		inside := k < len(v.DangerousMountSources)
+		dangerousPaths := []string{filepath.Clean(dangerous)}
+		if resolved, err := filepath.EvalSymlinks(dangerous); err == nil && resolved != dangerousPaths[0] {
+			dangerousPaths = append(dangerousPaths, resolved)
+		}
+		for _, s := range sources {
+			for _, d := range dangerousPaths {
+				if !mountSourceOverlaps(s, d, inside) {
+					continue
+				}
+				access := "writable"
+				if readonly {
+					access = "as a socket"
+				}
+				errs = multierror.Append(errs, specerror.NewError(specerror.LintMountSourceDangerous, fmt.Errorf("mounts[%d].source %q exposes the host path %q %s", i, mount.Source, dangerous, access), v.ruleVersion()))
+				return
+			}
+		}
+	}
+	return
+}
+
+// mountSourceOverlaps reports whether source is dangerous, is one of its
+// ancestors, or, if inside is set, is inside it.
+func mountSourceOverlaps(source string, dangerous string, inside bool) bool {
+	if source == dangerous {
+		return true
+	}
+	if ancestor, _ := osFilepath.IsAncestor("linux", source, dangerous, "/"); ancestor {
+		return true
+	}
+	// Every path is inside "/", which is only dangerous as a whole.
+	if !inside || dangerous == "/" {
+		return false
+	}
+	inside, _ = osFilepath.IsAncestor("linux", dangerous, source, "/")
+	return inside
+}
+
+// checkMountsMaskedPaths checks that mounts do not expose the paths
+// protected by linux.maskedPaths and linux.readonlyPaths, which runtimes
+// apply to the paths of the container's /proc and /sys only, and
+// remount read-only without their submounts.
+func (v *Validator) checkMountsMaskedPaths() (errs error) {
+	if v.spec.Linux == nil {
+		return
+	}
+	protected := append(slices.Clone(v.spec.Linux.MaskedPaths), v.spec.Linux.ReadonlyPaths...)
+
+	for i, mount := range v.spec.Mounts {
+		destination := path.Join("/", mount.Destination)
+
+		var root string
+		switch mount.Type {
+		case "proc":
+			root = "/proc"
+		case "sysfs":
+			root = "/sys"
+		}
+		if root != "" && destination != root {
+			for _, p := range protected {
+				if inside, _ := osFilepath.IsAncestor("linux", root, p, "/"); inside {
+					errs = multierror.Append(errs, specerror.NewError(specerror.LintMaskedPathsBypassed, fmt.Errorf("mounts[%d] mounts %s at %q, where %q is neither masked nor read-only", i, mount.Type, mount.Destination, path.Join(destination, strings.TrimPrefix(p, root))), v.ruleVersion()))
+				}
+			}
+		}
+
+		if slices.Contains(mount.Options, "ro") || slices.Contains(mount.Options, "rro") {
+			continue
+		}
+		for _, p := range v.spec.Linux.ReadonlyPaths {
+			if inside, _ := osFilepath.IsAncestor("linux", p, destination, "/"); inside {
+				errs = multierror.Append(errs, specerror.NewError(specerror.LintMaskedPathsBypassed, fmt.Errorf("mounts[%d] at %q is writable inside linux.readonlyPaths %q, which is remounted read-only without its submounts", i, mount.Destination, p), v.ruleVersion()))
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,460 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+	rfc2119 "github.com/opencontainers/runtime-tools/error"
+	"github.com/opencontainers/runtime-tools/specerror"
+	"github.com/sirupsen/logrus"
+)
+
+// PolicyRule is a user-defined rule, checked alongside the spec
+// requirements, for house rules such as requiring a memory limit.
+type PolicyRule struct {
+	// ID names the rule in the errors it reports.
+	ID string
+	// Level is the compliance level of the errors, compared with the
+	// level requested for validation like those of the spec.
+	Level rfc2119.Level
+	// Reference is a URL or a path documenting the rule.
+	Reference string
+	// Check returns the violations of the rule, one error each or a
+	// *multierror.Error of them.
+	Check func(spec *rspec.Spec) error
+}
+
+// AddCheck adds a policy rule which CheckPolicy checks.
+func (v *Validator) AddCheck(rule PolicyRule) error {
+	if rule.ID == "" {
+		return fmt.Errorf("policy rules need an ID")
+	}
+	if rule.Check == nil {
+		return fmt.Errorf("policy rule %s has no check", rule.ID)
+	}
+	for _, r := range v.policy {
+		if r.ID == rule.ID {
+			return fmt.Errorf("policy rule %s is already added", rule.ID)
+		}
+	}
+	v.policy = append(v.policy, rule)
+	return nil
+}
+
+// CheckPolicy checks the policy rules added with AddCheck, reporting
+// their violations as specerror.PolicyViolation errors.
+func (v *Validator) CheckPolicy() (errs error) {
+	logrus.Debugf("check policy")
+
+	for _, rule := range v.policy {
+		err := rule.Check(v.spec)
+		if err == nil {
+			continue
+		}
+		violations := []error{err}
+		if merr, ok := err.(*multierror.Error); ok {
+			violations = merr.Errors
+		}
+		for _, violation := range violations {
+			errs = multierror.Append(errs, specerror.NewPolicyError(rule.ID, rule.Level, rule.Reference, violation))
+		}
+	}
+	return
+}
+
+// policyFile is the format of policy files.
+type policyFile struct {
+	Rules []policyRuleConfig `json:"rules"`
+}
+
+// policyRuleConfig is a rule of a policy file, asserting conditions on
+// the values a path selects in the configuration.
+type policyRuleConfig struct {
+	ID          string `json:"id"`
+	Level       string `json:"level"`
+	Description string `json:"description"`
+	Reference   string `json:"reference"`
+	// Path selects values of the JSON configuration.
+	Path string `json:"path"`
+	// Exists requires the path to select a value, or none.
+	Exists *bool `json:"exists"`
+	// Equals requires the selected values to equal a value.
+	Equals any `json:"equals"`
+	// OneOf requires the selected values to be one of a list.
+	OneOf []any `json:"oneOf"`
+	// Pattern requires the selected values to be strings matching a
+	// regular expression.
+	Pattern string `json:"pattern"`
+	// Min and Max bound the selected numbers.
+	Min *json.Number `json:"min"`
+	Max *json.Number `json:"max"`
+}
+
+// LoadPolicy reads the rules of the policy file at path.  Rules without
+// a reference refer to their ID in the file.
+func LoadPolicy(path string) ([]PolicyRule, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	rules, err := ParsePolicy(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for i := range rules {
+		if rules[i].Reference == "" {
+			rules[i].Reference = path + "#" + rules[i].ID
+		}
+	}
+	return rules, nil
+}
+
+// ParsePolicy parses the rules of a policy file.  A policy file is a
+// JSON object with a list of "rules", each with an "id", a "level" (an
+// RFC 2119 keyword, MUST by default), a "path" selecting values of the
+// configuration and the conditions they must meet: "exists", "equals",
+// "oneOf", "pattern", "min" and "max".
+//
+// A path is a chain of JSON property names separated by dots, as in
+// "linux.resources.memory.limit".  Brackets select the property of a
+// name with dots ["com.example.owner"], an array element [0], all
+// elements or properties [*], or the array elements whose property is,
+// or whose array property contains, a value [?type==bind], or is not
+// [?type!=bind].
+func ParsePolicy(r io.Reader) ([]PolicyRule, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	decoder.DisallowUnknownFields()
+	var file policyFile
+	if err := decoder.Decode(&file); err != nil {
+		return nil, err
+	}
+
+	var rules []PolicyRule
+	for i, config := range file.Rules {
+		rule, err := config.compile()
+		if err != nil {
+			return nil, fmt.Errorf("rules[%d]: %w", i, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// compile returns the PolicyRule checking the conditions of c.
+func (c policyRuleConfig) compile() (rule PolicyRule, err error) {
+	if c.ID == "" {
+		return rule, fmt.Errorf("the rule has no id")
+	}
+	level := rfc2119.Must
+	if c.Level != "" {
+		if level, err = rfc2119.ParseLevel(c.Level); err != nil {
+			return rule, err
+		}
+	}
+	path, err := parsePolicyPath(c.Path)
+	if err != nil {
+		return rule, fmt.Errorf("invalid path %q: %w", c.Path, err)
+	}
+	var pattern *regexp.Regexp
+	if c.Pattern != "" {
+		if pattern, err = regexp.Compile(c.Pattern); err != nil {
+			return rule, err
+		}
+	}
+	if c.Exists == nil && c.Equals == nil && c.OneOf == nil && pattern == nil && c.Min == nil && c.Max == nil {
+		return rule, fmt.Errorf("rule %s has no condition", c.ID)
+	}
+
+	check := func(spec *rspec.Spec) (errs error) {
+		document, err := policyDocument(spec)
+		if err != nil {
+			return err
+		}
+		matches := path.selectValues(document)
+		if c.Exists != nil {
+			if *c.Exists && len(matches) == 0 {
+				errs = multierror.Append(errs, fmt.Errorf("%s is not set", c.Path))
+			}
+			if !*c.Exists {
+				for _, m := range matches {
+					errs = multierror.Append(errs, fmt.Errorf("%s is set", m.path))
+				}
+			}
+		}
+		for _, m := range matches {
+			if c.Equals != nil && !policyEqual(m.value, c.Equals) {
+				errs = multierror.Append(errs, fmt.Errorf("%s is %s, not %s", m.path, policyString(m.value), policyString(c.Equals)))
+			}
+			if c.OneOf != nil && !policyOneOf(m.value, c.OneOf) {
+				errs = multierror.Append(errs, fmt.Errorf("%s is %s, not one of %s", m.path, policyString(m.value), policyString(c.OneOf)))
+			}
+			if pattern != nil {
+				if s, ok := m.value.(string); !ok || !pattern.MatchString(s) {
+					errs = multierror.Append(errs, fmt.Errorf("%s is %s, which does not match %q", m.path, policyString(m.value), c.Pattern))
+				}
+			}
+			if c.Min != nil || c.Max != nil {
+				errs = multierror.Append(errs, checkPolicyBounds(m, c.Min, c.Max))
+			}
+		}
+		return
+	}
+	return PolicyRule{ID: c.ID, Level: level, Reference: c.Reference, Check: check}, nil
+}
+
+// checkPolicyBounds checks that the number of m is within min and max.
+func checkPolicyBounds(m policyMatch, min *json.Number, max *json.Number) (errs error) {
+	n, ok := m.value.(json.Number)
+	if !ok {
+		errs = multierror.Append(errs, fmt.Errorf("%s is %s, not a number", m.path, policyString(m.value)))
+		return
+	}
+	value, _ := n.Float64()
+	if min != nil {
+		if bound, _ := min.Float64(); value < bound {
+			errs = multierror.Append(errs, fmt.Errorf("%s is %s, below the minimum %s", m.path, n, *min))
+		}
+	}
+	if max != nil {
+		if bound, _ := max.Float64(); value > bound {
+			errs = multierror.Append(errs, fmt.Errorf("%s is %s, above the maximum %s", m.path, n, *max))
+		}
+	}
+	return
+}
+
+// policyDocument returns spec in its JSON form, with json.Number
+// numbers.
+func policyDocument(spec *rspec.Spec) (any, error) {
+	content, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	var document any
+	err = decoder.Decode(&document)
+	return document, err
+}
+
+// policyEqual reports whether the JSON values a and b are equal,
+// comparing numbers by value.
+func policyEqual(a any, b any) bool {
+	if na, ok := a.(json.Number); ok {
+		if nb, ok := b.(json.Number); ok {
+			fa, errA := na.Float64()
+			fb, errB := nb.Float64()
+			return errA == nil && errB == nil && fa == fb
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func policyOneOf(value any, values []any) bool {
+	for _, v := range values {
+		if policyEqual(value, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// policyString returns value in JSON.
+func policyString(value any) string {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(content)
+}
+
+// policyStep is a step of a policy path.
+type policyStep struct {
+	// key selects a property, index an array element.
+	key   string
+	index int
+	// all selects all elements or properties.
+	all bool
+	// filter selects the array elements whose property field equals, or
+	// contains, value, or which do not if negate is set.
+	filter bool
+	field  string
+	value  any
+	negate bool
+}
+
+type policyPath []policyStep
+
+// policyMatch is a value selected by a path, with its concrete path.
+type policyMatch struct {
+	path  string
+	value any
+}
+
+var policyIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// parsePolicyPath parses a path, as described by ParsePolicy.
+func parsePolicyPath(s string) (path policyPath, err error) {
+	if s == "" {
+		return nil, fmt.Errorf("empty path")
+	}
+	rest := s
+	for rest != "" {
+		switch {
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated bracket")
+			}
+			step, err := parsePolicySelector(rest[1:end])
+			if err != nil {
+				return nil, err
+			}
+			path = append(path, step)
+			rest = rest[end+1:]
+		case rest[0] == '.' && len(path) > 0:
+			rest = rest[1:]
+			fallthrough
+		default:
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("empty property name")
+			}
+			path = append(path, policyStep{key: rest[:end], index: -1})
+			rest = rest[end:]
+		}
+	}
+	return path, nil
+}
+
+// parsePolicySelector parses the selector between brackets.
+func parsePolicySelector(s string) (policyStep, error) {
+	switch {
+	case s == "*":
+		return policyStep{all: true, index: -1}, nil
+	case strings.HasPrefix(s, `"`):
+		key, err := strconv.Unquote(s)
+		if err != nil {
+			return policyStep{}, fmt.Errorf("invalid property name %s: %w", s, err)
+		}
+		return policyStep{key: key, index: -1}, nil
+	case strings.HasPrefix(s, "?"):
+		negate := false
+		field, literal, ok := strings.Cut(s[1:], "!=")
+		if ok {
+			negate = true
+		} else if field, literal, ok = strings.Cut(s[1:], "=="); !ok {
+			return policyStep{}, fmt.Errorf("invalid filter %q, expected [?field==value] or [?field!=value]", s)
+		}
+		return policyStep{filter: true, field: strings.TrimSpace(field), value: parsePolicyLiteral(strings.TrimSpace(literal)), negate: negate, index: -1}, nil
+	}
+	index, err := strconv.Atoi(s)
+	if err != nil || index < 0 {
+		return policyStep{}, fmt.Errorf("invalid selector [%s]", s)
+	}
+	return policyStep{index: index}, nil
+}
+
+// parsePolicyLiteral parses a filter value as JSON, or as a bare string.
+func parsePolicyLiteral(s string) any {
+	decoder := json.NewDecoder(strings.NewReader(s))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err == nil && !decoder.More() {
+		return value
+	}
+	return s
+}
+
+// selectValues returns the values path selects in document.
+func (path policyPath) selectValues(document any) []policyMatch {
+	matches := []policyMatch{{value: document}}
+	for _, step := range path {
+		var next []policyMatch
+		for _, m := range matches {
+			next = append(next, step.selectValues(m)...)
+		}
+		matches = next
+	}
+	return matches
+}
+
+func (step policyStep) selectValues(m policyMatch) (matches []policyMatch) {
+	switch value := m.value.(type) {
+	case map[string]any:
+		switch {
+		case step.all:
+			keys := make([]string, 0, len(value))
+			for key := range value {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				matches = append(matches, policyMatch{policyKeyPath(m.path, key), value[key]})
+			}
+		case step.key != "":
+			if v, ok := value[step.key]; ok {
+				matches = append(matches, policyMatch{policyKeyPath(m.path, step.key), v})
+			}
+		}
+	case []any:
+		for i, v := range value {
+			switch {
+			case step.all:
+			case step.filter:
+				if step.matchFilter(v) == step.negate {
+					continue
+				}
+			case i != step.index:
+				continue
+			}
+			matches = append(matches, policyMatch{fmt.Sprintf("%s[%d]", m.path, i), v})
+		}
+	}
+	return
+}
+
+// matchFilter reports whether the property step.field of element is, or
+// contains, step.value.
+func (step policyStep) matchFilter(element any) bool {
+	object, ok := element.(map[string]any)
+	if !ok {
+		return false
+	}
+	switch field := object[step.field].(type) {
+	case nil:
+		return false
+	case []any:
+		return policyOneOf(step.value, field)
+	default:
+		return policyEqual(field, step.value)
+	}
+}
+
+// policyKeyPath returns the path of the property key of the value at
+// path.
+func policyKeyPath(path string, key string) string {
+	if !policyIdentifier.MatchString(key) {
+		return fmt.Sprintf("%s[%q]", path, key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,68 @@
+package validate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxSymlinks bounds the symlinks followed when resolving a path, as
+// the kernel does with ELOOP.
+const maxSymlinks = 40
+
+// rootfsPath returns the path of the rootfs on the host.
+func (v *Validator) rootfsPath() string {
+	if filepath.IsAbs(v.spec.Root.Path) {
+		return v.spec.Root.Path
+	}
+	return filepath.Join(v.bundlePath, v.spec.Root.Path)
+}
+
+// resolveInRoot resolves path in the root directory as the kernel would
+// after chroot(2), and returns the resolved path on the host.  Symlinks
+// are followed, with absolute targets and ".." kept inside root.
+// Relative paths are resolved from the root.
+func resolveInRoot(root string, path string) (string, error) {
+	current := "/"
+	remaining := path
+	links := 0
+	for {
+		remaining = strings.TrimLeft(remaining, "/")
+		if remaining == "" {
+			return filepath.Join(root, current), nil
+		}
+		var part string
+		part, remaining, _ = strings.Cut(remaining, "/")
+		switch part {
+		case ".":
+			continue
+		case "..":
+			current = filepath.Dir(current)
+			continue
+		}
+
+		next := filepath.Join(current, part)
+		fi, err := os.Lstat(filepath.Join(root, next))
+		if err != nil {
+			return "", err
+		}
+		if fi.Mode()&os.ModeSymlink == 0 {
+			current = next
+			continue
+		}
+
+		links++
+		if links > maxSymlinks {
+			return "", fmt.Errorf("too many levels of symbolic links resolving %q", path)
+		}
+		target, err := os.Readlink(filepath.Join(root, next))
+		if err != nil {
+			return "", err
+		}
+		if filepath.IsAbs(target) {
+			current = "/"
+		}
+		remaining = target + "/" + remaining
+	}
+}
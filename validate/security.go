@@ -0,0 +1,169 @@
+package validate
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/opencontainers/runtime-tools/specerror"
+	"github.com/opencontainers/selinux/go-selinux"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// apparmorProfilesPath lists the profiles loaded in the kernel, one
+	// "name (mode)" per line.
+	apparmorProfilesPath = "/sys/kernel/security/apparmor/profiles"
+	// apparmorEnabledPath reads "Y" when the kernel enforces AppArmor.
+	apparmorEnabledPath = "/sys/module/apparmor/parameters/enabled"
+	// apparmorUnconfined is the profile of unconfined processes, which
+	// the kernel always knows.
+	apparmorUnconfined = "unconfined"
+)
+
+var (
+	selinuxIdentifier = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_.-]*$`)
+	selinuxLevel      = regexp.MustCompile(`^[A-Za-z0-9_.,:-]+$`)
+)
+
+// readApparmorProfiles returns the modes of the profiles listed in
+// path, in the format of /sys/kernel/security/apparmor/profiles.
+func readApparmorProfiles(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	profiles := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		name, mode := line, ""
+		if i := strings.LastIndex(line, " ("); i >= 0 && strings.HasSuffix(line, ")") {
+			name, mode = line[:i], line[i+2:len(line)-1]
+		}
+		profiles[name] = mode
+	}
+	return profiles, scanner.Err()
+}
+
+// apparmorEnabled reports whether the host kernel enforces AppArmor.
+func apparmorEnabled() bool {
+	buf, err := os.ReadFile(apparmorEnabledPath)
+	return err == nil && strings.HasPrefix(string(buf), "Y")
+}
+
+// CheckApparmorProfileInRootfs checks that process.apparmorProfile has
+// a profile file in /etc/apparmor.d of the rootfs.  Runtimes do not load
+// profiles from there, so this only tells whether the image ships the
+// profile; use CheckApparmorProfile to check the host.
+func (v *Validator) CheckApparmorProfileInRootfs() (errs error) {
+	logrus.Debugf("check apparmor profile in rootfs")
+
+	if v.spec.Process == nil || v.spec.Root == nil {
+		return
+	}
+	profile := v.spec.Process.ApparmorProfile
+	if profile == "" || profile == apparmorUnconfined {
+		return
+	}
+
+	profilePath := filepath.Join(v.rootfsPath(), "/etc/apparmor.d", profile)
+	if _, err := os.Stat(profilePath); err != nil {
+		errs = multierror.Append(errs, specerror.NewError(specerror.LintApparmorProfileNotFound, err, v.ruleVersion()))
+	}
+	return
+}
+
+// CheckApparmorProfile checks that the host kernel has loaded
+// process.apparmorProfile, as runtimes switch to the profile by name.
+// Only the host running the container has the profiles it will use, so
+// nothing is checked without host-specific validation.
+func (v *Validator) CheckApparmorProfile() (errs error) {
+	logrus.Debugf("check apparmor profile")
+
+	if !v.HostSpecific || v.spec.Process == nil {
+		return
+	}
+	profile := v.spec.Process.ApparmorProfile
+	if profile == "" {
+		return
+	}
+
+	profiles, err := readApparmorProfiles(apparmorProfilesPath)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		if apparmorEnabled() {
+			logrus.Warnf("cannot check apparmorProfile %q: securityfs is not mounted", profile)
+			return
+		}
+		return specerror.NewError(specerror.LintApparmorProfileNotLoaded, fmt.Errorf("apparmorProfile %q is set, but AppArmor is not enabled on the host", profile), v.ruleVersion())
+	case err != nil:
+		logrus.Warnf("cannot check apparmorProfile %q: %v", profile, err)
+		return
+	}
+	if _, ok := profiles[profile]; !ok && profile != apparmorUnconfined {
+		errs = multierror.Append(errs, specerror.NewError(specerror.LintApparmorProfileNotLoaded, fmt.Errorf("apparmorProfile %q is not loaded on the host", profile), v.ruleVersion()))
+	}
+	return
+}
+
+// selinuxLabelValid checks that label is an SELinux context of the form
+// "user:role:type[:level]".
+func selinuxLabelValid(label string) error {
+	parts := strings.SplitN(label, ":", 4)
+	if len(parts) < 3 {
+		return fmt.Errorf("%q is not of the form user:role:type[:level]", label)
+	}
+	for i, field := range []string{"user", "role", "type"} {
+		if !selinuxIdentifier.MatchString(parts[i]) {
+			return fmt.Errorf("%q has an invalid %s %q", label, field, parts[i])
+		}
+	}
+	if len(parts) == 4 && !selinuxLevel.MatchString(parts[3]) {
+		return fmt.Errorf("%q has an invalid level %q", label, parts[3])
+	}
+	return nil
+}
+
+// checkSelinuxLabel checks the syntax of label and, for host-specific
+// validation with SELinux enabled, that the host policy defines it.
+func (v *Validator) checkSelinuxLabel(label string) error {
+	if err := selinuxLabelValid(label); err != nil {
+		return err
+	}
+	if !v.HostSpecific || !selinux.GetEnabled() {
+		return nil
+	}
+	if err := selinux.SecurityCheckContext(label); err != nil {
+		if errors.Is(err, os.ErrPermission) {
+			logrus.Warnf("cannot check SELinux label %q: %v", label, err)
+			return nil
+		}
+		return fmt.Errorf("%q is not a valid context for the host policy: %w", label, err)
+	}
+	return nil
+}
+
+// CheckSelinuxLabel checks process.selinuxLabel, and for host-specific
+// validation that the host SELinux policy accepts it.
+func (v *Validator) CheckSelinuxLabel() (errs error) {
+	logrus.Debugf("check selinux label")
+
+	if v.spec.Process == nil || v.spec.Process.SelinuxLabel == "" {
+		return
+	}
+	if err := v.checkSelinuxLabel(v.spec.Process.SelinuxLabel); err != nil {
+		errs = multierror.Append(errs, specerror.NewError(specerror.LintSelinuxLabelInvalid, fmt.Errorf("selinuxLabel %w", err), v.ruleVersion()))
+	}
+	return
+}
@@ -0,0 +1,204 @@
+package validate
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/opencontainers/runtime-tools/specerror"
+	"github.com/sirupsen/logrus"
+)
+
+// passwdEntry is a user of an /etc/passwd file.
+type passwdEntry struct {
+	name string
+	uid  uint32
+	gid  uint32
+}
+
+// groupEntry is a group of an /etc/group file.
+type groupEntry struct {
+	name    string
+	gid     uint32
+	members []string
+}
+
+// readColonFile returns the fields of the lines of a colon-separated
+// file like /etc/passwd, skipping comments, NIS entries and lines with
+// less than n fields.
+func readColonFile(path string, n int) ([][]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries [][]string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "+") || strings.HasPrefix(line, "-") {
+			continue
+		}
+		fields := strings.Split(line, ":")
+		if len(fields) < n {
+			continue
+		}
+		entries = append(entries, fields)
+	}
+	return entries, scanner.Err()
+}
+
+func readPasswd(path string) ([]passwdEntry, error) {
+	lines, err := readColonFile(path, 4)
+	if err != nil {
+		return nil, err
+	}
+	var entries []passwdEntry
+	for _, fields := range lines {
+		uid, err := strconv.ParseUint(fields[2], 10, 32)
+		if err != nil {
+			continue
+		}
+		gid, err := strconv.ParseUint(fields[3], 10, 32)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, passwdEntry{name: fields[0], uid: uint32(uid), gid: uint32(gid)})
+	}
+	return entries, nil
+}
+
+func readGroup(path string) ([]groupEntry, error) {
+	lines, err := readColonFile(path, 3)
+	if err != nil {
+		return nil, err
+	}
+	var entries []groupEntry
+	for _, fields := range lines {
+		gid, err := strconv.ParseUint(fields[2], 10, 32)
+		if err != nil {
+			continue
+		}
+		entry := groupEntry{name: fields[0], gid: uint32(gid)}
+		if len(fields) > 3 && fields[3] != "" {
+			entry.members = strings.Split(fields[3], ",")
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// CheckProcessUser checks that process.user is mapped in a new user
+// namespace and, for host-specific validation on Linux and Solaris, that
+// process.user.username agrees with the users and groups of the rootfs.  The spec only defines
+// username for Windows, so it is reported on other platforms, where
+// runtimes do not resolve it.
+func (v *Validator) CheckProcessUser() (errs error) {
+	logrus.Debugf("check process user")
+
+	if v.spec.Process == nil {
+		return
+	}
+
+	if v.spec.Process.User.Username != "" && v.platform != "windows" {
+		errs = multierror.Append(errs, specerror.NewError(specerror.LintPlatformField, fmt.Errorf("For %q platform, the configuration structure does not support process.user.username", v.platform), v.ruleVersion()))
+	}
+	if v.platform == "linux" {
+		errs = multierror.Append(errs, v.checkProcessUserMappings())
+	}
+	if (v.platform == "linux" || v.platform == "solaris") && v.HostSpecific && v.spec.Process.User.Username != "" && v.spec.Root != nil {
+		errs = multierror.Append(errs, v.checkProcessUsername())
+	}
+	return
+}
+
+// checkProcessUserMappings checks that the ids of process.user are
+// mapped, when the container creates a user namespace with mappings.
+func (v *Validator) checkProcessUserMappings() (errs error) {
+	if v.spec.Linux == nil || !slices.ContainsFunc(v.spec.Linux.Namespaces, func(ns rspec.LinuxNamespace) bool {
+		return ns.Type == rspec.UserNamespace && ns.Path == ""
+	}) {
+		return
+	}
+
+	user := v.spec.Process.User
+	if len(v.spec.Linux.UIDMappings) > 0 && !idMapped(v.spec.Linux.UIDMappings, user.UID) {
+		errs = multierror.Append(errs, specerror.NewError(specerror.LintProcessUserUnmapped, fmt.Errorf("process.user.uid %d is not mapped by linux.uidMappings", user.UID), v.ruleVersion()))
+	}
+	if len(v.spec.Linux.GIDMappings) > 0 {
+		if !idMapped(v.spec.Linux.GIDMappings, user.GID) {
+			errs = multierror.Append(errs, specerror.NewError(specerror.LintProcessUserUnmapped, fmt.Errorf("process.user.gid %d is not mapped by linux.gidMappings", user.GID), v.ruleVersion()))
+		}
+		for _, gid := range user.AdditionalGids {
+			if !idMapped(v.spec.Linux.GIDMappings, gid) {
+				errs = multierror.Append(errs, specerror.NewError(specerror.LintProcessUserUnmapped, fmt.Errorf("process.user.additionalGids %d is not mapped by linux.gidMappings", gid), v.ruleVersion()))
+			}
+		}
+	}
+	return
+}
+
+// idMapped reports whether the container id is in one of mappings.
+func idMapped(mappings []rspec.LinuxIDMapping, id uint32) bool {
+	for _, m := range mappings {
+		if id >= m.ContainerID && uint64(id) < uint64(m.ContainerID)+uint64(m.Size) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkProcessUsername checks that process.user.username is a user of
+// the rootfs with the uid and gid of process.user, and that
+// additionalGids has the groups the user is a member of, as runtimes
+// resolving the username would set them.
+func (v *Validator) checkProcessUsername() (errs error) {
+	user := v.spec.Process.User
+	root := v.rootfsPath()
+
+	passwdPath, err := resolveInRoot(root, "/etc/passwd")
+	if err != nil {
+		errs = multierror.Append(errs, specerror.NewError(specerror.LintProcessUserNotFound, fmt.Errorf("cannot look up username %q in the rootfs: %w", user.Username, err), v.ruleVersion()))
+		return
+	}
+	users, err := readPasswd(passwdPath)
+	if err != nil {
+		errs = multierror.Append(errs, err)
+		return
+	}
+	i := slices.IndexFunc(users, func(u passwdEntry) bool { return u.name == user.Username })
+	if i < 0 {
+		errs = multierror.Append(errs, specerror.NewError(specerror.LintProcessUserNotFound, fmt.Errorf("username %q is not in the rootfs /etc/passwd", user.Username), v.ruleVersion()))
+		return
+	}
+	entry := users[i]
+	if entry.uid != user.UID {
+		errs = multierror.Append(errs, specerror.NewError(specerror.LintProcessUserNotFound, fmt.Errorf("username %q has uid %d in the rootfs /etc/passwd, but process.user.uid is %d", user.Username, entry.uid, user.UID), v.ruleVersion()))
+	}
+	if entry.gid != user.GID {
+		errs = multierror.Append(errs, specerror.NewError(specerror.LintProcessUserNotFound, fmt.Errorf("username %q has gid %d in the rootfs /etc/passwd, but process.user.gid is %d", user.Username, entry.gid, user.GID), v.ruleVersion()))
+	}
+
+	groupPath, err := resolveInRoot(root, "/etc/group")
+	if err != nil {
+		// Users need no supplementary groups.
+		return
+	}
+	groups, err := readGroup(groupPath)
+	if err != nil {
+		errs = multierror.Append(errs, err)
+		return
+	}
+	for _, group := range groups {
+		if slices.Contains(group.members, user.Username) && group.gid != user.GID && !slices.Contains(user.AdditionalGids, group.gid) {
+			errs = multierror.Append(errs, specerror.NewError(specerror.LintProcessUserGroups, fmt.Errorf("username %q is a member of group %q (%d) in the rootfs /etc/group, which is not in process.user.additionalGids", user.Username, group.name, group.gid), v.ruleVersion()))
+		}
+	}
+	return
+}
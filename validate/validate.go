@@ -12,6 +12,7 @@ import (
 	"regexp"
 	"runtime"
 	"slices"
+	"strconv"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -20,6 +21,7 @@ import (
 	"github.com/hashicorp/go-multierror"
 	rspec "github.com/opencontainers/runtime-spec/specs-go"
 	osFilepath "github.com/opencontainers/runtime-tools/filepath"
+	"github.com/opencontainers/runtime-tools/resctrl"
 	capsCheck "github.com/opencontainers/runtime-tools/validate/capabilities"
 	"github.com/sirupsen/logrus"
 
@@ -62,6 +64,21 @@ type Validator struct {
 	bundlePath   string
 	HostSpecific bool
 	platform     string
+	// DangerousMountSources are the host paths which bind mounts should
+	// not expose writable, DefaultDangerousMountSources by default.
+	DangerousMountSources []string
+	// DangerousMountDirs are the host directories which bind mounts
+	// should not expose writable as a whole, DefaultDangerousMountDirs
+	// by default.
+	DangerousMountDirs []string
+	// KernelVersion is the Linux release of the hosts the bundle
+	// targets, such as "5.4", to check the capabilities against.
+	KernelVersion string
+	// ApparmorRootfs checks that process.apparmorProfile is shipped in
+	// the rootfs /etc/apparmor.d when the host is not checked.
+	ApparmorRootfs bool
+	// policy holds the rules added with AddCheck.
+	policy []PolicyRule
 }
 
 // NewValidator creates a Validator
@@ -74,6 +91,9 @@ func NewValidator(spec *rspec.Spec, bundlePath string, hostSpecific bool, platfo
 		bundlePath:   bundlePath,
 		HostSpecific: hostSpecific,
 		platform:     platform,
+
+		DangerousMountSources: slices.Clone(DefaultDangerousMountSources),
+		DangerousMountDirs:    slices.Clone(DefaultDangerousMountDirs),
 	}, nil
 }
 
@@ -111,6 +131,7 @@ func (v *Validator) CheckAll() error {
 	errs = multierror.Append(errs, v.CheckRoot())
 	errs = multierror.Append(errs, v.CheckMandatoryFields())
 	errs = multierror.Append(errs, v.CheckSemVer())
+	errs = multierror.Append(errs, v.CheckVersionFields())
 	errs = multierror.Append(errs, v.CheckMounts())
 	errs = multierror.Append(errs, v.CheckProcess())
 	errs = multierror.Append(errs, v.CheckLinux())
@@ -118,6 +139,7 @@ func (v *Validator) CheckAll() error {
 	if v.platform == "linux" || v.platform == "solaris" {
 		errs = multierror.Append(errs, v.CheckHooks())
 	}
+	errs = multierror.Append(errs, v.CheckPolicy())
 
 	return errs.ErrorOrNil()
 }
@@ -159,7 +181,7 @@ func (v *Validator) CheckJSONSchema() (errs error) {
 
 	if !result.Valid() {
 		for _, resultError := range result.Errors() {
-			errs = multierror.Append(errs, errors.New(resultError.String()))
+			errs = multierror.Append(errs, specerror.NewError(specerror.ValidValues, errors.New(resultError.String()), v.ruleVersion()))
 		}
 	}
 
@@ -174,17 +196,17 @@ func (v *Validator) CheckRoot() (errs error) {
 		if v.spec.Windows != nil && v.spec.Windows.HyperV != nil {
 			if v.spec.Root != nil {
 				errs = multierror.Append(errs,
-					specerror.NewError(specerror.RootOnHyperVNotSet, fmt.Errorf("for Hyper-V containers, Root must not be set"), rspec.Version))
+					specerror.NewError(specerror.RootOnHyperVNotSet, fmt.Errorf("for Hyper-V containers, Root must not be set"), v.ruleVersion()))
 			}
 			return
 		} else if v.spec.Root == nil {
 			errs = multierror.Append(errs,
-				specerror.NewError(specerror.RootOnWindowsRequired, fmt.Errorf("on Windows, for Windows Server Containers, Root is REQUIRED"), rspec.Version))
+				specerror.NewError(specerror.RootOnWindowsRequired, fmt.Errorf("on Windows, for Windows Server Containers, Root is REQUIRED"), v.ruleVersion()))
 			return
 		}
 	} else if v.spec.Root == nil {
 		errs = multierror.Append(errs,
-			specerror.NewError(specerror.RootOnNonWindowsRequired, fmt.Errorf("on all other platforms, Root is REQUIRED"), rspec.Version))
+			specerror.NewError(specerror.RootOnNonWindowsRequired, fmt.Errorf("on all other platforms, Root is REQUIRED"), v.ruleVersion()))
 		return
 	}
 
@@ -194,12 +216,12 @@ func (v *Validator) CheckRoot() (errs error) {
 			errs = multierror.Append(errs, err)
 		} else if !matched {
 			errs = multierror.Append(errs,
-				specerror.NewError(specerror.RootPathOnWindowsGUID, fmt.Errorf("root.path is %q, but it MUST be a volume GUID path when target platform is windows", v.spec.Root.Path), rspec.Version))
+				specerror.NewError(specerror.RootPathOnWindowsGUID, fmt.Errorf("root.path is %q, but it MUST be a volume GUID path when target platform is windows", v.spec.Root.Path), v.ruleVersion()))
 		}
 
 		if v.spec.Root.Readonly {
 			errs = multierror.Append(errs,
-				specerror.NewError(specerror.RootReadonlyOnWindowsFalse, fmt.Errorf("root.readonly field MUST be omitted or false when target platform is windows"), rspec.Version))
+				specerror.NewError(specerror.RootReadonlyOnWindowsFalse, fmt.Errorf("root.readonly field MUST be omitted or false when target platform is windows"), v.ruleVersion()))
 		}
 
 		return
@@ -213,7 +235,7 @@ func (v *Validator) CheckRoot() (errs error) {
 
 	if filepath.Base(v.spec.Root.Path) != "rootfs" {
 		errs = multierror.Append(errs,
-			specerror.NewError(specerror.RootPathOnPosixConvention, fmt.Errorf("path name should be the conventional 'rootfs'"), rspec.Version))
+			specerror.NewError(specerror.RootPathOnPosixConvention, fmt.Errorf("path name should be the conventional 'rootfs'"), v.ruleVersion()))
 	}
 
 	var rootfsPath string
@@ -233,16 +255,16 @@ func (v *Validator) CheckRoot() (errs error) {
 
 	if fi, err := os.Stat(rootfsPath); err != nil {
 		errs = multierror.Append(errs,
-			specerror.NewError(specerror.RootPathExist, fmt.Errorf("cannot find the root path %q", rootfsPath), rspec.Version))
+			specerror.NewError(specerror.RootPathExist, fmt.Errorf("cannot find the root path %q", rootfsPath), v.ruleVersion()))
 	} else if !fi.IsDir() {
 		errs = multierror.Append(errs,
-			specerror.NewError(specerror.RootPathExist, fmt.Errorf("root.path %q is not a directory", rootfsPath), rspec.Version))
+			specerror.NewError(specerror.RootPathExist, fmt.Errorf("root.path %q is not a directory", rootfsPath), v.ruleVersion()))
 	}
 
 	rootParent := filepath.Dir(absRootPath)
 	if absRootPath == string(filepath.Separator) || rootParent != absBundlePath {
 		errs = multierror.Append(errs,
-			specerror.NewError(specerror.ArtifactsInSingleDir, fmt.Errorf("root.path is %q, but it MUST be a child of %q", v.spec.Root.Path, absBundlePath), rspec.Version))
+			specerror.NewError(specerror.ArtifactsInSingleDir, fmt.Errorf("root.path is %q, but it MUST be a child of %q", v.spec.Root.Path, absBundlePath), v.ruleVersion()))
 	}
 
 	return
@@ -253,13 +275,20 @@ func (v *Validator) CheckSemVer() (errs error) {
 	logrus.Debugf("check semver")
 
 	version := v.spec.Version
-	_, err := semver.Parse(version)
+	ver, err := parseSpecVersion(version)
 	if err != nil {
 		errs = multierror.Append(errs,
 			specerror.NewError(specerror.SpecVersionInSemVer, fmt.Errorf("%q is not valid SemVer: %s", version, err.Error()), rspec.Version))
+		return
+	}
+	release, ok := specRelease(ver)
+	if !ok {
+		errs = multierror.Append(errs, fmt.Errorf("validate handles runtime-spec 1.x versions from %s on, but the supplied configuration targets %s", specReleases[0], version))
+		return
 	}
-	if version != rspec.Version {
-		errs = multierror.Append(errs, fmt.Errorf("validate currently only handles version %s, but the supplied configuration targets %s", rspec.Version, version))
+	if !ver.EQ(semver.MustParse(release)) {
+		errs = multierror.Append(errs,
+			specerror.NewError(specerror.LintSpecVersionUnknown, fmt.Errorf("%s is not a runtime-spec release known to validate, checking it against the rules of %s", version, release), release))
 	}
 
 	return
@@ -270,12 +299,16 @@ func (v *Validator) CheckHooks() (errs error) {
 	logrus.Debugf("check hooks")
 
 	if v.platform != "linux" && v.platform != "solaris" {
-		errs = multierror.Append(errs, fmt.Errorf("For %q platform, the configuration structure does not support hooks", v.platform))
+		errs = multierror.Append(errs, specerror.NewError(specerror.LintPlatformField, fmt.Errorf("For %q platform, the configuration structure does not support hooks", v.platform), v.ruleVersion()))
 		return
 	}
 
 	if v.spec.Hooks != nil {
 		errs = multierror.Append(errs, v.checkEventHooks("prestart", v.spec.Hooks.Prestart, v.HostSpecific)) //nolint:staticcheck // Ignore SA1019: v.Spec.Hooks.Prestart is deprecated
+		errs = multierror.Append(errs, v.checkEventHooks("createRuntime", v.spec.Hooks.CreateRuntime, v.HostSpecific))
+		errs = multierror.Append(errs, v.checkEventHooks("createContainer", v.spec.Hooks.CreateContainer, v.HostSpecific))
+		// startContainer hooks resolve in the container namespace, not on the host.
+		errs = multierror.Append(errs, v.checkEventHooks("startContainer", v.spec.Hooks.StartContainer, false))
 		errs = multierror.Append(errs, v.checkEventHooks("poststart", v.spec.Hooks.Poststart, v.HostSpecific))
 		errs = multierror.Append(errs, v.checkEventHooks("poststop", v.spec.Hooks.Poststop, v.HostSpecific))
 	}
@@ -291,22 +324,30 @@ func (v *Validator) checkEventHooks(hookType string, hooks []rspec.Hook, hostSpe
 					specerror.PosixHooksPathAbs,
 					fmt.Errorf("hooks.%s[%d].path %v: is not absolute path",
 						hookType, i, hook.Path),
-					rspec.Version))
+					v.ruleVersion()))
+		}
+
+		if hook.Timeout != nil && *hook.Timeout <= 0 {
+			errs = multierror.Append(errs,
+				specerror.NewError(
+					specerror.PosixHooksTimeoutPositive,
+					fmt.Errorf("hooks.%s[%d].timeout %d: is not greater than zero",
+						hookType, i, *hook.Timeout),
+					v.ruleVersion()))
 		}
 
 		if hostSpecific {
 			fi, err := os.Stat(hook.Path)
 			if err != nil {
-				errs = multierror.Append(errs, fmt.Errorf("cannot find %s hook: %v", hookType, hook.Path))
-			}
-			if fi.Mode()&0o111 == 0 {
-				errs = multierror.Append(errs, fmt.Errorf("the %s hook %v: is not executable", hookType, hook.Path))
+				errs = multierror.Append(errs, specerror.NewError(specerror.LintHookNotExecutable, fmt.Errorf("cannot find %s hook: %v", hookType, hook.Path), v.ruleVersion()))
+			} else if fi.Mode()&0o111 == 0 {
+				errs = multierror.Append(errs, specerror.NewError(specerror.LintHookNotExecutable, fmt.Errorf("the %s hook %v: is not executable", hookType, hook.Path), v.ruleVersion()))
 			}
 		}
 
 		for _, env := range hook.Env {
 			if !envValid(env) {
-				errs = multierror.Append(errs, fmt.Errorf("env %q for hook %v is in the invalid form", env, hook.Path))
+				errs = multierror.Append(errs, specerror.NewError(specerror.LintEnvFormat, fmt.Errorf("env %q for hook %v is in the invalid form", env, hook.Path), v.ruleVersion()))
 			}
 		}
 	}
@@ -328,12 +369,12 @@ func (v *Validator) CheckProcess() (errs error) {
 			specerror.NewError(
 				specerror.ProcCwdAbs,
 				fmt.Errorf("cwd %q is not an absolute path", process.Cwd),
-				rspec.Version))
+				v.ruleVersion()))
 	}
 
 	for _, env := range process.Env {
 		if !envValid(env) {
-			errs = multierror.Append(errs, fmt.Errorf("env %q should be in the form of 'key=value'. The left hand side must consist solely of letters, digits, and underscores '_'", env))
+			errs = multierror.Append(errs, specerror.NewError(specerror.LintEnvFormat, fmt.Errorf("env %q should be in the form of 'key=value'. The left hand side must consist solely of letters, digits, and underscores '_'", env), v.ruleVersion()))
 		}
 	}
 
@@ -342,16 +383,12 @@ func (v *Validator) CheckProcess() (errs error) {
 			specerror.NewError(
 				specerror.ProcArgsOneEntryRequired,
 				fmt.Errorf("args must not be empty"),
-				rspec.Version))
+				v.ruleVersion()))
+	} else if v.HostSpecific && v.platform == "linux" {
+		errs = multierror.Append(errs, v.CheckProcessExecutable())
 	} else {
 		if filepath.IsAbs(process.Args[0]) && v.spec.Root != nil {
-			var rootfsPath string
-			if filepath.IsAbs(v.spec.Root.Path) {
-				rootfsPath = v.spec.Root.Path
-			} else {
-				rootfsPath = filepath.Join(v.bundlePath, v.spec.Root.Path)
-			}
-			absPath := filepath.Join(rootfsPath, process.Args[0])
+			absPath := filepath.Join(v.rootfsPath(), process.Args[0])
 			fileinfo, err := os.Stat(absPath)
 			if os.IsNotExist(err) {
 				logrus.Warnf("executable %q is not available in rootfs currently", process.Args[0])
@@ -360,7 +397,7 @@ func (v *Validator) CheckProcess() (errs error) {
 			} else {
 				m := fileinfo.Mode()
 				if m.IsDir() || m&0o111 == 0 {
-					errs = multierror.Append(errs, fmt.Errorf("arg %q is not executable", process.Args[0]))
+					errs = multierror.Append(errs, specerror.NewError(specerror.LintProcessArgsNotExecutable, fmt.Errorf("arg %q is not executable", process.Args[0]), v.ruleVersion()))
 				}
 			}
 		}
@@ -368,6 +405,7 @@ func (v *Validator) CheckProcess() (errs error) {
 
 	if v.platform == "linux" || v.platform == "solaris" {
 		errs = multierror.Append(errs, v.CheckRlimits())
+		errs = multierror.Append(errs, v.CheckProcessUser())
 	}
 
 	if v.platform == "linux" {
@@ -375,13 +413,12 @@ func (v *Validator) CheckProcess() (errs error) {
 			errs = multierror.Append(errs, v.CheckCapabilities())
 		}
 
-		if len(process.ApparmorProfile) > 0 {
-			profilePath := filepath.Join(v.bundlePath, v.spec.Root.Path, "/etc/apparmor.d", process.ApparmorProfile)
-			_, err := os.Stat(profilePath)
-			if err != nil {
-				errs = multierror.Append(errs, err)
-			}
+		if v.HostSpecific {
+			errs = multierror.Append(errs, v.CheckApparmorProfile())
+		} else if v.ApparmorRootfs {
+			errs = multierror.Append(errs, v.CheckApparmorProfileInRootfs())
 		}
+		errs = multierror.Append(errs, v.CheckSelinuxLabel())
 	}
 
 	return
@@ -390,7 +427,7 @@ func (v *Validator) CheckProcess() (errs error) {
 // CheckCapabilities checks v.spec.Process.Capabilities
 func (v *Validator) CheckCapabilities() (errs error) {
 	if v.platform != "linux" {
-		errs = multierror.Append(errs, fmt.Errorf("For %q platform, the configuration structure does not support process.capabilities", v.platform))
+		errs = multierror.Append(errs, specerror.NewError(specerror.LintPlatformField, fmt.Errorf("For %q platform, the configuration structure does not support process.capabilities", v.platform), v.ruleVersion()))
 		return
 	}
 
@@ -416,11 +453,23 @@ func (v *Validator) CheckCapabilities() (errs error) {
 
 	for capability, owns := range caps {
 		if err := CapValid(capability, v.HostSpecific); err != nil {
-			errs = multierror.Append(errs, fmt.Errorf("capability %q is not valid, man capabilities(7)", capability))
+			errs = multierror.Append(errs, specerror.NewError(specerror.LinuxProcCapError, fmt.Errorf("capability %q is not valid, man capabilities(7)", capability), v.ruleVersion()))
+		} else if v.KernelVersion != "" {
+			supported, err := capsCheck.CapSupportedByKernel(capability, v.KernelVersion)
+			if err != nil {
+				errs = multierror.Append(errs, err)
+			} else if !supported {
+				errs = multierror.Append(errs, specerror.NewError(specerror.LintCapabilityKernel, fmt.Errorf("capability %q was introduced in Linux %s, after the target kernel %s", capability, capsCheck.KernelVersion(capability), v.KernelVersion), v.ruleVersion()))
+			}
 		}
 
+		bounding := false
 		effective, permitted, ambient, inheritable = false, false, false, false
 		for _, set := range owns {
+			if set == "bounding" {
+				bounding = true
+				continue
+			}
 			if set == "effective" {
 				effective = true
 				continue
@@ -439,20 +488,57 @@ func (v *Validator) CheckCapabilities() (errs error) {
 			}
 		}
 		if effective && !permitted {
-			errs = multierror.Append(errs, fmt.Errorf("effective capability %q is not allowed, as it's not permitted", capability))
+			errs = multierror.Append(errs, specerror.NewError(specerror.LintCapabilityNotPermitted, fmt.Errorf("effective capability %q is not allowed, as it's not permitted", capability), v.ruleVersion()))
 		}
 		if ambient && !(permitted && inheritable) { //nolint:staticcheck // Ignore QF1001: could apply De Morgan's law.
-			errs = multierror.Append(errs, fmt.Errorf("ambient capability %q is not allowed, as it's not permitted and inheribate", capability))
+			errs = multierror.Append(errs, specerror.NewError(specerror.LintCapabilityNotPermitted, fmt.Errorf("ambient capability %q is not allowed, as it's not permitted and inheritable", capability), v.ruleVersion()))
+		}
+		if !bounding && (effective || permitted || inheritable || ambient) {
+			sets := slices.Compact(slices.Clone(owns))
+			errs = multierror.Append(errs, specerror.NewError(specerror.LintCapabilityNotBounded, fmt.Errorf("capability %q is in the %s sets but not in the bounding set, so the process cannot keep it", capability, strings.Join(sets, ", ")), v.ruleVersion()))
+		}
+	}
+
+	for _, set := range []struct {
+		name string
+		caps []string
+	}{
+		{"bounding", process.Capabilities.Bounding},
+		{"effective", process.Capabilities.Effective},
+		{"inheritable", process.Capabilities.Inheritable},
+		{"permitted", process.Capabilities.Permitted},
+		{"ambient", process.Capabilities.Ambient},
+	} {
+		for i, cap := range set.caps {
+			if slices.Index(set.caps, cap) < i {
+				errs = multierror.Append(errs, specerror.NewError(specerror.LintCapabilityDuplicate, fmt.Errorf("capability %q is listed more than once in process.capabilities.%s", cap, set.name), v.ruleVersion()))
+			}
 		}
 	}
 
+	if len(process.Capabilities.Ambient) > 0 && process.User.UID != 0 && !process.NoNewPrivileges {
+		errs = multierror.Append(errs, specerror.NewError(specerror.LintAmbientWithoutNoNewPrivileges, fmt.Errorf("uid %d keeps the ambient capabilities %v in every executable it runs, without process.noNewPrivileges to stop set-user-ID executables from gaining more", process.User.UID, process.Capabilities.Ambient), v.ruleVersion()))
+	}
+
 	return
 }
 
+// EffectiveCapabilities returns the capability sets of the process after
+// it executes process.args[0], assumed to have no file capabilities nor
+// set-user-ID bit, as process.user.uid.  It returns nil without
+// process.capabilities.
+func (v *Validator) EffectiveCapabilities() *rspec.LinuxCapabilities {
+	if v.spec.Process == nil || v.spec.Process.Capabilities == nil {
+		return nil
+	}
+	caps := capsCheck.AfterExecve(*v.spec.Process.Capabilities, v.spec.Process.User.UID, v.spec.Process.NoNewPrivileges)
+	return &caps
+}
+
 // CheckRlimits checks v.spec.Process.Rlimits
 func (v *Validator) CheckRlimits() (errs error) {
 	if v.platform != "linux" && v.platform != "solaris" {
-		errs = multierror.Append(errs, fmt.Errorf("For %q platform, the configuration structure does not support process.rlimits", v.platform))
+		errs = multierror.Append(errs, specerror.NewError(specerror.LintPlatformField, fmt.Errorf("For %q platform, the configuration structure does not support process.rlimits", v.platform), v.ruleVersion()))
 		return
 	}
 
@@ -465,7 +551,7 @@ func (v *Validator) CheckRlimits() (errs error) {
 						specerror.PosixProcRlimitsErrorOnDup,
 						fmt.Errorf("rlimit can not contain the same type %q",
 							process.Rlimits[index].Type),
-						rspec.Version))
+						v.ruleVersion()))
 			}
 		}
 		errs = multierror.Append(errs, v.rlimitValid(rlimit))
@@ -508,11 +594,12 @@ func supportedMountTypes(OS string, hostSpecific bool) (map[string]bool, error)
 		}
 
 		supportedTypes["bind"] = true
+		supportedTypes["none"] = true
+		supportedTypes[""] = true
 
 		return supportedTypes, nil
 	}
-	logrus.Warn("Checking linux mount types without --host-specific is not supported yet")
-	return nil, nil
+	return linuxMountTypes(), nil
 }
 
 // CheckMounts checks v.spec.Mounts
@@ -526,17 +613,29 @@ func (v *Validator) CheckMounts() (errs error) {
 	}
 
 	for i, mountA := range v.spec.Mounts {
-		if supportedTypes != nil && !supportedTypes[mountA.Type] {
-			errs = multierror.Append(errs, fmt.Errorf("unsupported mount type %q", mountA.Type))
+		mountType := mountA.Type
+		if v.platform == "linux" {
+			mountType = linuxMountType(mountType)
+		}
+		if supportedTypes != nil && !supportedTypes[mountType] {
+			errs = multierror.Append(errs, specerror.NewError(specerror.LintMountTypeUnsupported, fmt.Errorf("unsupported mount type %q", mountA.Type), v.ruleVersion()))
+		}
+		if v.platform == "linux" {
+			errs = multierror.Append(errs, v.checkLinuxMountOptions(i, mountA))
 		}
 		if !osFilepath.IsAbs(v.platform, mountA.Destination) {
+			// Since 1.2.0, Linux accepts destinations relative to "/".
+			code := specerror.MountsDestAbs
+			if v.platform == "linux" && v.ruleAtLeast("1.2.0") {
+				code = specerror.MountsDestLinuxAbs
+			}
 			errs = multierror.Append(errs,
 				specerror.NewError(
-					specerror.MountsDestAbs,
+					code,
 					fmt.Errorf("mounts[%d].destination %q is not absolute",
 						i,
 						mountA.Destination),
-					rspec.Version))
+					v.ruleVersion()))
 		}
 		for j, mountB := range v.spec.Mounts {
 			if i == j {
@@ -555,7 +654,7 @@ func (v *Validator) CheckMounts() (errs error) {
 							specerror.MountsDestOnWindowsNotNested,
 							fmt.Errorf("on Windows, %v nested within %v is forbidden",
 								mountB.Destination, mountA.Destination),
-							rspec.Version))
+							v.ruleVersion()))
 				}
 				if i > j {
 					logrus.Warnf("%v will be covered by %v", mountB.Destination, mountA.Destination)
@@ -564,6 +663,11 @@ func (v *Validator) CheckMounts() (errs error) {
 		}
 	}
 
+	if v.platform == "linux" {
+		errs = multierror.Append(errs, v.checkMountsMaskedPaths())
+		errs = multierror.Append(errs, v.CheckMountSources())
+	}
+
 	return
 }
 
@@ -587,7 +691,7 @@ func (v *Validator) CheckPlatform() (errs error) {
 				specerror.NewError(
 					specerror.PlatformSpecConfOnWindowsSet,
 					fmt.Errorf("'windows' MUST be set when platform is `windows`"),
-					rspec.Version))
+					v.ruleVersion()))
 		}
 	}
 
@@ -601,12 +705,45 @@ func (v *Validator) CheckLinuxResources() (errs error) {
 	r := v.spec.Linux.Resources
 	if r.Memory != nil {
 		if r.Memory.Limit != nil && r.Memory.Swap != nil && uint64(*r.Memory.Limit) > uint64(*r.Memory.Swap) {
-			errs = multierror.Append(errs, fmt.Errorf("minimum memoryswap should be larger than memory limit"))
+			errs = multierror.Append(errs, specerror.NewError(specerror.LintMemoryLimitOrder, fmt.Errorf("minimum memoryswap should be larger than memory limit"), v.ruleVersion()))
 		}
 		if r.Memory.Limit != nil && r.Memory.Reservation != nil && uint64(*r.Memory.Reservation) > uint64(*r.Memory.Limit) {
-			errs = multierror.Append(errs, fmt.Errorf("minimum memory limit should be larger than memory reservation"))
+			errs = multierror.Append(errs, specerror.NewError(specerror.LintMemoryLimitOrder, fmt.Errorf("minimum memory limit should be larger than memory reservation"), v.ruleVersion()))
+		}
+		for _, m := range []struct {
+			name  string
+			value *int64
+		}{
+			{"limit", r.Memory.Limit},
+			{"reservation", r.Memory.Reservation},
+			{"swap", r.Memory.Swap},
+			{"kernel", r.Memory.Kernel}, //nolint:staticcheck // Ignore SA1019: r.Memory.Kernel is deprecated
+			{"kernelTCP", r.Memory.KernelTCP},
+		} {
+			if m.value != nil && *m.value < -1 {
+				errs = multierror.Append(errs, specerror.NewError(specerror.ValidValues, fmt.Errorf("memory %s %d is invalid, it should be a number of bytes or -1 for unlimited", m.name, *m.value), v.ruleVersion()))
+			}
+		}
+		if r.Memory.Swappiness != nil && *r.Memory.Swappiness > 100 {
+			errs = multierror.Append(errs, specerror.NewError(specerror.ValidValues, fmt.Errorf("memory swappiness %d should be in the range [0, 100]", *r.Memory.Swappiness), v.ruleVersion()))
+		}
+		// Since 1.1.0, kernel memory limits are NOT RECOMMENDED, as cgroup v2
+		// does not support them.
+		if v.ruleAtLeast("1.1.0") {
+			if r.Memory.Kernel != nil { //nolint:staticcheck // Ignore SA1019: r.Memory.Kernel is deprecated
+				errs = multierror.Append(errs, specerror.NewError(specerror.MemoryKernelNotRecommended, fmt.Errorf("memory kernel is NOT RECOMMENDED since runtime-spec 1.1.0"), v.ruleVersion()))
+			}
+			if r.Memory.KernelTCP != nil {
+				errs = multierror.Append(errs, specerror.NewError(specerror.MemoryKernelTCPNotRecommended, fmt.Errorf("memory kernelTCP is NOT RECOMMENDED since runtime-spec 1.1.0"), v.ruleVersion()))
+			}
 		}
 	}
+	if r.CPU != nil {
+		errs = multierror.Append(errs, v.checkLinuxResourcesCPU())
+	}
+	if len(r.Unified) > 0 {
+		errs = multierror.Append(errs, v.checkUnifiedMemory())
+	}
 	if r.Network != nil && v.HostSpecific {
 		var exist bool
 		interfaces, err := net.Interfaces()
@@ -623,7 +760,7 @@ func (v *Validator) CheckLinuxResources() (errs error) {
 				}
 			}
 			if !exist {
-				errs = multierror.Append(errs, fmt.Errorf("interface %s does not exist currently", prio.Name))
+				errs = multierror.Append(errs, specerror.NewError(specerror.LintHostResourceMissing, fmt.Errorf("interface %s does not exist currently", prio.Name), v.ruleVersion()))
 			}
 		}
 	}
@@ -631,7 +768,7 @@ func (v *Validator) CheckLinuxResources() (errs error) {
 		switch r.Devices[index].Type {
 		case "a", "b", "c", "":
 		default:
-			errs = multierror.Append(errs, fmt.Errorf("type of devices %s is invalid", r.Devices[index].Type))
+			errs = multierror.Append(errs, specerror.NewError(specerror.LintDeviceCgroupInvalid, fmt.Errorf("type of devices %s is invalid", r.Devices[index].Type), v.ruleVersion()))
 		}
 
 		access := []byte(r.Devices[index].Access)
@@ -639,12 +776,30 @@ func (v *Validator) CheckLinuxResources() (errs error) {
 			switch access[i] {
 			case 'r', 'w', 'm':
 			default:
-				errs = multierror.Append(errs, fmt.Errorf("access %s is invalid", r.Devices[index].Access))
+				errs = multierror.Append(errs, specerror.NewError(specerror.LintDeviceCgroupInvalid, fmt.Errorf("access %s is invalid", r.Devices[index].Access), v.ruleVersion()))
 				return
 			}
 		}
 	}
 
+	for device, rdma := range r.Rdma {
+		if device == "" || strings.ContainsAny(device, " \t\n") {
+			errs = multierror.Append(errs, specerror.NewError(specerror.ValidValues, fmt.Errorf("linux.resources.rdma device name %q is invalid", device), v.ruleVersion()))
+		}
+		if rdma.HcaHandles == nil && rdma.HcaObjects == nil {
+			errs = multierror.Append(errs,
+				specerror.NewError(
+					specerror.RdmaHcaHandlesOrHcaObjectsExist,
+					fmt.Errorf("linux.resources.rdma[%q] specifies neither hcaHandles nor hcaObjects", device),
+					v.ruleVersion()))
+		}
+		if v.HostSpecific {
+			if _, err := os.Stat(filepath.Join("/sys/class/infiniband", device)); err != nil {
+				errs = multierror.Append(errs, specerror.NewError(specerror.LintHostResourceMissing, fmt.Errorf("RDMA device %s does not exist currently", device), v.ruleVersion()))
+			}
+		}
+	}
+
 	if r.BlockIO != nil && r.BlockIO.WeightDevice != nil {
 		for i, weightDevice := range r.BlockIO.WeightDevice {
 			if weightDevice.Weight == nil && weightDevice.LeafWeight == nil {
@@ -652,11 +807,221 @@ func (v *Validator) CheckLinuxResources() (errs error) {
 					specerror.NewError(
 						specerror.BlkIOWeightOrLeafWeightExist,
 						fmt.Errorf("linux.resources.blockIO.weightDevice[%d] specifies neither weight nor leafWeight", i),
-						rspec.Version))
+						v.ruleVersion()))
+			}
+		}
+	}
+
+	return
+}
+
+// checkLinuxResourcesCPU checks v.spec.Linux.Resources.CPU against the
+// limits the kernel enforces when the values are written.
+func (v *Validator) checkLinuxResourcesCPU() (errs error) {
+	cpu := v.spec.Linux.Resources.CPU
+
+	// The CFS bandwidth controller only accepts periods between 1ms and 1s
+	// and quotas of at least 1ms, see kernel/sched/core.c.
+	if cpu.Period != nil && *cpu.Period != 0 && (*cpu.Period < 1000 || *cpu.Period > 1000000) {
+		errs = multierror.Append(errs, specerror.NewError(specerror.LintCPUBandwidthRange, fmt.Errorf("cpu period %d should be in the range [1000, 1000000]", *cpu.Period), v.ruleVersion()))
+	}
+	if cpu.Quota != nil && *cpu.Quota != -1 && *cpu.Quota != 0 && *cpu.Quota < 1000 {
+		errs = multierror.Append(errs, specerror.NewError(specerror.LintCPUBandwidthRange, fmt.Errorf("cpu quota %d should be -1 or at least 1000", *cpu.Quota), v.ruleVersion()))
+	}
+	// The kernel only bounds the burst by a positive quota, unlimited
+	// quotas accept any burst.
+	if cpu.Burst != nil && cpu.Quota != nil && *cpu.Quota > 0 && *cpu.Burst > uint64(*cpu.Quota) {
+		errs = multierror.Append(errs, specerror.NewError(specerror.CPUBurstNotLargerThanQuota, fmt.Errorf("cpu burst %d should not be larger than cpu quota %d", *cpu.Burst, *cpu.Quota), v.ruleVersion()))
+	}
+	if cpu.Idle != nil && *cpu.Idle != 0 && *cpu.Idle != 1 {
+		errs = multierror.Append(errs, specerror.NewError(specerror.LintCPUIdleValue, fmt.Errorf("cpu idle %d should be 0 or 1", *cpu.Idle), v.ruleVersion()))
+	}
+	if cpu.RealtimeRuntime != nil && cpu.RealtimePeriod != nil && *cpu.RealtimeRuntime > 0 && uint64(*cpu.RealtimeRuntime) > *cpu.RealtimePeriod {
+		errs = multierror.Append(errs, specerror.NewError(specerror.LintCPUBandwidthRange, fmt.Errorf("cpu realtimeRuntime %d should not be larger than realtimePeriod %d", *cpu.RealtimeRuntime, *cpu.RealtimePeriod), v.ruleVersion()))
+	}
+
+	for _, set := range []struct {
+		name   string
+		value  string
+		online string
+	}{
+		{"cpus", cpu.Cpus, "/sys/devices/system/cpu/online"},
+		{"mems", cpu.Mems, "/sys/devices/system/node/online"},
+	} {
+		if set.value == "" {
+			continue
+		}
+		ids, err := parseCPUSetList(set.value)
+		if err != nil {
+			errs = multierror.Append(errs, specerror.NewError(specerror.ValidValues, fmt.Errorf("cpu %s %q is invalid: %w", set.name, set.value, err), v.ruleVersion()))
+			continue
+		}
+		if !v.HostSpecific {
+			continue
+		}
+		contents, err := os.ReadFile(set.online)
+		if os.IsNotExist(err) && set.name == "mems" {
+			// Kernels without CONFIG_NUMA only have memory node 0.
+			contents, err = []byte("0"), nil
+		}
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		online, err := parseCPUSetList(strings.TrimSpace(string(contents)))
+		if err != nil {
+			errs = multierror.Append(errs, fmt.Errorf("%s: %w", set.online, err))
+			continue
+		}
+		for _, id := range ids {
+			if !slices.Contains(online, id) {
+				errs = multierror.Append(errs, specerror.NewError(specerror.LintHostResourceMissing, fmt.Errorf("cpu %s %q is not a subset of the %s online on the host (%s)", set.name, set.value, set.name, strings.TrimSpace(string(contents))), v.ruleVersion()))
+				break
+			}
+		}
+	}
+
+	return
+}
+
+// parseCPUSetList parses a cpuset list such as "0-3,5,7-8" and returns
+// the IDs it contains.  As with the kernel, ranges may have a stride
+// "used/group", as in "0-7:2/4", to take the first used IDs of every
+// group of IDs.
+func parseCPUSetList(list string) ([]int, error) {
+	var ids []int
+	for _, part := range strings.Split(list, ",") {
+		part = strings.TrimSpace(part)
+		element, stride, hasStride := strings.Cut(part, ":")
+		bounds := strings.SplitN(element, "-", 2)
+		start, err := strconv.ParseUint(bounds[0], 10, 16)
+		if err != nil {
+			return nil, fmt.Errorf("invalid element %q", part)
+		}
+		end := start
+		if len(bounds) == 2 {
+			end, err = strconv.ParseUint(bounds[1], 10, 16)
+			if err != nil || end < start {
+				return nil, fmt.Errorf("invalid range %q", part)
+			}
+		}
+		used, group := uint64(1), uint64(1)
+		if hasStride {
+			usedStr, groupStr, ok := strings.Cut(stride, "/")
+			if len(bounds) != 2 || !ok {
+				return nil, fmt.Errorf("invalid stride %q", part)
+			}
+			used, err = strconv.ParseUint(usedStr, 10, 16)
+			if err != nil {
+				return nil, fmt.Errorf("invalid stride %q", part)
+			}
+			group, err = strconv.ParseUint(groupStr, 10, 16)
+			if err != nil || used == 0 || group == 0 || used > group {
+				return nil, fmt.Errorf("invalid stride %q", part)
+			}
+		}
+		for id := start; id <= end; id++ {
+			if (id-start)%group < used {
+				ids = append(ids, int(id))
+			}
+		}
+	}
+	return ids, nil
+}
+
+// checkUnifiedMemory checks the cgroup v2 memory knobs in
+// v.spec.Linux.Resources.Unified.
+func (v *Validator) checkUnifiedMemory() (errs error) {
+	r := v.spec.Linux.Resources
+	values := make(map[string]int64)
+	for _, key := range []string{"memory.min", "memory.low", "memory.high", "memory.max", "memory.swap.max"} {
+		raw, ok := r.Unified[key]
+		if !ok {
+			continue
+		}
+		value, err := parseUnifiedMemory(raw)
+		if err != nil {
+			errs = multierror.Append(errs, specerror.NewError(specerror.LintUnifiedValue, fmt.Errorf("unified %s: %w", key, err), v.ruleVersion()))
+			continue
+		}
+		values[key] = value
+	}
+
+	// -1 stands for "max", so only compare pairs of bounded values.
+	bounded := func(key string) (int64, bool) {
+		value, ok := values[key]
+		return value, ok && value >= 0
+	}
+	ordered := []string{"memory.min", "memory.low", "memory.high", "memory.max"}
+	for i, lower := range ordered {
+		lv, ok := bounded(lower)
+		if !ok {
+			continue
+		}
+		for _, upper := range ordered[i+1:] {
+			if uv, ok := bounded(upper); ok && lv > uv {
+				errs = multierror.Append(errs, specerror.NewError(specerror.LintMemoryLimitOrder, fmt.Errorf("unified %s %d is larger than %s %d", lower, lv, upper, uv), v.ruleVersion()))
+			}
+		}
+	}
+	if high, ok := bounded("memory.high"); ok && r.Memory != nil && r.Memory.Limit != nil && *r.Memory.Limit >= 0 && high > *r.Memory.Limit {
+		errs = multierror.Append(errs, specerror.NewError(specerror.LintMemoryLimitOrder, fmt.Errorf("unified memory.high %d is larger than memory limit %d and has no effect", high, *r.Memory.Limit), v.ruleVersion()))
+	}
+	if memMax, ok := values["memory.max"]; ok && r.Memory != nil && r.Memory.Limit != nil && memMax != *r.Memory.Limit {
+		errs = multierror.Append(errs, specerror.NewError(specerror.LintMemoryLimitOrder, fmt.Errorf("unified memory.max %s conflicts with memory limit %d", r.Unified["memory.max"], *r.Memory.Limit), v.ruleVersion()))
+	}
+
+	return
+}
+
+// parseUnifiedMemory parses a cgroup v2 memory value, which is either a
+// number of bytes or "max". "max" is returned as -1.
+func parseUnifiedMemory(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	if value == "max" {
+		return -1, nil
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("%q is not a number of bytes or \"max\"", value)
+	}
+	return n, nil
+}
+
+// CheckLinuxIntelRdt checks v.spec.Linux.IntelRdt
+func (v *Validator) CheckLinuxIntelRdt() (errs error) {
+	logrus.Debugf("check linux intelRdt")
+
+	rdt := v.spec.Linux.IntelRdt
+	if err := resctrl.ValidateClosID(rdt.ClosID); err != nil {
+		errs = multierror.Append(errs, specerror.NewError(specerror.ValidValues, err, v.ruleVersion()))
+	}
+
+	checkSchemata := func(field string, schemata string, code specerror.Code, resources ...string) {
+		schemas, err := resctrl.ParseSchemata(schemata)
+		if err != nil {
+			errs = multierror.Append(errs, specerror.NewError(specerror.ValidValues, fmt.Errorf("linux.intelRdt.%s: %v", field, err), v.ruleVersion()))
+			return
+		}
+		for _, schema := range schemas {
+			if len(resources) > 0 && !slices.Contains(resources, schema.Resource) {
+				errs = multierror.Append(errs, specerror.NewError(code, fmt.Errorf("linux.intelRdt.%s: resource %q is not one of %v", field, schema.Resource, resources), v.ruleVersion()))
+			} else if !resctrl.IsKnownResource(schema.Resource) {
+				logrus.Warnf("linux.intelRdt.%s: resource %q may not be supported", field, schema.Resource)
 			}
 		}
 	}
 
+	checkSchemata("l3CacheSchema", rdt.L3CacheSchema, specerror.IntelRdtL3CacheSchemaPrefix, "L3", "L3CODE", "L3DATA")
+	checkSchemata("memBwSchema", rdt.MemBwSchema, specerror.IntelRdtMemBwSchemaPrefix, "MB")
+	for i, line := range rdt.Schemata {
+		checkSchemata(fmt.Sprintf("schemata[%d]", i), line, specerror.ValidValues)
+	}
+
+	if len(rdt.Schemata) > 0 && (rdt.L3CacheSchema != "" || rdt.MemBwSchema != "") {
+		logrus.Warnf("linux.intelRdt.schemata overwrites l3CacheSchema and memBwSchema, which should not be specified together with it")
+	}
+
 	return
 }
 
@@ -671,7 +1036,7 @@ func (v *Validator) CheckAnnotations() (errs error) {
 				specerror.NewError(
 					specerror.AnnotationsKeyReservedNS,
 					fmt.Errorf("key %q is reserved", key),
-					rspec.Version))
+					v.ruleVersion()))
 		}
 
 		if !reversedDomain.MatchString(key) {
@@ -679,7 +1044,7 @@ func (v *Validator) CheckAnnotations() (errs error) {
 				specerror.NewError(
 					specerror.AnnotationsKeyReversedDomain,
 					fmt.Errorf("key %q SHOULD be named using a reverse domain notation", key),
-					rspec.Version))
+					v.ruleVersion()))
 		}
 	}
 
@@ -716,7 +1081,7 @@ func envValid(env string) bool {
 
 func (v *Validator) rlimitValid(rlimit rspec.POSIXRlimit) (errs error) {
 	if rlimit.Hard < rlimit.Soft {
-		errs = multierror.Append(errs, fmt.Errorf("hard limit of rlimit %s should not be less than soft limit", rlimit.Type))
+		errs = multierror.Append(errs, specerror.NewError(specerror.LintRlimitSoftAboveHard, fmt.Errorf("hard limit of rlimit %s should not be less than soft limit", rlimit.Type), v.ruleVersion()))
 	}
 
 	switch v.platform {
@@ -745,38 +1110,38 @@ func isStructPtr(t reflect.Type) bool {
 	return t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Struct
 }
 
-func checkMandatoryUnit(field reflect.Value, tagField reflect.StructField, parent string) (errs error) {
+func checkMandatoryUnit(field reflect.Value, tagField reflect.StructField, parent string, version string) (errs error) {
 	mandatory := !strings.Contains(tagField.Tag.Get("json"), "omitempty")
 	switch field.Kind() {
 	case reflect.Ptr:
 		if mandatory && field.IsNil() {
-			errs = multierror.Append(errs, fmt.Errorf("'%s.%s' should not be empty", parent, tagField.Name))
+			errs = multierror.Append(errs, specerror.NewError(specerror.ValidValues, fmt.Errorf("'%s.%s' should not be empty", parent, tagField.Name), version))
 		}
 	case reflect.String:
 		if mandatory && (field.Len() == 0) {
-			errs = multierror.Append(errs, fmt.Errorf("'%s.%s' should not be empty", parent, tagField.Name))
+			errs = multierror.Append(errs, specerror.NewError(specerror.ValidValues, fmt.Errorf("'%s.%s' should not be empty", parent, tagField.Name), version))
 		}
 	case reflect.Slice:
 		if mandatory && (field.IsNil() || field.Len() == 0) {
-			errs = multierror.Append(errs, fmt.Errorf("'%s.%s' should not be empty", parent, tagField.Name))
+			errs = multierror.Append(errs, specerror.NewError(specerror.ValidValues, fmt.Errorf("'%s.%s' should not be empty", parent, tagField.Name), version))
 			return
 		}
 		for index := 0; index < field.Len(); index++ {
 			mValue := field.Index(index)
 			if mValue.CanInterface() {
-				errs = multierror.Append(errs, checkMandatory(mValue.Interface()))
+				errs = multierror.Append(errs, checkMandatory(mValue.Interface(), version))
 			}
 		}
 	case reflect.Map:
 		if mandatory && (field.IsNil() || field.Len() == 0) {
-			errs = multierror.Append(errs, fmt.Errorf("'%s.%s' should not be empty", parent, tagField.Name))
+			errs = multierror.Append(errs, specerror.NewError(specerror.ValidValues, fmt.Errorf("'%s.%s' should not be empty", parent, tagField.Name), version))
 			return
 		}
 		keys := field.MapKeys()
 		for index := 0; index < len(keys); index++ {
 			mValue := field.MapIndex(keys[index])
 			if mValue.CanInterface() {
-				errs = multierror.Append(errs, checkMandatory(mValue.Interface()))
+				errs = multierror.Append(errs, checkMandatory(mValue.Interface(), version))
 			}
 		}
 	default:
@@ -785,7 +1150,7 @@ func checkMandatoryUnit(field reflect.Value, tagField reflect.StructField, paren
 	return
 }
 
-func checkMandatory(obj any) (errs error) {
+func checkMandatory(obj any, version string) (errs error) {
 	objT := reflect.TypeOf(obj)
 	objV := reflect.ValueOf(obj)
 	if isStructPtr(objT) {
@@ -799,12 +1164,12 @@ func checkMandatory(obj any) (errs error) {
 		t := objT.Field(i).Type
 		if isStructPtr(t) && objV.Field(i).IsNil() {
 			if !strings.Contains(objT.Field(i).Tag.Get("json"), "omitempty") {
-				errs = multierror.Append(errs, fmt.Errorf("'%s.%s' should not be empty", objT.Name(), objT.Field(i).Name))
+				errs = multierror.Append(errs, specerror.NewError(specerror.ValidValues, fmt.Errorf("'%s.%s' should not be empty", objT.Name(), objT.Field(i).Name), version))
 			}
 		} else if (isStruct(t) || isStructPtr(t)) && objV.Field(i).CanInterface() {
-			errs = multierror.Append(errs, checkMandatory(objV.Field(i).Interface()))
+			errs = multierror.Append(errs, checkMandatory(objV.Field(i).Interface(), version))
 		} else {
-			errs = multierror.Append(errs, checkMandatoryUnit(objV.Field(i), objT.Field(i), objT.Name()))
+			errs = multierror.Append(errs, checkMandatoryUnit(objV.Field(i), objT.Field(i), objT.Name(), version))
 		}
 
 	}
@@ -819,5 +1184,5 @@ func (v *Validator) CheckMandatoryFields() error {
 		return fmt.Errorf("Spec can't be nil")
 	}
 
-	return checkMandatory(v.spec)
+	return checkMandatory(v.spec, v.ruleVersion())
 }
@@ -14,24 +14,25 @@ import (
 	rspec "github.com/opencontainers/runtime-spec/specs-go"
 	osFilepath "github.com/opencontainers/runtime-tools/filepath"
 	"github.com/opencontainers/runtime-tools/specerror"
-	"github.com/opencontainers/selinux/go-selinux/label"
 	"github.com/sirupsen/logrus"
 )
 
-func deviceValid(d rspec.LinuxDevice) bool {
+// checkDevice checks the type and the major and minor numbers of d.  It
+// returns the code of the spec requirement d violates, or NonRFCError.
+func checkDevice(d rspec.LinuxDevice) (specerror.Code, error) {
 	switch d.Type {
 	case "b", "c", "u":
 		if d.Major <= 0 || d.Minor <= 0 {
-			return false
+			return specerror.DevicesMajMinRequired, fmt.Errorf("device %s of type %s requires positive major and minor numbers", d.Path, d.Type)
 		}
 	case "p":
 		if d.Major != 0 || d.Minor != 0 {
-			return false
+			return specerror.NonRFCError, fmt.Errorf("device %s of type p must not have major and minor numbers", d.Path)
 		}
 	default:
-		return false
+		return specerror.NonRFCError, fmt.Errorf("device %s has an invalid type %q", d.Path, d.Type)
 	}
-	return true
+	return specerror.NonError, nil
 }
 
 // CheckLinux checks v.spec.Linux
@@ -59,13 +60,13 @@ func (v *Validator) CheckLinux() (errs error) {
 	for index := 0; index < len(v.spec.Linux.Namespaces); index++ {
 		ns := v.spec.Linux.Namespaces[index]
 		if ns.Path != "" && !osFilepath.IsAbs(v.platform, ns.Path) {
-			errs = multierror.Append(errs, specerror.NewError(specerror.NSPathAbs, fmt.Errorf("namespace.path %q is not an absolute path", ns.Path), rspec.Version))
+			errs = multierror.Append(errs, specerror.NewError(specerror.NSPathAbs, fmt.Errorf("namespace.path %q is not an absolute path", ns.Path), v.ruleVersion()))
 		}
 
 		tmpItem := nsTypeList[ns.Type]
 		tmpItem.num = tmpItem.num + 1
 		if tmpItem.num > 1 {
-			errs = multierror.Append(errs, specerror.NewError(specerror.NSErrorOnDup, fmt.Errorf("duplicated namespace %q", ns.Type), rspec.Version))
+			errs = multierror.Append(errs, specerror.NewError(specerror.NSErrorOnDup, fmt.Errorf("duplicated namespace %q", ns.Type), v.ruleVersion()))
 		}
 
 		if len(ns.Path) == 0 {
@@ -75,26 +76,43 @@ func (v *Validator) CheckLinux() (errs error) {
 	}
 
 	if (len(v.spec.Linux.UIDMappings) > 0 || len(v.spec.Linux.GIDMappings) > 0) && !nsTypeList[rspec.UserNamespace].newExist {
-		errs = multierror.Append(errs, errors.New("the UID/GID mappings requires a new User namespace to be specified as well"))
+		errs = multierror.Append(errs, specerror.NewError(specerror.LintIDMappingsWithoutUserNS, errors.New("the UID/GID mappings requires a new User namespace to be specified as well"), v.ruleVersion()))
 	}
 
 	for k := range v.spec.Linux.Sysctl {
 		if strings.HasPrefix(k, "net.") && !nsTypeList[rspec.NetworkNamespace].newExist {
-			errs = multierror.Append(errs, fmt.Errorf("sysctl %v requires a new Network namespace to be specified as well", k))
+			errs = multierror.Append(errs, specerror.NewError(specerror.LintSysctlNamespace, fmt.Errorf("sysctl %v requires a new Network namespace to be specified as well", k), v.ruleVersion()))
 		}
 		if strings.HasPrefix(k, "fs.mqueue.") {
 			if !nsTypeList[rspec.MountNamespace].newExist || !nsTypeList[rspec.IPCNamespace].newExist {
-				errs = multierror.Append(errs, fmt.Errorf("sysctl %v requires a new IPC namespace and Mount namespace to be specified as well", k))
+				errs = multierror.Append(errs, specerror.NewError(specerror.LintSysctlNamespace, fmt.Errorf("sysctl %v requires a new IPC namespace and Mount namespace to be specified as well", k), v.ruleVersion()))
 			}
 		}
 	}
 
-	if v.platform == "linux" && !nsTypeList[rspec.UTSNamespace].newExist && v.spec.Hostname != "" {
-		errs = multierror.Append(errs, fmt.Errorf("on Linux, hostname requires a new UTS namespace to be specified as well"))
+	// The container UTS namespace may be the runtime's, but then
+	// setting the hostname changes the runtime's.
+	if v.platform == "linux" && !nsTypeList[rspec.UTSNamespace].newExist {
+		if v.spec.Hostname != "" {
+			errs = multierror.Append(errs, specerror.NewError(specerror.LintUTSNamespace, fmt.Errorf("on Linux, hostname requires a new UTS namespace to be specified as well"), v.ruleVersion()))
+		}
+		if v.spec.Domainname != "" {
+			errs = multierror.Append(errs, specerror.NewError(specerror.LintUTSNamespace, fmt.Errorf("on Linux, domainname requires a new UTS namespace to be specified as well"), v.ruleVersion()))
+		}
 	}
 
 	if !nsTypeList[rspec.TimeNamespace].newExist && len(v.spec.Linux.TimeOffsets) > 0 {
-		errs = multierror.Append(errs, fmt.Errorf("TimeOffsets requires a new time namespace to be specified as well"))
+		errs = multierror.Append(errs, specerror.NewError(specerror.LintTimeNamespace, fmt.Errorf("timeOffsets requires a new time namespace to be specified as well"), v.ruleVersion()))
+	}
+
+	errs = multierror.Append(errs, v.checkIDMappedMounts(nsTypeList[rspec.UserNamespace].num > 0))
+
+	if v.spec.Linux.Seccomp != nil && v.spec.Linux.Seccomp.ListenerMetadata != "" && v.spec.Linux.Seccomp.ListenerPath == "" {
+		errs = multierror.Append(errs,
+			specerror.NewError(
+				specerror.SeccListenerMetadataWithoutPath,
+				fmt.Errorf("linux.seccomp.listenerMetadata is set without linux.seccomp.listenerPath"),
+				v.ruleVersion()))
 	}
 
 	// Linux devices validation
@@ -102,20 +120,16 @@ func (v *Validator) CheckLinux() (errs error) {
 	devTypeList := make(map[string]bool)
 	for index := 0; index < len(v.spec.Linux.Devices); index++ {
 		device := v.spec.Linux.Devices[index]
-		if !deviceValid(device) {
-			errs = multierror.Append(errs, fmt.Errorf("device %v is invalid", device))
+		if code, err := checkDevice(device); code == specerror.NonRFCError {
+			errs = multierror.Append(errs, err)
+		} else if err != nil {
+			errs = multierror.Append(errs, specerror.NewError(code, err, v.ruleVersion()))
 		}
 
 		if _, exists := devList[device.Path]; exists {
-			errs = multierror.Append(errs, fmt.Errorf("device %s is duplicated", device.Path))
+			errs = multierror.Append(errs, specerror.NewError(specerror.LintDevicesPathDup, fmt.Errorf("device %s is duplicated", device.Path), v.ruleVersion()))
 		} else {
-			var rootfsPath string
-			if filepath.IsAbs(v.spec.Root.Path) {
-				rootfsPath = v.spec.Root.Path
-			} else {
-				rootfsPath = filepath.Join(v.bundlePath, v.spec.Root.Path)
-			}
-			absPath := filepath.Join(rootfsPath, device.Path)
+			absPath := filepath.Join(v.rootfsPath(), device.Path)
 			fi, err := os.Stat(absPath)
 			if os.IsNotExist(err) {
 				devList[device.Path] = true
@@ -125,7 +139,7 @@ func (v *Validator) CheckLinux() (errs error) {
 				fStat, ok := fi.Sys().(*syscall.Stat_t)
 				if !ok {
 					errs = multierror.Append(errs, specerror.NewError(specerror.DevicesAvailable,
-						fmt.Errorf("cannot determine state for device %s", device.Path), rspec.Version))
+						fmt.Errorf("cannot determine state for device %s", device.Path), v.ruleVersion()))
 					continue
 				}
 				var devType string
@@ -141,7 +155,7 @@ func (v *Validator) CheckLinux() (errs error) {
 				}
 				if devType != device.Type || (devType == "c" && device.Type == "u") {
 					errs = multierror.Append(errs, specerror.NewError(specerror.DevicesFileNotMatch,
-						fmt.Errorf("unmatched %s already exists in filesystem", device.Path), rspec.Version))
+						fmt.Errorf("unmatched %s already exists in filesystem", device.Path), v.ruleVersion()))
 					continue
 				}
 				if devType != "p" {
@@ -150,7 +164,7 @@ func (v *Validator) CheckLinux() (errs error) {
 					minor := (dev & 0xff) | ((dev >> 12) & 0xfff00)
 					if int64(major) != device.Major || int64(minor) != device.Minor {
 						errs = multierror.Append(errs, specerror.NewError(specerror.DevicesFileNotMatch,
-							fmt.Errorf("unmatched %s already exists in filesystem", device.Path), rspec.Version))
+							fmt.Errorf("unmatched %s already exists in filesystem", device.Path), v.ruleVersion()))
 						continue
 					}
 				}
@@ -159,21 +173,21 @@ func (v *Validator) CheckLinux() (errs error) {
 					actualPerm := fi.Mode() & os.ModePerm
 					if expectedPerm != actualPerm {
 						errs = multierror.Append(errs, specerror.NewError(specerror.DevicesFileNotMatch,
-							fmt.Errorf("unmatched %s already exists in filesystem", device.Path), rspec.Version))
+							fmt.Errorf("unmatched %s already exists in filesystem", device.Path), v.ruleVersion()))
 						continue
 					}
 				}
 				if device.UID != nil {
 					if *device.UID != fStat.Uid {
 						errs = multierror.Append(errs, specerror.NewError(specerror.DevicesFileNotMatch,
-							fmt.Errorf("unmatched %s already exists in filesystem", device.Path), rspec.Version))
+							fmt.Errorf("unmatched %s already exists in filesystem", device.Path), v.ruleVersion()))
 						continue
 					}
 				}
 				if device.GID != nil {
 					if *device.GID != fStat.Gid {
 						errs = multierror.Append(errs, specerror.NewError(specerror.DevicesFileNotMatch,
-							fmt.Errorf("unmatched %s already exists in filesystem", device.Path), rspec.Version))
+							fmt.Errorf("unmatched %s already exists in filesystem", device.Path), v.ruleVersion()))
 						continue
 					}
 				}
@@ -189,7 +203,7 @@ func (v *Validator) CheckLinux() (errs error) {
 		}
 
 		if _, exists := devTypeList[devID]; exists {
-			logrus.Warnf("%v", specerror.NewError(specerror.DevicesErrorOnDup, fmt.Errorf("type:%s, major:%d and minor:%d for linux devices is duplicated", device.Type, device.Major, device.Minor), rspec.Version))
+			logrus.Warnf("%v", specerror.NewError(specerror.DevicesErrorOnDup, fmt.Errorf("type:%s, major:%d and minor:%d for linux devices is duplicated", device.Type, device.Major, device.Minor), v.ruleVersion()))
 		} else {
 			devTypeList[devID] = true
 		}
@@ -199,13 +213,17 @@ func (v *Validator) CheckLinux() (errs error) {
 		errs = multierror.Append(errs, v.CheckLinuxResources())
 	}
 
+	if v.spec.Linux.IntelRdt != nil {
+		errs = multierror.Append(errs, v.CheckLinuxIntelRdt())
+	}
+
 	for _, maskedPath := range v.spec.Linux.MaskedPaths {
 		if !strings.HasPrefix(maskedPath, "/") {
 			errs = multierror.Append(errs,
 				specerror.NewError(
 					specerror.MaskedPathsAbs,
 					fmt.Errorf("maskedPath %v is not an absolute path", maskedPath),
-					rspec.Version))
+					v.ruleVersion()))
 		}
 	}
 
@@ -215,13 +233,59 @@ func (v *Validator) CheckLinux() (errs error) {
 				specerror.NewError(
 					specerror.ReadonlyPathsAbs,
 					fmt.Errorf("readonlyPath %v is not an absolute path", readonlyPath),
-					rspec.Version))
+					v.ruleVersion()))
 		}
 	}
 
 	if v.spec.Linux.MountLabel != "" {
-		if err := label.Validate(v.spec.Linux.MountLabel); err != nil {
-			errs = multierror.Append(errs, fmt.Errorf("mountLabel %v is invalid", v.spec.Linux.MountLabel))
+		if err := v.checkSelinuxLabel(v.spec.Linux.MountLabel); err != nil {
+			errs = multierror.Append(errs, specerror.NewError(specerror.LintMountLabelInvalid, fmt.Errorf("mountLabel %w", err), v.ruleVersion()))
+		}
+	}
+
+	return
+}
+
+// checkIDMappedMounts checks the ID mappings of v.spec.Mounts.  userNS
+// reports whether the container has a user namespace whose mappings the
+// runtime may use for idmap mounts without mappings.
+func (v *Validator) checkIDMappedMounts(userNS bool) (errs error) {
+	for i, mount := range v.spec.Mounts {
+		hasUID, hasGID := len(mount.UIDMappings) > 0, len(mount.GIDMappings) > 0
+		if hasUID && !hasGID {
+			errs = multierror.Append(errs,
+				specerror.NewError(
+					specerror.PosixMountsUIDMappingsWithGIDMappings,
+					fmt.Errorf("mounts[%d].uidMappings is specified without gidMappings", i),
+					v.ruleVersion()))
+		}
+		if hasGID && !hasUID {
+			errs = multierror.Append(errs,
+				specerror.NewError(
+					specerror.PosixMountsGIDMappingsWithUIDMappings,
+					fmt.Errorf("mounts[%d].gidMappings is specified without uidMappings", i),
+					v.ruleVersion()))
+		}
+
+		idmap := false
+		for _, option := range mount.Options {
+			if option == "idmap" || option == "ridmap" {
+				idmap = true
+			}
+		}
+		if (hasUID || hasGID) && !idmap {
+			errs = multierror.Append(errs,
+				specerror.NewError(
+					specerror.PosixMountsIDMappingsOptions,
+					fmt.Errorf("mounts[%d] has ID mappings but neither the idmap nor the ridmap option", i),
+					v.ruleVersion()))
+		}
+		if idmap && !hasUID && !hasGID && !userNS {
+			errs = multierror.Append(errs,
+				specerror.NewError(
+					specerror.MountsIDMapWithoutUserNSError,
+					fmt.Errorf("mounts[%d] is idmapped without ID mappings or a user namespace", i),
+					v.ruleVersion()))
 		}
 	}
 
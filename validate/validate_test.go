@@ -1,16 +1,22 @@
 package validate
 
 import (
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
 	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/go-multierror"
 	rspec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/stretchr/testify/assert"
 
+	rfc2119 "github.com/opencontainers/runtime-tools/error"
 	"github.com/opencontainers/runtime-tools/specerror"
 )
 
@@ -22,8 +28,8 @@ func TestNewValidator(t *testing.T) {
 		val      Validator
 		expected Validator
 	}{
-		{Validator{testSpec, testBundle, true, runtime.GOOS}, Validator{testSpec, testBundle, true, runtime.GOOS}},
-		{Validator{testSpec, testBundle, false, testPlatform}, Validator{testSpec, testBundle, false, testPlatform}},
+		{Validator{testSpec, testBundle, true, runtime.GOOS, nil, nil, "", false, nil}, Validator{testSpec, testBundle, true, runtime.GOOS, DefaultDangerousMountSources, DefaultDangerousMountDirs, "", false, nil}},
+		{Validator{testSpec, testBundle, false, testPlatform, nil, nil, "", false, nil}, Validator{testSpec, testBundle, false, testPlatform, DefaultDangerousMountSources, DefaultDangerousMountDirs, "", false, nil}},
 	}
 
 	for _, c := range cases {
@@ -94,7 +100,7 @@ func TestJSONSchema(t *testing.T) {
 					RootfsPropagation: "rshared",
 				},
 			},
-			error: "linux.rootfsPropagation: linux.rootfsPropagation must be one of the following: \"private\", \"shared\", \"slave\", \"unbindable\"",
+			error: "linux.rootfsPropagation: linux.rootfsPropagation must be one of the following: \"private\", \"shared\", \"slave\", \"unbindable\"\nRefer to: https://github.com/opencontainers/runtime-spec/blob/v1.0.2/config.md#valid-values",
 		},
 		{
 			config: &rspec.Spec{
@@ -120,7 +126,7 @@ func TestJSONSchema(t *testing.T) {
 					},
 				},
 			},
-			error: "2 errors occurred:\n\t* linux.namespaces.0: Must validate at least one schema (anyOf)\n\t* linux.namespaces.0.type: linux.namespaces.0.type must be one of the following: \"mount\", \"pid\", \"network\", \"uts\", \"ipc\", \"user\", \"cgroup\"\n\n",
+			error: "2 errors occurred:\n\t* linux.namespaces.0: Must validate at least one schema (anyOf)\nRefer to: https://github.com/opencontainers/runtime-spec/blob/v1.0.2/config.md#valid-values\n\t* linux.namespaces.0.type: linux.namespaces.0.type must be one of the following: \"mount\", \"pid\", \"network\", \"uts\", \"ipc\", \"user\", \"cgroup\"\nRefer to: https://github.com/opencontainers/runtime-spec/blob/v1.0.2/config.md#valid-values\n\n",
 		},
 		{
 			config: &rspec.Spec{
@@ -150,7 +156,7 @@ func TestJSONSchema(t *testing.T) {
 					},
 				},
 			},
-			error: "linux.seccomp.architectures.1: linux.seccomp.architectures.1 must be one of the following: \"SCMP_ARCH_X86\", \"SCMP_ARCH_X86_64\", \"SCMP_ARCH_X32\", \"SCMP_ARCH_ARM\", \"SCMP_ARCH_AARCH64\", \"SCMP_ARCH_MIPS\", \"SCMP_ARCH_MIPS64\", \"SCMP_ARCH_MIPS64N32\", \"SCMP_ARCH_MIPSEL\", \"SCMP_ARCH_MIPSEL64\", \"SCMP_ARCH_MIPSEL64N32\", \"SCMP_ARCH_PPC\", \"SCMP_ARCH_PPC64\", \"SCMP_ARCH_PPC64LE\", \"SCMP_ARCH_S390\", \"SCMP_ARCH_S390X\", \"SCMP_ARCH_PARISC\", \"SCMP_ARCH_PARISC64\"",
+			error: "linux.seccomp.architectures.1: linux.seccomp.architectures.1 must be one of the following: \"SCMP_ARCH_X86\", \"SCMP_ARCH_X86_64\", \"SCMP_ARCH_X32\", \"SCMP_ARCH_ARM\", \"SCMP_ARCH_AARCH64\", \"SCMP_ARCH_MIPS\", \"SCMP_ARCH_MIPS64\", \"SCMP_ARCH_MIPS64N32\", \"SCMP_ARCH_MIPSEL\", \"SCMP_ARCH_MIPSEL64\", \"SCMP_ARCH_MIPSEL64N32\", \"SCMP_ARCH_PPC\", \"SCMP_ARCH_PPC64\", \"SCMP_ARCH_PPC64LE\", \"SCMP_ARCH_S390\", \"SCMP_ARCH_S390X\", \"SCMP_ARCH_PARISC\", \"SCMP_ARCH_PARISC64\"\nRefer to: https://github.com/opencontainers/runtime-spec/blob/v1.0.2/config.md#valid-values",
 		},
 		{
 			config: &rspec.Spec{
@@ -184,7 +190,7 @@ func TestJSONSchema(t *testing.T) {
 					},
 				},
 			},
-			error: "linux.seccomp.syscalls.0.action: linux.seccomp.syscalls.0.action must be one of the following: \"SCMP_ACT_KILL\", \"SCMP_ACT_TRAP\", \"SCMP_ACT_ERRNO\", \"SCMP_ACT_TRACE\", \"SCMP_ACT_ALLOW\", \"SCMP_ACT_LOG\"",
+			error: "linux.seccomp.syscalls.0.action: linux.seccomp.syscalls.0.action must be one of the following: \"SCMP_ACT_KILL\", \"SCMP_ACT_TRAP\", \"SCMP_ACT_ERRNO\", \"SCMP_ACT_TRACE\", \"SCMP_ACT_ALLOW\", \"SCMP_ACT_LOG\"\nRefer to: https://github.com/opencontainers/runtime-spec/blob/v1.0.2/config.md#valid-values",
 		},
 		{
 			config: &rspec.Spec{
@@ -232,7 +238,7 @@ func TestJSONSchema(t *testing.T) {
 					},
 				},
 			},
-			error: "linux.seccomp.syscalls.0.args.0.op: linux.seccomp.syscalls.0.args.0.op must be one of the following: \"SCMP_CMP_NE\", \"SCMP_CMP_LT\", \"SCMP_CMP_LE\", \"SCMP_CMP_EQ\", \"SCMP_CMP_GE\", \"SCMP_CMP_GT\", \"SCMP_CMP_MASKED_EQ\"",
+			error: "linux.seccomp.syscalls.0.args.0.op: linux.seccomp.syscalls.0.args.0.op must be one of the following: \"SCMP_CMP_NE\", \"SCMP_CMP_LT\", \"SCMP_CMP_LE\", \"SCMP_CMP_EQ\", \"SCMP_CMP_GE\", \"SCMP_CMP_GT\", \"SCMP_CMP_MASKED_EQ\"\nRefer to: https://github.com/opencontainers/runtime-spec/blob/v1.0.2/config.md#valid-values",
 		},
 	} {
 		t.Run(tt.error, func(t *testing.T) {
@@ -315,8 +321,12 @@ func TestCheckSemVer(t *testing.T) {
 		expected specerror.Code
 	}{
 		{rspec.Version, specerror.NonError},
-		// FIXME: validate currently only handles rpsec.Version
+		{"1.0.2", specerror.NonError},
+		{"1.0.2-dev", specerror.NonError},
+		{"1.1.1", specerror.LintSpecVersionUnknown},
+		{"1.99.0", specerror.LintSpecVersionUnknown},
 		{"0.0.1", specerror.NonRFCError},
+		{"2.0.0", specerror.NonRFCError},
 		{"invalid", specerror.SpecVersionInSemVer},
 	}
 
@@ -330,6 +340,85 @@ func TestCheckSemVer(t *testing.T) {
 	}
 }
 
+func TestCheckVersionFields(t *testing.T) {
+	cases := []struct {
+		val      rspec.Spec
+		expected specerror.Code
+	}{
+		{rspec.Spec{Version: "1.0.2", Domainname: "example.com"}, specerror.LintFieldNotInVersion},
+		{rspec.Spec{Version: "1.1.0", Domainname: "example.com"}, specerror.NonError},
+		{rspec.Spec{Version: "1.0.1", Hooks: &rspec.Hooks{CreateRuntime: []rspec.Hook{{Path: "/bin/true"}}}}, specerror.LintFieldNotInVersion},
+		{rspec.Spec{Version: "1.1.0", Mounts: []rspec.Mount{{Destination: "/mnt", Options: []string{"idmap"}}}}, specerror.LintFieldNotInVersion},
+		{rspec.Spec{Version: "1.2.0", Mounts: []rspec.Mount{{Destination: "/mnt", Options: []string{"idmap"}}}}, specerror.NonError},
+		{rspec.Spec{Version: "1.2.1", Linux: &rspec.Linux{NetDevices: map[string]rspec.LinuxNetDevice{"eth1": {}}}}, specerror.LintFieldNotInVersion},
+		{rspec.Spec{Version: "1.99.0", Linux: &rspec.Linux{NetDevices: map[string]rspec.LinuxNetDevice{"eth1": {}}}}, specerror.NonError},
+	}
+
+	for _, c := range cases {
+		v, err := NewValidator(&c.val, "", false, "linux")
+		if err != nil {
+			t.Errorf("unexpected NewValidator error: %+v", err)
+		}
+		err = v.CheckVersionFields()
+		assert.Equal(t, c.expected, specerror.FindError(err, c.expected), fmt.Sprintf("Fail to check version fields: %v %d", err, c.expected))
+	}
+}
+
+func TestCheckMounts(t *testing.T) {
+	cases := []struct {
+		val      rspec.Spec
+		platform string
+		expected specerror.Code
+	}{
+		{rspec.Spec{Version: "1.1.0", Mounts: []rspec.Mount{{Destination: "mnt"}}}, "linux", specerror.MountsDestAbs},
+		{rspec.Spec{Version: "1.2.0", Mounts: []rspec.Mount{{Destination: "mnt"}}}, "linux", specerror.MountsDestLinuxAbs},
+		{rspec.Spec{Version: "1.2.0", Mounts: []rspec.Mount{{Destination: "/mnt"}}}, "linux", specerror.NonError},
+		{rspec.Spec{Version: "1.2.0", Mounts: []rspec.Mount{{Destination: "mnt"}}}, "solaris", specerror.MountsDestAbs},
+		{rspec.Spec{Version: "1.2.0", Mounts: []rspec.Mount{{Destination: "/mnt", Type: "tmpfs"}}}, "linux", specerror.NonError},
+		{rspec.Spec{Version: "1.2.0", Mounts: []rspec.Mount{{Destination: "/mnt", Type: "fuse.sshfs"}}}, "linux", specerror.NonError},
+		{rspec.Spec{Version: "1.2.0", Mounts: []rspec.Mount{{Destination: "/mnt", Type: "tmpfss"}}}, "linux", specerror.LintMountTypeUnsupported},
+		{rspec.Spec{Version: "1.2.0", Mounts: []rspec.Mount{{Destination: "/mnt", Type: "bind", Options: []string{"rbind", "nosiud"}}}}, "linux", specerror.LintMountOptionUnknown},
+		{rspec.Spec{Version: "1.2.0", Mounts: []rspec.Mount{{Destination: "/mnt", Type: "bind", Options: []string{"rbind", "mode=755"}}}}, "linux", specerror.LintMountOptionUnknown},
+		{rspec.Spec{Version: "1.2.0", Mounts: []rspec.Mount{{Destination: "/mnt", Type: "tmpfs", Options: []string{"nosuid", "mode=755", "size=65536k", "x-foo"}}}}, "linux", specerror.NonError},
+		{rspec.Spec{Version: "1.2.0", Mounts: []rspec.Mount{{Destination: "/mnt", Type: "ext4", Options: []string{"data=ordered"}}}}, "linux", specerror.NonError},
+		{rspec.Spec{Version: "1.2.0", Mounts: []rspec.Mount{{Destination: "/mnt", Type: "proc", Options: []string{"ro", "rw"}}}}, "linux", specerror.LintMountOptionsConflict},
+		{rspec.Spec{Version: "1.2.0", Mounts: []rspec.Mount{{Destination: "/mnt", Type: "bind", Options: []string{"rbind", "private", "shared"}}}}, "linux", specerror.LintMountOptionsConflict},
+		{rspec.Spec{Version: "1.2.0", Mounts: []rspec.Mount{{Destination: "/mnt", Type: "bind", Options: []string{"rbind", "rprivate", "ro"}}}}, "linux", specerror.NonError},
+		{rspec.Spec{Version: "1.2.0", Mounts: []rspec.Mount{{Destination: "/proc", Type: "proc"}}, Linux: &rspec.Linux{MaskedPaths: []string{"/proc/kcore"}}}, "linux", specerror.NonError},
+		{rspec.Spec{Version: "1.2.0", Mounts: []rspec.Mount{{Destination: "/mnt/proc", Type: "proc"}}, Linux: &rspec.Linux{MaskedPaths: []string{"/proc/kcore"}}}, "linux", specerror.LintMaskedPathsBypassed},
+		{rspec.Spec{Version: "1.2.0", Mounts: []rspec.Mount{{Destination: "/host-sys", Type: "sysfs", Options: []string{"ro"}}}, Linux: &rspec.Linux{ReadonlyPaths: []string{"/sys/firmware"}}}, "linux", specerror.LintMaskedPathsBypassed},
+		{rspec.Spec{Version: "1.2.0", Mounts: []rspec.Mount{{Destination: "/proc/sys/fs/binfmt_misc", Type: "binfmt_misc"}}, Linux: &rspec.Linux{ReadonlyPaths: []string{"/proc/sys"}}}, "linux", specerror.LintMaskedPathsBypassed},
+		{rspec.Spec{Version: "1.2.0", Mounts: []rspec.Mount{{Destination: "/proc/sys/fs/binfmt_misc", Type: "binfmt_misc", Options: []string{"ro"}}}, Linux: &rspec.Linux{ReadonlyPaths: []string{"/proc/sys"}}}, "linux", specerror.NonError},
+	}
+
+	for _, c := range cases {
+		v, err := NewValidator(&c.val, "", false, c.platform)
+		if err != nil {
+			t.Errorf("unexpected NewValidator error: %+v", err)
+		}
+		err = v.CheckMounts()
+		assert.Equal(t, c.expected, specerror.FindError(err, c.expected), fmt.Sprintf("Fail to check mounts: %v %d", err, c.expected))
+		if merr, ok := err.(*multierror.Error); ok && c.expected == specerror.NonError {
+			assert.NoError(t, merr.ErrorOrNil())
+		}
+	}
+}
+
+func TestClosestMountOption(t *testing.T) {
+	for _, c := range []struct {
+		option   string
+		expected string
+	}{
+		{"nosiud", "nosuid"},
+		{"noexce", "noexec"},
+		{"rprivte", "rprivate"},
+		{"mdoe=755", "mode"},
+		{"compress=zstd", ""},
+	} {
+		assert.Equal(t, c.expected, closestMountOption(c.option, append(slices.Clone(linuxMountOptions), tmpfsOptions...)), c.option)
+	}
+}
+
 func TestCheckProcess(t *testing.T) {
 	cases := []struct {
 		val      rspec.Spec
@@ -507,6 +596,18 @@ func TestCheckLinux(t *testing.T) {
 	}
 	weightDevices[0].Major = 5
 	weightDevices[0].Minor = 0
+	memKernelTCP := int64(-1)
+	memInvalidLimit := int64(-2)
+	memLimit := int64(1048576)
+	memUseHierarchy := true
+	cpuQuota := int64(50000)
+	cpuPeriod := uint64(100000)
+	cpuBurst := uint64(20000)
+	cpuIdle := int64(1)
+	cpuInvalidIdle := int64(2)
+	cpuInvalidPeriod := uint64(500)
+	cpuInvalidBurst := uint64(60000)
+	cpuUnlimitedQuota := int64(-1)
 
 	cases := []struct {
 		val      rspec.Spec
@@ -608,70 +709,77 @@ func TestCheckLinux(t *testing.T) {
 			},
 			expected: specerror.BlkIOWeightOrLeafWeightExist,
 		},
-	}
-	for _, c := range cases {
-		v, err := NewValidator(&c.val, ".", false, "linux")
-		if err != nil {
-			t.Errorf("unexpected NewValidator error: %+v", err)
-		}
-		err = v.CheckLinux()
-		assert.Equal(t, c.expected, specerror.FindError(err, c.expected), fmt.Sprintf("failed CheckLinux: %v %d", err, c.expected))
-	}
-}
-
-func TestCheckPlatform(t *testing.T) {
-	cases := []struct {
-		val      rspec.Spec
-		platform string
-		expected specerror.Code
-	}{
 		{
 			val: rspec.Spec{
 				Version: "1.0.0",
+				Linux: &rspec.Linux{
+					Resources: &rspec.LinuxResources{
+						Rdma: map[string]rspec.LinuxRdma{
+							"mlx5_1": {},
+						},
+					},
+				},
+			},
+			expected: specerror.RdmaHcaHandlesOrHcaObjectsExist,
+		},
+		{
+			val: rspec.Spec{
+				Version: "1.0.0",
+				Linux: &rspec.Linux{
+					IntelRdt: &rspec.LinuxIntelRdt{
+						ClosID:        "ocitest",
+						L3CacheSchema: "L3:0=ff;1=ff",
+						MemBwSchema:   "MB:0=70;1=70",
+					},
+				},
 			},
-			platform: "linux",
 			expected: specerror.NonError,
 		},
 		{
 			val: rspec.Spec{
 				Version: "1.0.0",
+				Linux: &rspec.Linux{
+					IntelRdt: &rspec.LinuxIntelRdt{
+						ClosID: "../ocitest",
+					},
+				},
 			},
-			platform: "windows",
-			expected: specerror.PlatformSpecConfOnWindowsSet,
+			expected: specerror.ValidValues,
 		},
 		{
 			val: rspec.Spec{
 				Version: "1.0.0",
-				Windows: &rspec.Windows{
-					LayerFolders: []string{"C:\\Layers\\layer1"},
+				Linux: &rspec.Linux{
+					IntelRdt: &rspec.LinuxIntelRdt{
+						L3CacheSchema: "L3:0=ff,1=ff",
+					},
 				},
 			},
-			platform: "windows",
-			expected: specerror.NonError,
+			expected: specerror.ValidValues,
 		},
-	}
-	for _, c := range cases {
-		v, err := NewValidator(&c.val, ".", false, c.platform)
-		if err != nil {
-			t.Errorf("unexpected NewValidator error: %+v", err)
-		}
-		err = v.CheckPlatform()
-		assert.Equal(t, c.expected, specerror.FindError(err, c.expected), fmt.Sprintf("failed CheckPlatform: %v %d", err, c.expected))
-	}
-}
-
-func TestCheckHooks(t *testing.T) {
-	cases := []struct {
-		val      rspec.Spec
-		expected specerror.Code
-	}{
 		{
 			val: rspec.Spec{
 				Version: "1.0.0",
-				Hooks: &rspec.Hooks{
-					Prestart: []rspec.Hook{
-						{
-							Path: "/usr/bin/fix-mounts",
+				Linux: &rspec.Linux{
+					IntelRdt: &rspec.LinuxIntelRdt{
+						MemBwSchema: "L3:0=ff",
+					},
+				},
+			},
+			expected: specerror.IntelRdtMemBwSchemaPrefix,
+		},
+		{
+			val: rspec.Spec{
+				Version: "1.1.0",
+				Linux: &rspec.Linux{
+					Resources: &rspec.LinuxResources{
+						Memory: &rspec.LinuxMemory{
+							UseHierarchy:      &memUseHierarchy,
+							CheckBeforeUpdate: &memUseHierarchy,
+						},
+						Unified: map[string]string{
+							"memory.high": "max",
+							"memory.min":  "1048576",
 						},
 					},
 				},
@@ -680,119 +788,1233 @@ func TestCheckHooks(t *testing.T) {
 		},
 		{
 			val: rspec.Spec{
-				Version: "1.0.0",
-				Hooks: &rspec.Hooks{
-					Prestart: []rspec.Hook{
-						{
-							Path: "usr",
+				Version: "1.1.0",
+				Linux: &rspec.Linux{
+					Resources: &rspec.LinuxResources{
+						Memory: &rspec.LinuxMemory{
+							KernelTCP: &memKernelTCP,
 						},
 					},
 				},
 			},
-			expected: specerror.PosixHooksPathAbs,
+			expected: specerror.MemoryKernelTCPNotRecommended,
 		},
-	}
-	for _, c := range cases {
-		v, err := NewValidator(&c.val, ".", false, "linux")
-		if err != nil {
-			t.Errorf("unexpected NewValidator error: %+v", err)
-		}
-		err = v.CheckHooks()
-		assert.Equal(t, c.expected, specerror.FindError(err, c.expected), fmt.Sprintf("failed CheckHooks: %v %d", err, c.expected))
-	}
-}
-
-func TestCheckMandatoryFields(t *testing.T) {
-	for _, tt := range []struct {
-		config *rspec.Spec
-		error  string
-	}{
 		{
-			config: &rspec.Spec{},
-			error:  "1 error occurred:\n\t* 'Spec.Version' should not be empty\n\n",
+			val: rspec.Spec{
+				Version: "1.0.0",
+				Root:    &rspec.Root{Path: "rootfs"},
+				Linux: &rspec.Linux{
+					Devices: []rspec.LinuxDevice{{Path: "/dev/test", Type: "b"}},
+				},
+			},
+			expected: specerror.DevicesMajMinRequired,
 		},
 		{
-			config: nil,
-			error:  "1 error occurred:\n\t* Spec can't be nil\n\n",
+			val: rspec.Spec{
+				Version: "1.0.0",
+				Root:    &rspec.Root{Path: "rootfs"},
+				Linux: &rspec.Linux{
+					Devices: []rspec.LinuxDevice{{Path: "/dev/test", Type: "p", Major: 1, Minor: 3}},
+				},
+			},
+			expected: specerror.NonRFCError,
 		},
 		{
-			config: &rspec.Spec{
+			val: rspec.Spec{
 				Version: "1.0.0",
+				Root:    &rspec.Root{Path: "rootfs"},
+				Linux: &rspec.Linux{
+					Devices: []rspec.LinuxDevice{{Path: "/dev/test", Type: "p"}},
+				},
 			},
-			error: "",
+			expected: specerror.NonError,
 		},
 		{
-			config: &rspec.Spec{
+			val: rspec.Spec{
 				Version: "1.0.0",
-				Root:    &rspec.Root{},
+				Linux: &rspec.Linux{
+					Resources: &rspec.LinuxResources{
+						Memory: &rspec.LinuxMemory{
+							Limit: &memInvalidLimit,
+						},
+					},
+				},
 			},
-			error: "1 error occurred:\n\t* 'Root.Path' should not be empty\n\n",
+			expected: specerror.ValidValues,
 		},
-	} {
-		t.Run(tt.error, func(t *testing.T) {
-			var errs *multierror.Error
-			v := &Validator{spec: tt.config}
-			errs = multierror.Append(errs, v.CheckMandatoryFields())
-			if tt.error == "" {
-				if errs.ErrorOrNil() == nil {
-					return
-				}
-				t.Fatalf("expected no error, but got: %s", errs.Error())
-			}
-			if errs.ErrorOrNil() == nil {
-				t.Fatal("failed to raise the expected error")
-			}
-
-			for _, err := range errs.Errors {
-				if err.Error() == tt.error {
-					return
-				}
-			}
-			assert.Equal(t, tt.error, errs.Error())
-		})
-	}
-}
-
-func TestCheckAnnotations(t *testing.T) {
-	cases := []struct {
-		val      rspec.Spec
-		expected specerror.Code
-	}{
 		{
-			val:      rspec.Spec{},
-			expected: specerror.NonError,
+			val: rspec.Spec{
+				Version: "1.0.0",
+				Linux: &rspec.Linux{
+					Resources: &rspec.LinuxResources{
+						Unified: map[string]string{
+							"memory.high": "1G",
+						},
+					},
+				},
+			},
+			expected: specerror.LintUnifiedValue,
 		},
 		{
 			val: rspec.Spec{
-				Annotations: map[string]string{},
+				Version: "1.0.0",
+				Linux: &rspec.Linux{
+					Resources: &rspec.LinuxResources{
+						Unified: map[string]string{
+							"memory.min":  "2097152",
+							"memory.high": "1048576",
+						},
+					},
+				},
+			},
+			expected: specerror.LintMemoryLimitOrder,
+		},
+		{
+			val: rspec.Spec{
+				Version: "1.0.0",
+				Linux: &rspec.Linux{
+					Resources: &rspec.LinuxResources{
+						Memory: &rspec.LinuxMemory{
+							Limit: &memLimit,
+						},
+						Unified: map[string]string{
+							"memory.max": "max",
+						},
+					},
+				},
+			},
+			expected: specerror.LintMemoryLimitOrder,
+		},
+		{
+			val: rspec.Spec{
+				Version: "1.0.0",
+				Linux: &rspec.Linux{
+					Resources: &rspec.LinuxResources{
+						CPU: &rspec.LinuxCPU{
+							Quota:  &cpuQuota,
+							Period: &cpuPeriod,
+							Burst:  &cpuBurst,
+							Idle:   &cpuIdle,
+							Cpus:   "0-3,5",
+							Mems:   "0",
+						},
+					},
+				},
 			},
 			expected: specerror.NonError,
 		},
 		{
 			val: rspec.Spec{
-				Annotations: map[string]string{"invalid": ""},
+				Version: "1.0.0",
+				Linux: &rspec.Linux{
+					Resources: &rspec.LinuxResources{
+						CPU: &rspec.LinuxCPU{
+							Idle: &cpuInvalidIdle,
+						},
+					},
+				},
 			},
-			expected: specerror.AnnotationsKeyReversedDomain,
+			expected: specerror.LintCPUIdleValue,
 		},
 		{
 			val: rspec.Spec{
-				Annotations: map[string]string{"org.opencontainers.oci": ""},
+				Version: "1.0.0",
+				Linux: &rspec.Linux{
+					Resources: &rspec.LinuxResources{
+						Devices: []rspec.LinuxDeviceCgroup{
+							{Allow: true, Type: "p", Access: "rwm"},
+						},
+					},
+				},
 			},
-			expected: specerror.AnnotationsKeyReservedNS,
+			expected: specerror.LintDeviceCgroupInvalid,
 		},
 		{
 			val: rspec.Spec{
-				Annotations: map[string]string{"com.example": ""},
+				Version: "1.0.0",
+				Linux: &rspec.Linux{
+					Resources: &rspec.LinuxResources{
+						Devices: []rspec.LinuxDeviceCgroup{
+							{Allow: true, Type: "c", Access: "rx"},
+						},
+					},
+				},
 			},
-			expected: specerror.NonError,
+			expected: specerror.LintDeviceCgroupInvalid,
 		},
-	}
-	for _, c := range cases {
-		v, err := NewValidator(&c.val, ".", false, "")
-		if err != nil {
-			t.Errorf("unexpected NewValidator error: %+v", err)
-		}
-		err = v.CheckAnnotations()
-		assert.Equal(t, c.expected, specerror.FindError(err, c.expected), fmt.Sprintf("failed CheckAnnotations: %v %d", err, c.expected))
+		{
+			val: rspec.Spec{
+				Version: "1.0.0",
+				Linux: &rspec.Linux{
+					Resources: &rspec.LinuxResources{
+						CPU: &rspec.LinuxCPU{
+							Period: &cpuInvalidPeriod,
+						},
+					},
+				},
+			},
+			expected: specerror.LintCPUBandwidthRange,
+		},
+		{
+			val: rspec.Spec{
+				Version: "1.0.0",
+				Linux: &rspec.Linux{
+					Resources: &rspec.LinuxResources{
+						CPU: &rspec.LinuxCPU{
+							Quota: &cpuQuota,
+							Burst: &cpuInvalidBurst,
+						},
+					},
+				},
+			},
+			expected: specerror.CPUBurstNotLargerThanQuota,
+		},
+		{
+			val: rspec.Spec{
+				Version: "1.0.0",
+				Linux: &rspec.Linux{
+					Resources: &rspec.LinuxResources{
+						CPU: &rspec.LinuxCPU{
+							Quota: &cpuUnlimitedQuota,
+							Burst: &cpuInvalidBurst,
+						},
+					},
+				},
+			},
+			expected: specerror.NonError,
+		},
+		{
+			val: rspec.Spec{
+				Version: "1.0.0",
+				Linux: &rspec.Linux{
+					Resources: &rspec.LinuxResources{
+						CPU: &rspec.LinuxCPU{
+							Cpus: "3-1",
+						},
+					},
+				},
+			},
+			expected: specerror.ValidValues,
+		},
+		{
+			val: rspec.Spec{
+				Version: "1.0.0",
+				Linux: &rspec.Linux{
+					Resources: &rspec.LinuxResources{
+						CPU: &rspec.LinuxCPU{
+							Mems: "0,,1",
+						},
+					},
+				},
+			},
+			expected: specerror.ValidValues,
+		},
+		{
+			val: rspec.Spec{
+				Version:  "1.0.0",
+				Hostname: "test",
+				Linux:    &rspec.Linux{},
+			},
+			expected: specerror.LintUTSNamespace,
+		},
+		{
+			val: rspec.Spec{
+				Version:  "1.0.0",
+				Hostname: "test",
+				Linux: &rspec.Linux{
+					Namespaces: []rspec.LinuxNamespace{
+						{
+							Type: "uts",
+						},
+					},
+				},
+			},
+			expected: specerror.NonError,
+		},
+		{
+			val: rspec.Spec{
+				Version:    "1.0.0",
+				Domainname: "example.com",
+				Linux:      &rspec.Linux{},
+			},
+			expected: specerror.LintUTSNamespace,
+		},
+		{
+			val: rspec.Spec{
+				Version: "1.0.0",
+				Linux: &rspec.Linux{
+					TimeOffsets: map[string]rspec.LinuxTimeOffset{
+						"monotonic": {Secs: 10},
+					},
+				},
+			},
+			expected: specerror.LintTimeNamespace,
+		},
+		{
+			val: rspec.Spec{
+				Version: "1.0.0",
+				Mounts: []rspec.Mount{
+					{
+						Destination: "/data",
+						Options:     []string{"bind", "idmap"},
+						UIDMappings: []rspec.LinuxIDMapping{{ContainerID: 0, HostID: 1000, Size: 1}},
+					},
+				},
+				Linux: &rspec.Linux{},
+			},
+			expected: specerror.PosixMountsUIDMappingsWithGIDMappings,
+		},
+		{
+			val: rspec.Spec{
+				Version: "1.0.0",
+				Mounts: []rspec.Mount{
+					{
+						Destination: "/data",
+						Options:     []string{"bind"},
+						UIDMappings: []rspec.LinuxIDMapping{{ContainerID: 0, HostID: 1000, Size: 1}},
+						GIDMappings: []rspec.LinuxIDMapping{{ContainerID: 0, HostID: 1000, Size: 1}},
+					},
+				},
+				Linux: &rspec.Linux{},
+			},
+			expected: specerror.PosixMountsIDMappingsOptions,
+		},
+		{
+			val: rspec.Spec{
+				Version: "1.0.0",
+				Mounts: []rspec.Mount{
+					{
+						Destination: "/data",
+						Options:     []string{"rbind", "ridmap"},
+					},
+				},
+				Linux: &rspec.Linux{},
+			},
+			expected: specerror.MountsIDMapWithoutUserNSError,
+		},
+		{
+			val: rspec.Spec{
+				Version: "1.0.0",
+				Mounts: []rspec.Mount{
+					{
+						Destination: "/data",
+						Options:     []string{"rbind", "ridmap"},
+					},
+				},
+				Linux: &rspec.Linux{
+					Namespaces: []rspec.LinuxNamespace{
+						{
+							Type: "user",
+						},
+					},
+					UIDMappings: []rspec.LinuxIDMapping{{ContainerID: 0, HostID: 1000, Size: 1}},
+					GIDMappings: []rspec.LinuxIDMapping{{ContainerID: 0, HostID: 1000, Size: 1}},
+				},
+			},
+			expected: specerror.NonError,
+		},
+		{
+			val: rspec.Spec{
+				Version: "1.0.0",
+				Linux: &rspec.Linux{
+					Seccomp: &rspec.LinuxSeccomp{
+						DefaultAction:    rspec.ActAllow,
+						ListenerMetadata: "metadata",
+					},
+				},
+			},
+			expected: specerror.SeccListenerMetadataWithoutPath,
+		},
+		{
+			val: rspec.Spec{
+				Version: "1.0.0",
+				Linux: &rspec.Linux{
+					Sysctl: map[string]string{
+						"net.ipv4.ip_forward": "1",
+					},
+				},
+			},
+			expected: specerror.LintSysctlNamespace,
+		},
+		{
+			val: rspec.Spec{
+				Version: "1.0.0",
+				Linux: &rspec.Linux{
+					MountLabel: "svirt_sandbox_file_t",
+				},
+			},
+			expected: specerror.LintMountLabelInvalid,
+		},
+	}
+	for _, c := range cases {
+		v, err := NewValidator(&c.val, ".", false, "linux")
+		if err != nil {
+			t.Errorf("unexpected NewValidator error: %+v", err)
+		}
+		err = v.CheckLinux()
+		assert.Equal(t, c.expected, specerror.FindError(err, c.expected), fmt.Sprintf("failed CheckLinux: %v %d", err, c.expected))
+		if merr, ok := err.(*multierror.Error); ok && c.expected == specerror.NonError {
+			assert.NoError(t, merr.ErrorOrNil())
+		}
+	}
+}
+
+func TestCheckPlatform(t *testing.T) {
+	cases := []struct {
+		val      rspec.Spec
+		platform string
+		expected specerror.Code
+	}{
+		{
+			val: rspec.Spec{
+				Version: "1.0.0",
+			},
+			platform: "linux",
+			expected: specerror.NonError,
+		},
+		{
+			val: rspec.Spec{
+				Version: "1.0.0",
+			},
+			platform: "windows",
+			expected: specerror.PlatformSpecConfOnWindowsSet,
+		},
+		{
+			val: rspec.Spec{
+				Version: "1.0.0",
+				Windows: &rspec.Windows{
+					LayerFolders: []string{"C:\\Layers\\layer1"},
+				},
+			},
+			platform: "windows",
+			expected: specerror.NonError,
+		},
+	}
+	for _, c := range cases {
+		v, err := NewValidator(&c.val, ".", false, c.platform)
+		if err != nil {
+			t.Errorf("unexpected NewValidator error: %+v", err)
+		}
+		err = v.CheckPlatform()
+		assert.Equal(t, c.expected, specerror.FindError(err, c.expected), fmt.Sprintf("failed CheckPlatform: %v %d", err, c.expected))
+	}
+}
+
+func TestCheckHooks(t *testing.T) {
+	zeroTimeout := 0
+	cases := []struct {
+		val      rspec.Spec
+		expected specerror.Code
+	}{
+		{
+			val: rspec.Spec{
+				Version: "1.0.0",
+				Hooks: &rspec.Hooks{
+					Prestart: []rspec.Hook{
+						{
+							Path: "/usr/bin/fix-mounts",
+						},
+					},
+				},
+			},
+			expected: specerror.NonError,
+		},
+		{
+			val: rspec.Spec{
+				Version: "1.0.0",
+				Hooks: &rspec.Hooks{
+					Prestart: []rspec.Hook{
+						{
+							Path: "usr",
+						},
+					},
+				},
+			},
+			expected: specerror.PosixHooksPathAbs,
+		},
+		{
+			val: rspec.Spec{
+				Version: "1.0.0",
+				Hooks: &rspec.Hooks{
+					StartContainer: []rspec.Hook{
+						{
+							Path: "bin/hook",
+						},
+					},
+				},
+			},
+			expected: specerror.PosixHooksPathAbs,
+		},
+		{
+			val: rspec.Spec{
+				Version: "1.0.0",
+				Hooks: &rspec.Hooks{
+					CreateRuntime: []rspec.Hook{
+						{
+							Path:    "/usr/bin/setup-network",
+							Timeout: &zeroTimeout,
+						},
+					},
+				},
+			},
+			expected: specerror.PosixHooksTimeoutPositive,
+		},
+	}
+	for _, c := range cases {
+		v, err := NewValidator(&c.val, ".", false, "linux")
+		if err != nil {
+			t.Errorf("unexpected NewValidator error: %+v", err)
+		}
+		err = v.CheckHooks()
+		assert.Equal(t, c.expected, specerror.FindError(err, c.expected), fmt.Sprintf("failed CheckHooks: %v %d", err, c.expected))
+	}
+}
+
+func TestParseCPUSetList(t *testing.T) {
+	for _, c := range []struct {
+		list     string
+		expected []int
+		valid    bool
+	}{
+		{"0-3,5", []int{0, 1, 2, 3, 5}, true},
+		{" 0-1 , 4 ", []int{0, 1, 4}, true},
+		{"0-7:2/4", []int{0, 1, 4, 5}, true},
+		{"0-9:1/3,12", []int{0, 3, 6, 9, 12}, true},
+		{"0-3:4/4", []int{0, 1, 2, 3}, true},
+		{"3-1", nil, false},
+		{"0-7:3/2", nil, false},
+		{"0-7:0/2", nil, false},
+		{"0-7:2", nil, false},
+		{"4:1/2", nil, false},
+		{"a", nil, false},
+	} {
+		ids, err := parseCPUSetList(c.list)
+		if c.valid {
+			assert.NoError(t, err, c.list)
+		} else {
+			assert.Error(t, err, c.list)
+		}
+		assert.Equal(t, c.expected, ids, c.list)
+	}
+}
+
+func TestCheckMandatoryFields(t *testing.T) {
+	for _, tt := range []struct {
+		config *rspec.Spec
+		error  string
+	}{
+		{
+			config: &rspec.Spec{},
+			error:  "1 error occurred:\n\t* 'Spec.Version' should not be empty\nRefer to: https://github.com/opencontainers/runtime-spec/blob/v" + rspec.Version + "/config.md#valid-values\n\n",
+		},
+		{
+			config: nil,
+			error:  "1 error occurred:\n\t* Spec can't be nil\n\n",
+		},
+		{
+			config: &rspec.Spec{
+				Version: "1.0.0",
+			},
+			error: "",
+		},
+		{
+			config: &rspec.Spec{
+				Version: "1.0.0",
+				Root:    &rspec.Root{},
+			},
+			error: "1 error occurred:\n\t* 'Root.Path' should not be empty\nRefer to: https://github.com/opencontainers/runtime-spec/blob/v1.0.0/config.md#valid-values\n\n",
+		},
+	} {
+		t.Run(tt.error, func(t *testing.T) {
+			var errs *multierror.Error
+			v := &Validator{spec: tt.config}
+			errs = multierror.Append(errs, v.CheckMandatoryFields())
+			if tt.error == "" {
+				if errs.ErrorOrNil() == nil {
+					return
+				}
+				t.Fatalf("expected no error, but got: %s", errs.Error())
+			}
+			if errs.ErrorOrNil() == nil {
+				t.Fatal("failed to raise the expected error")
+			}
+
+			for _, err := range errs.Errors {
+				if err.Error() == tt.error {
+					return
+				}
+			}
+			assert.Equal(t, tt.error, errs.Error())
+		})
+	}
+}
+
+func TestCheckAnnotations(t *testing.T) {
+	cases := []struct {
+		val      rspec.Spec
+		expected specerror.Code
+	}{
+		{
+			val:      rspec.Spec{},
+			expected: specerror.NonError,
+		},
+		{
+			val: rspec.Spec{
+				Annotations: map[string]string{},
+			},
+			expected: specerror.NonError,
+		},
+		{
+			val: rspec.Spec{
+				Annotations: map[string]string{"invalid": ""},
+			},
+			expected: specerror.AnnotationsKeyReversedDomain,
+		},
+		{
+			val: rspec.Spec{
+				Annotations: map[string]string{"org.opencontainers.oci": ""},
+			},
+			expected: specerror.AnnotationsKeyReservedNS,
+		},
+		{
+			val: rspec.Spec{
+				Annotations: map[string]string{"com.example": ""},
+			},
+			expected: specerror.NonError,
+		},
+	}
+	for _, c := range cases {
+		v, err := NewValidator(&c.val, ".", false, "")
+		if err != nil {
+			t.Errorf("unexpected NewValidator error: %+v", err)
+		}
+		err = v.CheckAnnotations()
+		assert.Equal(t, c.expected, specerror.FindError(err, c.expected), fmt.Sprintf("failed CheckAnnotations: %v %d", err, c.expected))
+	}
+}
+
+// writeELF writes the headers of a little-endian ELF executable, with a
+// PT_INTERP program header if interpreter is set.
+func writeELF(t *testing.T, path string, class elf.Class, machine elf.Machine, interpreter string) {
+	var buf bytes.Buffer
+	ident := [elf.EI_NIDENT]byte{0x7f, 'E', 'L', 'F', byte(class), byte(elf.ELFDATA2LSB), byte(elf.EV_CURRENT)}
+	var phnum uint16
+	if interpreter != "" {
+		phnum = 1
+	}
+	if class == elf.ELFCLASS64 {
+		binary.Write(&buf, binary.LittleEndian, elf.Header64{Ident: ident, Type: uint16(elf.ET_EXEC), Machine: uint16(machine), Version: uint32(elf.EV_CURRENT), Phoff: 64, Ehsize: 64, Phentsize: 56, Phnum: phnum})
+		if interpreter != "" {
+			binary.Write(&buf, binary.LittleEndian, elf.Prog64{Type: uint32(elf.PT_INTERP), Off: 120, Filesz: uint64(len(interpreter) + 1), Memsz: uint64(len(interpreter) + 1)})
+		}
+	} else {
+		binary.Write(&buf, binary.LittleEndian, elf.Header32{Ident: ident, Type: uint16(elf.ET_EXEC), Machine: uint16(machine), Version: uint32(elf.EV_CURRENT), Phoff: 52, Ehsize: 52, Phentsize: 32, Phnum: phnum})
+		if interpreter != "" {
+			binary.Write(&buf, binary.LittleEndian, elf.Prog32{Type: uint32(elf.PT_INTERP), Off: 84, Filesz: uint32(len(interpreter) + 1), Memsz: uint32(len(interpreter) + 1)})
+		}
+	}
+	if interpreter != "" {
+		buf.WriteString(interpreter + "\x00")
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckProcessExecutableEmptyRootfs(t *testing.T) {
+	bundle := t.TempDir()
+	if err := os.Mkdir(filepath.Join(bundle, "rootfs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, root := range []string{"rootfs", "missing"} {
+		spec := &rspec.Spec{
+			Root:    &rspec.Root{Path: root},
+			Process: &rspec.Process{Args: []string{"sh"}, Cwd: "/", Env: []string{"PATH=/bin"}},
+		}
+		v, err := NewValidator(spec, bundle, false, "linux")
+		if err != nil {
+			t.Fatalf("unexpected NewValidator error: %+v", err)
+		}
+		v.HostSpecific = true
+		assert.NoError(t, v.CheckProcessExecutable(), root)
+	}
+}
+
+func TestCheckProcessExecutable(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("host-specific validation of a linux rootfs")
+	}
+
+	bundle := t.TempDir()
+	rootfs := filepath.Join(bundle, "rootfs")
+	for _, dir := range []string{"bin", "lib", "usr/bin"} {
+		if err := os.MkdirAll(filepath.Join(rootfs, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeELF(t, filepath.Join(rootfs, "lib/ld.so"), elf.ELFCLASS64, elf.EM_X86_64, "")
+	writeELF(t, filepath.Join(rootfs, "bin/sh"), elf.ELFCLASS64, elf.EM_X86_64, "/lib/ld.so")
+	writeELF(t, filepath.Join(rootfs, "usr/bin/dynamic"), elf.ELFCLASS64, elf.EM_X86_64, "/lib/missing.so")
+	writeELF(t, filepath.Join(rootfs, "usr/bin/app32"), elf.ELFCLASS32, elf.EM_386, "")
+	for name, content := range map[string]string{
+		"usr/bin/script":         "#!/bin/sh -e\necho ok\n",
+		"usr/bin/script-missing": "#!/bin/missing\n",
+		"usr/bin/script-loop":    "#!/usr/bin/script-loop\n",
+	} {
+		if err := os.WriteFile(filepath.Join(rootfs, name), []byte(content), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(rootfs, "usr/bin/noexec"), []byte("#!/bin/sh\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// Symlinks resolve inside the rootfs, whatever exists on the host.
+	if err := os.Symlink("/lib/ld.so", filepath.Join(rootfs, "usr/bin/absolute")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("../../../../../../../../usr/bin/env", filepath.Join(rootfs, "usr/bin/escape")); err != nil {
+		t.Fatal(err)
+	}
+
+	amd64 := []rspec.Arch{rspec.ArchX86_64}
+	path := []string{"PATH=/usr/bin:/bin"}
+	cases := []struct {
+		args     []string
+		env      []string
+		cwd      string
+		archs    []rspec.Arch
+		expected specerror.Code
+	}{
+		{[]string{"sh"}, path, "/", amd64, specerror.NonError},
+		{[]string{"sh"}, nil, "/", amd64, specerror.LintProcessArgsNotExecutable},
+		{[]string{"missing"}, path, "/", amd64, specerror.LintProcessArgsNotExecutable},
+		{[]string{"/bin/sh"}, nil, "/", amd64, specerror.NonError},
+		{[]string{"./sh"}, nil, "/bin", amd64, specerror.NonError},
+		{[]string{"app32"}, path, "/", amd64, specerror.LintExecutableArchitecture},
+		{[]string{"app32"}, path, "/", []rspec.Arch{rspec.ArchX86_64, rspec.ArchX86}, specerror.NonError},
+		{[]string{"dynamic"}, path, "/", amd64, specerror.LintProcessArgsNotExecutable},
+		{[]string{"script"}, path, "/", amd64, specerror.NonError},
+		{[]string{"script-missing"}, path, "/", amd64, specerror.LintProcessArgsNotExecutable},
+		{[]string{"script-loop"}, path, "/", amd64, specerror.LintProcessArgsNotExecutable},
+		{[]string{"/usr/bin/noexec"}, nil, "/", amd64, specerror.LintProcessArgsNotExecutable},
+		{[]string{"/usr/bin/absolute"}, nil, "/", amd64, specerror.NonError},
+		{[]string{"/usr/bin/escape"}, nil, "/", amd64, specerror.LintProcessArgsNotExecutable},
+	}
+	for _, c := range cases {
+		spec := &rspec.Spec{
+			Root:    &rspec.Root{Path: "rootfs"},
+			Process: &rspec.Process{Args: c.args, Env: c.env, Cwd: c.cwd},
+			Linux:   &rspec.Linux{Seccomp: &rspec.LinuxSeccomp{Architectures: c.archs}},
+		}
+		v, err := NewValidator(spec, bundle, true, "linux")
+		if err != nil {
+			t.Fatalf("unexpected NewValidator error: %+v", err)
+		}
+		err = v.CheckProcessExecutable()
+		assert.Equal(t, c.expected, specerror.FindError(err, c.expected), fmt.Sprintf("Fail to check process executable %v: %v %d", c.args, err, c.expected))
+	}
+}
+
+func TestCheckProcessUser(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("host-specific validation of a linux rootfs")
+	}
+
+	bundle := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(bundle, "rootfs/etc"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	passwd := "# users\nroot:x:0:0:root:/root:/bin/sh\nalice:x:1000:1000::/home/alice:/bin/sh\n+nis\n"
+	group := "root:x:0:\nalice:x:1000:\nwheel:x:10:root,alice\n"
+	if err := os.WriteFile(filepath.Join(bundle, "rootfs/etc/passwd"), []byte(passwd), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(bundle, "rootfs/etc/group"), []byte(group), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	userns := []rspec.LinuxNamespace{{Type: rspec.UserNamespace}}
+	mappings := []rspec.LinuxIDMapping{{ContainerID: 0, HostID: 100000, Size: 1001}}
+	cases := []struct {
+		user         rspec.User
+		hostSpecific bool
+		linux        *rspec.Linux
+		expected     specerror.Code
+	}{
+		{rspec.User{Username: "alice", UID: 1000, GID: 1000, AdditionalGids: []uint32{10}}, true, nil, specerror.LintPlatformField},
+		{rspec.User{Username: "bob", UID: 1000, GID: 1000}, true, nil, specerror.LintProcessUserNotFound},
+		{rspec.User{Username: "alice", UID: 0, GID: 1000, AdditionalGids: []uint32{10}}, true, nil, specerror.LintProcessUserNotFound},
+		{rspec.User{Username: "alice", UID: 1000, GID: 0, AdditionalGids: []uint32{10}}, true, nil, specerror.LintProcessUserNotFound},
+		{rspec.User{Username: "alice", UID: 1000, GID: 1000}, true, nil, specerror.LintProcessUserGroups},
+		{rspec.User{Username: "bob", UID: 1000, GID: 1000}, false, nil, specerror.LintPlatformField},
+		{rspec.User{UID: 1000, GID: 1000, AdditionalGids: []uint32{10}}, false, &rspec.Linux{Namespaces: userns, UIDMappings: mappings, GIDMappings: mappings}, specerror.NonError},
+		{rspec.User{UID: 2000, GID: 1000}, false, &rspec.Linux{Namespaces: userns, UIDMappings: mappings, GIDMappings: mappings}, specerror.LintProcessUserUnmapped},
+		{rspec.User{UID: 1000, GID: 2000}, false, &rspec.Linux{Namespaces: userns, UIDMappings: mappings, GIDMappings: mappings}, specerror.LintProcessUserUnmapped},
+		{rspec.User{UID: 1000, GID: 1000, AdditionalGids: []uint32{2000}}, false, &rspec.Linux{Namespaces: userns, UIDMappings: mappings, GIDMappings: mappings}, specerror.LintProcessUserUnmapped},
+		{rspec.User{UID: 2000, GID: 2000}, false, &rspec.Linux{UIDMappings: mappings, GIDMappings: mappings}, specerror.NonError},
+		{rspec.User{UID: 2000, GID: 2000}, false, &rspec.Linux{Namespaces: []rspec.LinuxNamespace{{Type: rspec.UserNamespace, Path: "/proc/1/ns/user"}}, UIDMappings: mappings, GIDMappings: mappings}, specerror.NonError},
+	}
+	for _, c := range cases {
+		spec := &rspec.Spec{
+			Root:    &rspec.Root{Path: "rootfs"},
+			Process: &rspec.Process{User: c.user},
+			Linux:   c.linux,
+		}
+		v, err := NewValidator(spec, bundle, c.hostSpecific, "linux")
+		if err != nil {
+			t.Fatalf("unexpected NewValidator error: %+v", err)
+		}
+		err = v.CheckProcessUser()
+		assert.Equal(t, c.expected, specerror.FindError(err, c.expected), fmt.Sprintf("Fail to check process user %+v: %v %d", c.user, err, c.expected))
+		if merr, ok := err.(*multierror.Error); ok && c.expected == specerror.LintPlatformField {
+			assert.Len(t, merr.Errors, 1, fmt.Sprintf("Fail to check process user %+v: %v", c.user, err))
+		}
+	}
+
+	// Windows resolves usernames itself, there is no /etc/passwd.
+	spec := &rspec.Spec{
+		Root:    &rspec.Root{Path: "rootfs"},
+		Process: &rspec.Process{User: rspec.User{Username: "ContainerUser"}},
+	}
+	v, err := NewValidator(spec, bundle, false, "windows")
+	if err != nil {
+		t.Fatalf("unexpected NewValidator error: %+v", err)
+	}
+	assert.NoError(t, v.CheckProcessUser())
+	v.HostSpecific = true
+	assert.NoError(t, v.CheckProcessUser())
+}
+
+func TestCheckMountSources(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("host-specific validation of linux mounts")
+	}
+
+	bundle := t.TempDir()
+	for _, dir := range []string{"rootfs/data", "rootfs/etc", "data", "host/etc"} {
+		if err := os.MkdirAll(filepath.Join(bundle, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, file := range []string{"rootfs/etc/hosts", "hosts"} {
+		if err := os.WriteFile(filepath.Join(bundle, file), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dangerous := []string{filepath.Join(bundle, "host/etc")}
+
+	cases := []struct {
+		mount    rspec.Mount
+		expected specerror.Code
+	}{
+		{rspec.Mount{Destination: "/data", Type: "bind", Source: "data", Options: []string{"rbind"}}, specerror.NonError},
+		{rspec.Mount{Destination: "/data", Type: "bind", Source: filepath.Join(bundle, "data"), Options: []string{"rbind"}}, specerror.NonError},
+		{rspec.Mount{Destination: "/etc/hosts", Type: "bind", Source: "hosts", Options: []string{"bind"}}, specerror.NonError},
+		{rspec.Mount{Destination: "/data", Type: "bind", Source: "missing", Options: []string{"rbind"}}, specerror.LintMountSourceMissing},
+		{rspec.Mount{Destination: "/data", Type: "bind", Source: "hosts", Options: []string{"bind"}}, specerror.LintMountSourceTypeMismatch},
+		{rspec.Mount{Destination: "/etc/hosts", Type: "bind", Source: "data", Options: []string{"rbind"}}, specerror.LintMountSourceTypeMismatch},
+		{rspec.Mount{Destination: "/new", Type: "bind", Source: "data", Options: []string{"rbind"}}, specerror.NonError},
+		{rspec.Mount{Destination: "/data", Type: "bind", Source: "host/etc", Options: []string{"rbind"}}, specerror.LintMountSourceDangerous},
+		{rspec.Mount{Destination: "/data", Type: "bind", Source: "host", Options: []string{"rbind"}}, specerror.LintMountSourceDangerous},
+		{rspec.Mount{Destination: "/data", Type: "bind", Source: "host/etc/../etc", Options: []string{"rbind"}}, specerror.LintMountSourceDangerous},
+		{rspec.Mount{Destination: "/data", Type: "bind", Source: "host", Options: []string{"rbind", "ro"}}, specerror.NonError},
+		{rspec.Mount{Destination: "/tmp", Type: "tmpfs", Source: "missing"}, specerror.NonError},
+	}
+	for _, c := range cases {
+		spec := &rspec.Spec{
+			Root:   &rspec.Root{Path: "rootfs"},
+			Mounts: []rspec.Mount{c.mount},
+		}
+		v, err := NewValidator(spec, bundle, true, "linux")
+		if err != nil {
+			t.Fatalf("unexpected NewValidator error: %+v", err)
+		}
+		v.DangerousMountSources = dangerous
+		v.DangerousMountDirs = nil
+		err = v.CheckMountSources()
+		assert.Equal(t, c.expected, specerror.FindError(err, c.expected), fmt.Sprintf("Fail to check mount source %+v: %v %d", c.mount, err, c.expected))
+		if merr, ok := err.(*multierror.Error); ok && c.expected == specerror.NonError {
+			assert.NoError(t, merr.ErrorOrNil())
+		}
+	}
+}
+
+func TestCheckMountSourcesEngineState(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("host-specific validation of linux mounts")
+	}
+
+	// The host is rebased on host/ of the bundle.
+	bundle := t.TempDir()
+	host := filepath.Join(bundle, "host")
+	pod := "var/lib/kubelet/pods/0f6c6d1e-3a4b-4c2d-9e8f-1a2b3c4d5e6f"
+	sandbox := "var/lib/containerd/io.containerd.grpc.v1.cri/sandboxes/4a5b6c7d8e9f"
+	for _, dir := range []string{pod + "/volumes/kubernetes.io~empty-dir/cache", pod + "/volumes/kubernetes.io~secret/token", "dev"} {
+		if err := os.MkdirAll(filepath.Join(host, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(host, sandbox), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range []string{pod + "/etc-hosts", sandbox + "/hostname", sandbox + "/resolv.conf", "dev/null", "dev/fuse"} {
+		if err := os.WriteFile(filepath.Join(host, file), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rebase := func(paths []string) (rebased []string) {
+		for _, path := range paths {
+			rebased = append(rebased, filepath.Join(host, path))
+		}
+		return
+	}
+
+	bind := func(destination, source string, options ...string) rspec.Mount {
+		return rspec.Mount{Destination: destination, Type: "bind", Source: filepath.Join(host, source), Options: append([]string{"rbind"}, options...)}
+	}
+	spec := &rspec.Spec{
+		Root: &rspec.Root{Path: "rootfs"},
+		Mounts: []rspec.Mount{
+			bind("/cache", pod+"/volumes/kubernetes.io~empty-dir/cache", "rw"),
+			bind("/var/run/secrets/kubernetes.io/serviceaccount", pod+"/volumes/kubernetes.io~secret/token", "ro"),
+			bind("/etc/hosts", pod+"/etc-hosts", "rw"),
+			bind("/etc/hostname", sandbox+"/hostname", "rw"),
+			bind("/etc/resolv.conf", sandbox+"/resolv.conf", "rw"),
+			bind("/dev/null", "dev/null", "rw"),
+			bind("/dev/fuse", "dev/fuse", "rw"),
+		},
+	}
+	v, err := NewValidator(spec, bundle, true, "linux")
+	if err != nil {
+		t.Fatalf("unexpected NewValidator error: %+v", err)
+	}
+	v.DangerousMountSources = rebase(DefaultDangerousMountSources)
+	v.DangerousMountDirs = rebase(DefaultDangerousMountDirs)
+	assert.NoError(t, multierror.Append(nil, v.CheckMountSources()).ErrorOrNil())
+
+	for _, source := range []string{"var/lib/kubelet", "var/lib", "dev"} {
+		spec.Mounts = []rspec.Mount{bind("/data", source)}
+		err := v.CheckMountSources()
+		assert.Equal(t, specerror.LintMountSourceDangerous, specerror.FindError(err, specerror.LintMountSourceDangerous), fmt.Sprintf("Fail to check mount source %q: %v", source, err))
+	}
+}
+
+func TestMountSourceOverlaps(t *testing.T) {
+	for _, c := range []struct {
+		source    string
+		dangerous string
+		inside    bool
+		expected  bool
+	}{
+		{"/etc", "/etc", true, true},
+		{"/", "/etc", true, true},
+		{"/etc/ssl", "/etc", true, true},
+		{"/etcetera", "/etc", true, false},
+		{"/data", "/", true, false},
+		{"/", "/", true, true},
+		{"/var/lib/kubelet", "/var/lib/kubelet", false, true},
+		{"/var/lib", "/var/lib/kubelet", false, true},
+		{"/var/lib/kubelet/pods", "/var/lib/kubelet", false, false},
+	} {
+		assert.Equal(t, c.expected, mountSourceOverlaps(c.source, c.dangerous, c.inside), "%s %s %v", c.source, c.dangerous, c.inside)
+	}
+}
+
+func TestCheckPolicy(t *testing.T) {
+	policy := `{"rules": [
+		{"id": "memory-limit", "path": "linux.resources.memory.limit", "exists": true},
+		{"id": "data-binds", "level": "SHOULD", "path": "mounts[?options==rbind].source", "pattern": "^/data(/|$)"},
+		{"id": "owner", "path": "annotations[\"com.example.owner\"]", "exists": true},
+		{"id": "no-privileged", "path": "process.capabilities.bounding[*]", "oneOf": ["CAP_CHOWN", "CAP_KILL"]},
+		{"id": "pids", "level": "MAY", "path": "linux.resources.pids.limit", "min": 1, "max": 1024},
+		{"id": "no-host-network", "path": "linux.namespaces[?type==network].path", "exists": false}
+	]}`
+	rules, err := ParsePolicy(strings.NewReader(policy))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	limit := int64(1 << 30)
+	pids := int64(4096)
+	cases := []struct {
+		spec     rspec.Spec
+		expected []string
+	}{
+		{
+			rspec.Spec{
+				Process:     &rspec.Process{Capabilities: &rspec.LinuxCapabilities{Bounding: []string{"CAP_KILL"}}},
+				Mounts:      []rspec.Mount{{Destination: "/data", Source: "/data/app", Options: []string{"rbind"}}, {Destination: "/tmp", Type: "tmpfs", Source: "tmpfs"}},
+				Annotations: map[string]string{"com.example.owner": "team"},
+				Linux: &rspec.Linux{
+					Resources:  &rspec.LinuxResources{Memory: &rspec.LinuxMemory{Limit: &limit}},
+					Namespaces: []rspec.LinuxNamespace{{Type: rspec.NetworkNamespace}},
+				},
+			},
+			nil,
+		},
+		{
+			rspec.Spec{
+				Process: &rspec.Process{Capabilities: &rspec.LinuxCapabilities{Bounding: []string{"CAP_KILL", "CAP_SYS_ADMIN"}}},
+				Mounts:  []rspec.Mount{{Destination: "/etc", Source: "/etc", Options: []string{"rbind", "ro"}}},
+				Linux: &rspec.Linux{
+					Resources:  &rspec.LinuxResources{Pids: &rspec.LinuxPids{Limit: &pids}},
+					Namespaces: []rspec.LinuxNamespace{{Type: rspec.NetworkNamespace, Path: "/proc/1/ns/net"}},
+				},
+			},
+			[]string{
+				"policy rule memory-limit: linux.resources.memory.limit is not set",
+				`policy rule data-binds: mounts[0].source is "/etc", which does not match "^/data(/|$)"`,
+				`policy rule owner: annotations["com.example.owner"] is not set`,
+				`policy rule no-privileged: process.capabilities.bounding[1] is "CAP_SYS_ADMIN", not one of ["CAP_CHOWN","CAP_KILL"]`,
+				"policy rule pids: linux.resources.pids.limit is 4096, above the maximum 1024",
+				"policy rule no-host-network: linux.namespaces[0].path is set",
+			},
+		},
+	}
+	for _, c := range cases {
+		v, err := NewValidator(&c.spec, "", false, "linux")
+		if err != nil {
+			t.Fatalf("unexpected NewValidator error: %+v", err)
+		}
+		for _, rule := range rules {
+			assert.NoError(t, v.AddCheck(rule))
+		}
+		var messages []string
+		if merr, ok := v.CheckPolicy().(*multierror.Error); ok {
+			for _, err := range merr.Errors {
+				e, ok := err.(*specerror.Error)
+				if !ok || e.Code != specerror.PolicyViolation || e.Rule == "" {
+					t.Errorf("unexpected policy error %#v", err)
+					continue
+				}
+				messages = append(messages, e.Err.Err.Error())
+			}
+		}
+		assert.Equal(t, c.expected, messages)
+	}
+}
+
+func TestAddCheck(t *testing.T) {
+	spec := rspec.Spec{Hostname: "example"}
+	v, err := NewValidator(&spec, "", false, "linux")
+	if err != nil {
+		t.Fatalf("unexpected NewValidator error: %+v", err)
+	}
+	rule := PolicyRule{
+		ID:    "no-hostname",
+		Level: rfc2119.Should,
+		Check: func(spec *rspec.Spec) error {
+			if spec.Hostname != "" {
+				return fmt.Errorf("hostname is set")
+			}
+			return nil
+		},
+	}
+	assert.NoError(t, v.AddCheck(rule))
+	assert.Error(t, v.AddCheck(rule))
+	assert.Error(t, v.AddCheck(PolicyRule{ID: "no-check"}))
+
+	err = v.CheckPolicy()
+	assert.Equal(t, specerror.PolicyViolation, specerror.FindError(err, specerror.PolicyViolation))
+
+	levelErrors, err := specerror.SplitLevel(err, rfc2119.Must)
+	assert.NoError(t, err)
+	assert.Nil(t, levelErrors.Error.ErrorOrNil())
+	if assert.Len(t, levelErrors.Warnings, 1) {
+		assert.Equal(t, "no-hostname", levelErrors.Warnings[0].Rule)
+	}
+}
+
+func TestParsePolicyErrors(t *testing.T) {
+	for _, policy := range []string{
+		`{"rules": [{"path": "hostname", "exists": true}]}`,
+		`{"rules": [{"id": "a", "path": "hostname"}]}`,
+		`{"rules": [{"id": "a", "path": "mounts[", "exists": true}]}`,
+		`{"rules": [{"id": "a", "path": "mounts[?type]", "exists": true}]}`,
+		`{"rules": [{"id": "a", "level": "SOMETIMES", "path": "hostname", "exists": true}]}`,
+		`{"rules": [{"id": "a", "path": "hostname", "pattern": "("}]}`,
+		`{"rules": [{"id": "a", "path": "hostname", "exist": true}]}`,
+	} {
+		_, err := ParsePolicy(strings.NewReader(policy))
+		assert.Error(t, err, policy)
+	}
+}
+
+func TestCheckCapabilities(t *testing.T) {
+	all := []string{"CAP_CHOWN", "CAP_BPF"}
+	cases := []struct {
+		caps            rspec.LinuxCapabilities
+		uid             uint32
+		noNewPrivileges bool
+		kernel          string
+		expected        specerror.Code
+	}{
+		{rspec.LinuxCapabilities{Bounding: all, Effective: all, Permitted: all}, 0, false, "", specerror.NonError},
+		{rspec.LinuxCapabilities{Bounding: all[:1], Effective: all, Permitted: all}, 0, false, "", specerror.LintCapabilityNotBounded},
+		{rspec.LinuxCapabilities{Bounding: all[:1], Inheritable: all}, 0, false, "", specerror.LintCapabilityNotBounded},
+		{rspec.LinuxCapabilities{Bounding: []string{"CAP_CHOWN", "CAP_BPF", "CAP_CHOWN"}}, 0, false, "", specerror.LintCapabilityDuplicate},
+		{rspec.LinuxCapabilities{Bounding: all}, 0, false, "5.8", specerror.NonError},
+		{rspec.LinuxCapabilities{Bounding: all}, 0, false, "5.4.0-150-generic", specerror.LintCapabilityKernel},
+		{rspec.LinuxCapabilities{Bounding: all}, 0, false, "6", specerror.NonRFCError},
+		{rspec.LinuxCapabilities{Bounding: all, Permitted: all, Inheritable: all, Ambient: all}, 1000, false, "", specerror.LintAmbientWithoutNoNewPrivileges},
+		{rspec.LinuxCapabilities{Bounding: all, Permitted: all, Inheritable: all, Ambient: all}, 1000, true, "", specerror.NonError},
+		{rspec.LinuxCapabilities{Bounding: all, Permitted: all, Inheritable: all, Ambient: all}, 0, false, "", specerror.NonError},
+	}
+	for _, c := range cases {
+		spec := rspec.Spec{Process: &rspec.Process{
+			User:            rspec.User{UID: c.uid},
+			Capabilities:    &c.caps,
+			NoNewPrivileges: c.noNewPrivileges,
+		}}
+		v, err := NewValidator(&spec, "", false, "linux")
+		if err != nil {
+			t.Fatalf("unexpected NewValidator error: %+v", err)
+		}
+		v.KernelVersion = c.kernel
+		err = v.CheckCapabilities()
+		if c.expected == specerror.NonRFCError {
+			assert.Error(t, err)
+			continue
+		}
+		assert.Equal(t, c.expected, specerror.FindError(err, c.expected), fmt.Sprintf("Fail to check capabilities %+v: %v %d", c.caps, err, c.expected))
+		if merr, ok := err.(*multierror.Error); ok && c.expected == specerror.NonError {
+			assert.NoError(t, merr.ErrorOrNil())
+		}
+	}
+}
+
+func TestEffectiveCapabilities(t *testing.T) {
+	caps := rspec.LinuxCapabilities{
+		Bounding:    []string{"CAP_KILL", "CAP_CHOWN", "CAP_NET_BIND_SERVICE"},
+		Permitted:   []string{"CAP_KILL", "CAP_NET_BIND_SERVICE"},
+		Inheritable: []string{"CAP_NET_BIND_SERVICE"},
+		Ambient:     []string{"CAP_NET_BIND_SERVICE"},
+	}
+	cases := []struct {
+		uid             uint32
+		noNewPrivileges bool
+		effective       []string
+	}{
+		{0, false, []string{"CAP_CHOWN", "CAP_KILL", "CAP_NET_BIND_SERVICE"}},
+		{0, true, []string{"CAP_KILL", "CAP_NET_BIND_SERVICE"}},
+		{1000, false, []string{"CAP_NET_BIND_SERVICE"}},
+	}
+	for _, c := range cases {
+		spec := rspec.Spec{Process: &rspec.Process{User: rspec.User{UID: c.uid}, Capabilities: &caps, NoNewPrivileges: c.noNewPrivileges}}
+		v, err := NewValidator(&spec, "", false, "linux")
+		if err != nil {
+			t.Fatalf("unexpected NewValidator error: %+v", err)
+		}
+		after := v.EffectiveCapabilities()
+		assert.Equal(t, c.effective, after.Effective, "uid %d", c.uid)
+		assert.Equal(t, c.effective, after.Permitted, "uid %d", c.uid)
+		assert.Equal(t, []string{"CAP_CHOWN", "CAP_KILL", "CAP_NET_BIND_SERVICE"}, after.Bounding)
+	}
+
+	v, err := NewValidator(&rspec.Spec{Process: &rspec.Process{}}, "", false, "linux")
+	if err != nil {
+		t.Fatalf("unexpected NewValidator error: %+v", err)
+	}
+	assert.Nil(t, v.EffectiveCapabilities())
+}
+
+func TestCheckApparmorProfileInRootfs(t *testing.T) {
+	bundle := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(bundle, "rootfs/etc/apparmor.d"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(bundle, "rootfs/etc/apparmor.d/acme"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range []struct {
+		profile  string
+		expected specerror.Code
+	}{
+		{"", specerror.NonError},
+		{"unconfined", specerror.NonError},
+		{"acme", specerror.NonError},
+		{"missing", specerror.LintApparmorProfileNotFound},
+	} {
+		spec := &rspec.Spec{
+			Root:    &rspec.Root{Path: "rootfs"},
+			Process: &rspec.Process{ApparmorProfile: c.profile},
+		}
+		v, err := NewValidator(spec, bundle, false, "linux")
+		if err != nil {
+			t.Fatalf("unexpected NewValidator error: %+v", err)
+		}
+		err = v.CheckApparmorProfileInRootfs()
+		assert.Equal(t, c.expected, specerror.FindError(err, c.expected), fmt.Sprintf("Fail to check apparmor profile %q: %v %d", c.profile, err, c.expected))
+		if merr, ok := err.(*multierror.Error); ok && c.expected == specerror.NonError {
+			assert.NoError(t, merr.ErrorOrNil())
+		}
+	}
+}
+
+func TestCheckProcessApparmorRootfs(t *testing.T) {
+	spec := &rspec.Spec{
+		Version: "1.0.0",
+		Root:    &rspec.Root{Path: "rootfs"},
+		Process: &rspec.Process{
+			Args:            []string{"sh"},
+			Cwd:             "/",
+			ApparmorProfile: "missing",
+		},
+	}
+	v, err := NewValidator(spec, t.TempDir(), false, "linux")
+	if err != nil {
+		t.Fatalf("unexpected NewValidator error: %+v", err)
+	}
+	assert.NotEqual(t, specerror.LintApparmorProfileNotFound, specerror.FindError(v.CheckProcess(), specerror.LintApparmorProfileNotFound))
+
+	v.ApparmorRootfs = true
+	assert.Equal(t, specerror.LintApparmorProfileNotFound, specerror.FindError(v.CheckProcess(), specerror.LintApparmorProfileNotFound))
+}
+
+func TestReadApparmorProfiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "profiles")
+	content := "docker-default (enforce)\n/usr/bin/man (complain)\nsnap.lxd (unconfined)\n\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	profiles, err := readApparmorProfiles(path)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"docker-default": "enforce",
+		"/usr/bin/man":   "complain",
+		"snap.lxd":       "unconfined",
+	}, profiles)
+}
+
+func TestCheckSelinuxLabel(t *testing.T) {
+	for _, c := range []struct {
+		label    string
+		expected specerror.Code
+	}{
+		{"", specerror.NonError},
+		{"system_u:system_r:container_t", specerror.NonError},
+		{"system_u:system_r:container_t:s0", specerror.NonError},
+		{"system_u:system_r:container_t:s0:c715,c811", specerror.NonError},
+		{"system_u:system_r:svirt_lxc_net_t:s0-s0:c0.c1023", specerror.NonError},
+		{"container_t", specerror.LintSelinuxLabelInvalid},
+		{"system_u:system_r", specerror.LintSelinuxLabelInvalid},
+		{"system_u::container_t", specerror.LintSelinuxLabelInvalid},
+		{"system_u:system_r:container t", specerror.LintSelinuxLabelInvalid},
+		{"system_u:system_r:container_t:", specerror.LintSelinuxLabelInvalid},
+	} {
+		spec := &rspec.Spec{
+			Process: &rspec.Process{SelinuxLabel: c.label},
+		}
+		v, err := NewValidator(spec, ".", false, "linux")
+		if err != nil {
+			t.Fatalf("unexpected NewValidator error: %+v", err)
+		}
+		err = v.CheckSelinuxLabel()
+		assert.Equal(t, c.expected, specerror.FindError(err, c.expected), fmt.Sprintf("Fail to check selinux label %q: %v %d", c.label, err, c.expected))
+		if merr, ok := err.(*multierror.Error); ok && c.expected == specerror.NonError {
+			assert.NoError(t, merr.ErrorOrNil())
+		}
 	}
 }
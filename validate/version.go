@@ -0,0 +1,237 @@
+package validate
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/blang/semver/v4"
+	"github.com/hashicorp/go-multierror"
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/opencontainers/runtime-tools/specerror"
+	"github.com/sirupsen/logrus"
+)
+
+// specReleases are the runtime-spec releases whose rules validate knows,
+// in ascending order.  The last one must be the vendored rspec.Version.
+var specReleases = []string{"1.0.0", "1.0.1", "1.0.2", "1.1.0", "1.2.0", "1.2.1", "1.3.0"}
+
+// versionedField is a configuration property introduced after 1.0.0.
+type versionedField struct {
+	// since is the first release defining the property.
+	since string
+	// path locates the property in the configuration.
+	path string
+	// set reports whether the configuration uses the property.
+	set func(spec *rspec.Spec) bool
+}
+
+var versionedFields = []versionedField{
+	{"1.0.2", "process.commandLine", func(spec *rspec.Spec) bool {
+		return spec.Process != nil && spec.Process.CommandLine != ""
+	}},
+	{"1.0.2", "process.user.umask", func(spec *rspec.Spec) bool {
+		return spec.Process != nil && spec.Process.User.Umask != nil
+	}},
+	{"1.0.2", "hooks.createRuntime", func(spec *rspec.Spec) bool {
+		return spec.Hooks != nil && len(spec.Hooks.CreateRuntime) > 0
+	}},
+	{"1.0.2", "hooks.createContainer", func(spec *rspec.Spec) bool {
+		return spec.Hooks != nil && len(spec.Hooks.CreateContainer) > 0
+	}},
+	{"1.0.2", "hooks.startContainer", func(spec *rspec.Spec) bool {
+		return spec.Hooks != nil && len(spec.Hooks.StartContainer) > 0
+	}},
+	{"1.0.2", "linux.resources.memory.useHierarchy", func(spec *rspec.Spec) bool {
+		return linuxMemory(spec) != nil && linuxMemory(spec).UseHierarchy != nil
+	}},
+	{"1.0.2", "linux.resources.rdma", func(spec *rspec.Spec) bool {
+		return spec.Linux != nil && spec.Linux.Resources != nil && len(spec.Linux.Resources.Rdma) > 0
+	}},
+	{"1.0.2", "linux.seccomp.flags", func(spec *rspec.Spec) bool {
+		return spec.Linux != nil && spec.Linux.Seccomp != nil && len(spec.Linux.Seccomp.Flags) > 0
+	}},
+	{"1.0.2", "linux.intelRdt.closID", func(spec *rspec.Spec) bool {
+		return spec.Linux != nil && spec.Linux.IntelRdt != nil && spec.Linux.IntelRdt.ClosID != ""
+	}},
+	{"1.0.2", "linux.intelRdt.memBwSchema", func(spec *rspec.Spec) bool {
+		return spec.Linux != nil && spec.Linux.IntelRdt != nil && spec.Linux.IntelRdt.MemBwSchema != ""
+	}},
+	{"1.0.2", "linux.personality", func(spec *rspec.Spec) bool {
+		return spec.Linux != nil && spec.Linux.Personality != nil
+	}},
+	{"1.0.2", "windows.devices", func(spec *rspec.Spec) bool {
+		return spec.Windows != nil && len(spec.Windows.Devices) > 0
+	}},
+	{"1.0.2", "windows.network.networkNamespace", func(spec *rspec.Spec) bool {
+		return spec.Windows != nil && spec.Windows.Network != nil && spec.Windows.Network.NetworkNamespace != ""
+	}},
+	{"1.0.2", "vm", func(spec *rspec.Spec) bool {
+		return spec.VM != nil
+	}},
+	{"1.1.0", "domainname", func(spec *rspec.Spec) bool {
+		return spec.Domainname != ""
+	}},
+	{"1.1.0", "process.scheduler", func(spec *rspec.Spec) bool {
+		return spec.Process != nil && spec.Process.Scheduler != nil
+	}},
+	{"1.1.0", "process.ioPriority", func(spec *rspec.Spec) bool {
+		return spec.Process != nil && spec.Process.IOPriority != nil
+	}},
+	{"1.1.0", "mounts[].uidMappings", func(spec *rspec.Spec) bool {
+		return slices.ContainsFunc(spec.Mounts, func(m rspec.Mount) bool { return len(m.UIDMappings) > 0 })
+	}},
+	{"1.1.0", "mounts[].gidMappings", func(spec *rspec.Spec) bool {
+		return slices.ContainsFunc(spec.Mounts, func(m rspec.Mount) bool { return len(m.GIDMappings) > 0 })
+	}},
+	{"1.1.0", "linux.namespaces[].type \"time\"", func(spec *rspec.Spec) bool {
+		return spec.Linux != nil && slices.ContainsFunc(spec.Linux.Namespaces, func(ns rspec.LinuxNamespace) bool {
+			return ns.Type == rspec.TimeNamespace
+		})
+	}},
+	{"1.1.0", "linux.timeOffsets", func(spec *rspec.Spec) bool {
+		return spec.Linux != nil && len(spec.Linux.TimeOffsets) > 0
+	}},
+	{"1.1.0", "linux.resources.unified", func(spec *rspec.Spec) bool {
+		return spec.Linux != nil && spec.Linux.Resources != nil && len(spec.Linux.Resources.Unified) > 0
+	}},
+	{"1.1.0", "linux.resources.cpu.burst", func(spec *rspec.Spec) bool {
+		return linuxCPU(spec) != nil && linuxCPU(spec).Burst != nil
+	}},
+	{"1.1.0", "linux.resources.cpu.idle", func(spec *rspec.Spec) bool {
+		return linuxCPU(spec) != nil && linuxCPU(spec).Idle != nil
+	}},
+	{"1.1.0", "linux.resources.memory.checkBeforeUpdate", func(spec *rspec.Spec) bool {
+		return linuxMemory(spec) != nil && linuxMemory(spec).CheckBeforeUpdate != nil
+	}},
+	{"1.1.0", "linux.seccomp.defaultErrnoRet", func(spec *rspec.Spec) bool {
+		return spec.Linux != nil && spec.Linux.Seccomp != nil && spec.Linux.Seccomp.DefaultErrnoRet != nil
+	}},
+	{"1.1.0", "linux.seccomp.listenerPath", func(spec *rspec.Spec) bool {
+		return spec.Linux != nil && spec.Linux.Seccomp != nil && spec.Linux.Seccomp.ListenerPath != ""
+	}},
+	{"1.1.0", "linux.seccomp.listenerMetadata", func(spec *rspec.Spec) bool {
+		return spec.Linux != nil && spec.Linux.Seccomp != nil && spec.Linux.Seccomp.ListenerMetadata != ""
+	}},
+	{"1.1.0", "linux.seccomp.syscalls[].errnoRet", func(spec *rspec.Spec) bool {
+		return spec.Linux != nil && spec.Linux.Seccomp != nil && slices.ContainsFunc(spec.Linux.Seccomp.Syscalls, func(s rspec.LinuxSyscall) bool {
+			return s.ErrnoRet != nil
+		})
+	}},
+	{"1.1.0", "zos", func(spec *rspec.Spec) bool {
+		return spec.ZOS != nil
+	}},
+	{"1.2.0", "mounts[].options \"idmap\" and \"ridmap\"", func(spec *rspec.Spec) bool {
+		return slices.ContainsFunc(spec.Mounts, func(m rspec.Mount) bool {
+			return slices.Contains(m.Options, "idmap") || slices.Contains(m.Options, "ridmap")
+		})
+	}},
+	{"1.2.1", "process.execCPUAffinity", func(spec *rspec.Spec) bool {
+		return spec.Process != nil && spec.Process.ExecCPUAffinity != nil
+	}},
+	{"1.2.1", "windows.resources.cpu.affinity", func(spec *rspec.Spec) bool {
+		return spec.Windows != nil && spec.Windows.Resources != nil && spec.Windows.Resources.CPU != nil && len(spec.Windows.Resources.CPU.Affinity) > 0
+	}},
+	{"1.3.0", "linux.netDevices", func(spec *rspec.Spec) bool {
+		return spec.Linux != nil && len(spec.Linux.NetDevices) > 0
+	}},
+	{"1.3.0", "linux.memoryPolicy", func(spec *rspec.Spec) bool {
+		return spec.Linux != nil && spec.Linux.MemoryPolicy != nil
+	}},
+	{"1.3.0", "linux.intelRdt.schemata", func(spec *rspec.Spec) bool {
+		return spec.Linux != nil && spec.Linux.IntelRdt != nil && len(spec.Linux.IntelRdt.Schemata) > 0
+	}},
+	{"1.3.0", "linux.intelRdt.enableMonitoring", func(spec *rspec.Spec) bool {
+		return spec.Linux != nil && spec.Linux.IntelRdt != nil && spec.Linux.IntelRdt.EnableMonitoring
+	}},
+	{"1.3.0", "vm.hwConfig", func(spec *rspec.Spec) bool {
+		return spec.VM != nil && spec.VM.HwConfig != nil
+	}},
+	{"1.3.0", "freebsd", func(spec *rspec.Spec) bool {
+		return spec.FreeBSD != nil
+	}},
+}
+
+func linuxCPU(spec *rspec.Spec) *rspec.LinuxCPU {
+	if spec.Linux == nil || spec.Linux.Resources == nil {
+		return nil
+	}
+	return spec.Linux.Resources.CPU
+}
+
+func linuxMemory(spec *rspec.Spec) *rspec.LinuxMemory {
+	if spec.Linux == nil || spec.Linux.Resources == nil {
+		return nil
+	}
+	return spec.Linux.Resources.Memory
+}
+
+// parseSpecVersion parses an ociVersion.  The "-dev" suffix, which
+// runtime-spec used for development versions before 1.1.0, is read as
+// the release it follows.
+func parseSpecVersion(version string) (semver.Version, error) {
+	ver, err := semver.Parse(version)
+	if err != nil {
+		return ver, err
+	}
+	if len(ver.Pre) == 1 && ver.Pre[0].VersionStr == "dev" {
+		ver.Pre = nil
+	}
+	return ver, nil
+}
+
+// specRelease returns the latest release in specReleases which is not
+// newer than version, if version is a 1.x version.
+func specRelease(version semver.Version) (release string, ok bool) {
+	if version.Major != 1 {
+		return "", false
+	}
+	for i := len(specReleases) - 1; i >= 0; i-- {
+		if semver.MustParse(specReleases[i]).LTE(version) {
+			return specReleases[i], true
+		}
+	}
+	return "", false
+}
+
+// ruleVersion returns the runtime-spec release whose rules apply to the
+// configuration, which is also the version errors reference.
+// Configurations whose ociVersion is invalid or unsupported are checked
+// against rspec.Version.
+func (v *Validator) ruleVersion() string {
+	ver, err := parseSpecVersion(v.spec.Version)
+	if err != nil {
+		return rspec.Version
+	}
+	if release, ok := specRelease(ver); ok {
+		return release
+	}
+	return rspec.Version
+}
+
+// ruleAtLeast reports whether the rules of release, or of a later
+// release, apply to the configuration.
+func (v *Validator) ruleAtLeast(release string) bool {
+	return semver.MustParse(v.ruleVersion()).GTE(semver.MustParse(release))
+}
+
+// CheckVersionFields checks that the configuration only uses properties
+// defined by the runtime-spec release its ociVersion declares.
+func (v *Validator) CheckVersionFields() (errs error) {
+	logrus.Debugf("check version fields")
+
+	ver, err := parseSpecVersion(v.spec.Version)
+	if err != nil {
+		// CheckSemVer reports the invalid version.
+		return
+	}
+	for _, field := range versionedFields {
+		if ver.LT(semver.MustParse(field.since)) && field.set(v.spec) {
+			errs = multierror.Append(errs,
+				specerror.NewError(
+					specerror.LintFieldNotInVersion,
+					fmt.Errorf("%s was introduced in runtime-spec %s, but the configuration targets %s", field.path, field.since, v.spec.Version),
+					v.ruleVersion()))
+		}
+	}
+	return
+}
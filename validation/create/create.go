@@ -1,4 +1,4 @@
-package main
+package create
 
 import (
 	"fmt"
@@ -13,7 +13,11 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
-func main() {
+func init() {
+	util.RegisterTest("create", run)
+}
+
+func run() {
 	t := tap.New()
 	t.Header(0)
 
@@ -23,6 +27,9 @@ func main() {
 	}
 	g.SetRootPath(".")
 	g.SetProcessArgs([]string{"ls"})
+	if err := util.SetupRootless(&g); err != nil {
+		util.Fatal(err)
+	}
 
 	bundleDir, err := util.PrepareBundle()
 	if err != nil {
@@ -57,6 +64,7 @@ func main() {
 		t.Ok((err == nil) == c.errExpected, c.err.(*specerror.Error).Err.Err.Error())
 		diagnostic := map[string]string{
 			"reference": c.err.(*specerror.Error).Err.Reference,
+			"code":      c.err.(*specerror.Error).Code.String(),
 		}
 		if err != nil {
 			diagnostic["error"] = err.Error()
@@ -1,10 +1,14 @@
-package main
+package defaults
 
 import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
-func main() {
+func init() {
+	util.RegisterTest("default", run)
+}
+
+func run() {
 	g, err := util.GetDefaultGenerator()
 	if err != nil {
 		util.Fatal(err)
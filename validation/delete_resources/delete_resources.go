@@ -1,4 +1,4 @@
-package main
+package deleteresources
 
 import (
 	"fmt"
@@ -15,7 +15,15 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
-func main() {
+func init() {
+	util.RegisterTest("delete_resources", run)
+}
+
+func run() {
+	if util.SkipRootlessCgroups() {
+		return
+	}
+
 	t := tap.New()
 	t.Header(0)
 
@@ -1,4 +1,4 @@
-package main
+package hooks
 
 import (
 	"fmt"
@@ -14,7 +14,11 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
-func main() {
+func init() {
+	util.RegisterTest("hooks", run)
+}
+
+func run() {
 	t := tap.New()
 	t.Header(0)
 
@@ -1,4 +1,4 @@
-package main
+package hooksstdin
 
 import (
 	"encoding/json"
@@ -17,6 +17,10 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
+func init() {
+	util.RegisterTest("hooks_stdin", run)
+}
+
 func stdinStateCheck(outputDir, hookName string, expectedState rspecs.State) (errs *multierror.Error) {
 	var state rspecs.State
 	data, err := os.ReadFile(filepath.Join(outputDir, hookName))
@@ -74,7 +78,7 @@ func stdinStateCheck(outputDir, hookName string, expectedState rspecs.State) (er
 	return
 }
 
-func main() {
+func run() {
 	t := tap.New()
 	t.Header(0)
 
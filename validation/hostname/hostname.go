@@ -1,4 +1,4 @@
-package main
+package hostname
 
 import (
 	"fmt"
@@ -8,6 +8,10 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
+func init() {
+	util.RegisterTest("hostname", run)
+}
+
 func testHostname(t *tap.T, hostname string) error {
 	g, err := util.GetDefaultGenerator()
 	if err != nil {
@@ -25,7 +29,7 @@ func testHostname(t *tap.T, hostname string) error {
 	return nil
 }
 
-func main() {
+func run() {
 	t := tap.New()
 	t.Header(0)
 	defer t.AutoPlan()
@@ -1,4 +1,4 @@
-package main
+package killsig
 
 import (
 	"fmt"
@@ -13,13 +13,17 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
+func init() {
+	util.RegisterTest("killsig", run)
+}
+
 var signals = []string{
 	"TERM",
 	"USR1",
 	"USR2",
 }
 
-func main() {
+func run() {
 	t := tap.New()
 	t.Header(0)
 	bundleDir, err := util.PrepareBundle()
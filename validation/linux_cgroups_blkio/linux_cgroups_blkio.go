@@ -1,4 +1,4 @@
-package main
+package linuxcgroupsblkio
 
 import (
 	"fmt"
@@ -9,6 +9,10 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
+func init() {
+	util.RegisterTest("linux_cgroups_blkio", run)
+}
+
 func testBlkioCgroups(rate uint64, isEmpty bool) error {
 	var weight uint16 = 500
 	var leafWeight uint16 = 300
@@ -49,7 +53,11 @@ func testBlkioCgroups(rate uint64, isEmpty bool) error {
 	return nil
 }
 
-func main() {
+func run() {
+	if util.SkipRootlessCgroups() {
+		return
+	}
+
 	if runtime.GOOS != "linux" {
 		util.Fatal(fmt.Errorf("linux-specific cgroup test"))
 	}
@@ -1,4 +1,4 @@
-package main
+package linuxcgroupscpus
 
 import (
 	"fmt"
@@ -11,6 +11,10 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
+func init() {
+	util.RegisterTest("linux_cgroups_cpus", run)
+}
+
 const (
 	defaultRealtimePeriod  uint64 = 1000000
 	defaultRealtimeRuntime int64  = 950000
@@ -99,6 +103,38 @@ func testCPUCgroups() error {
 	return nil
 }
 
+func testCPUBurstIdle() error {
+	t := tap.New()
+	t.Header(0)
+	defer t.AutoPlan()
+
+	g, err := util.GetDefaultGenerator()
+	if err != nil {
+		return fmt.Errorf("cannot get default config from generator: %v", err)
+	}
+	g.SetLinuxCgroupsPath(cgroups.AbsCgroupPath)
+	g.SetLinuxResourcesCPUShares(1024)
+	g.SetLinuxResourcesCPUPeriod(100000)
+	g.SetLinuxResourcesCPUQuota(50000)
+	g.SetLinuxResourcesCPUCpus("0")
+	g.SetLinuxResourcesCPUMems("0")
+
+	// cpu.cfs_burst_us and cpu.idle need Linux 5.14 and 5.15 respectively.
+	if _, err := os.Stat(filepath.Join(util.CPUCgroupPrefix, "cpu.cfs_burst_us")); !os.IsNotExist(err) {
+		g.SetLinuxResourcesCPUBurst(20000)
+	}
+
+	if _, err := os.Stat(filepath.Join(util.CPUCgroupPrefix, "cpu.idle")); !os.IsNotExist(err) {
+		g.SetLinuxResourcesCPUIdle(1)
+	}
+
+	if err := util.RuntimeOutsideValidate(g, t, util.ValidateLinuxResourcesCPU); err != nil {
+		return fmt.Errorf("cannot validate CPU burst and idle cgroups: %v", err)
+	}
+
+	return nil
+}
+
 func testEmptyCPU() error {
 	t := tap.New()
 	t.Header(0)
@@ -118,7 +154,11 @@ func testEmptyCPU() error {
 	return nil
 }
 
-func main() {
+func run() {
+	if util.SkipRootlessCgroups() {
+		return
+	}
+
 	if runtime.GOOS != "linux" {
 		util.Fatal(fmt.Errorf("linux-specific cgroup test"))
 	}
@@ -127,6 +167,10 @@ func main() {
 		util.Fatal(err)
 	}
 
+	if err := testCPUBurstIdle(); err != nil {
+		util.Fatal(err)
+	}
+
 	if err := testEmptyCPU(); err != nil {
 		util.Fatal(err)
 	}
@@ -1,4 +1,4 @@
-package main
+package linuxcgroupsdevices
 
 import (
 	"github.com/mndrix/tap-go"
@@ -6,7 +6,15 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
-func main() {
+func init() {
+	util.RegisterTest("linux_cgroups_devices", run)
+}
+
+func run() {
+	if util.SkipRootlessCgroups() {
+		return
+	}
+
 	var major1, minor1, major2, minor2, major3, minor3 int64 = 10, 229, 8, 20, 10, 200
 
 	t := tap.New()
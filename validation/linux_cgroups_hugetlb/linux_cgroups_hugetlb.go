@@ -1,4 +1,4 @@
-package main
+package linuxcgroupshugetlb
 
 import (
 	"fmt"
@@ -10,6 +10,10 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
+func init() {
+	util.RegisterTest("linux_cgroups_hugetlb", run)
+}
+
 func testHugetlbCgroups() error {
 	t := tap.New()
 	t.Header(0)
@@ -87,7 +91,11 @@ func testWrongHugetlb() error {
 	return err
 }
 
-func main() {
+func run() {
+	if util.SkipRootlessCgroups() {
+		return
+	}
+
 	if runtime.GOOS != "linux" {
 		util.Fatal(fmt.Errorf("linux-specific cgroup test"))
 	}
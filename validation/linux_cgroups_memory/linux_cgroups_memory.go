@@ -1,4 +1,4 @@
-package main
+package linuxcgroupsmemory
 
 import (
 	"fmt"
@@ -9,7 +9,15 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
-func main() {
+func init() {
+	util.RegisterTest("linux_cgroups_memory", run)
+}
+
+func run() {
+	if util.SkipRootlessCgroups() {
+		return
+	}
+
 	if runtime.GOOS != "linux" {
 		util.Fatal(fmt.Errorf("linux-specific cgroup test"))
 	}
@@ -42,6 +50,7 @@ func main() {
 		g.SetLinuxResourcesMemoryKernelTCP(c.limit)
 		g.SetLinuxResourcesMemorySwappiness(c.swappiness)
 		g.SetLinuxResourcesMemoryDisableOOMKiller(true)
+		g.SetLinuxResourcesMemoryUseHierarchy(true)
 		err = util.RuntimeOutsideValidate(g, t, util.ValidateLinuxResourcesMemory)
 		if err != nil {
 			t.Fail(err.Error())
@@ -1,4 +1,4 @@
-package main
+package linuxcgroupsnetwork
 
 import (
 	"fmt"
@@ -10,6 +10,10 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
+func init() {
+	util.RegisterTest("linux_cgroups_network", run)
+}
+
 func testNetworkCgroups() error {
 	t := tap.New()
 	t.Header(0)
@@ -97,7 +101,11 @@ func testNetworkCgroups() error {
 	return nil
 }
 
-func main() {
+func run() {
+	if util.SkipRootlessCgroups() {
+		return
+	}
+
 	if runtime.GOOS != "linux" {
 		util.Fatal(fmt.Errorf("linux-specific cgroup test"))
 	}
@@ -0,0 +1,56 @@
+package linuxcgroupsrdma
+
+import (
+	"os"
+	"runtime"
+
+	"github.com/mndrix/tap-go"
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/opencontainers/runtime-tools/cgroups"
+	"github.com/opencontainers/runtime-tools/validation/util"
+)
+
+func init() {
+	util.RegisterTest("linux_cgroups_rdma", run)
+}
+
+func run() {
+	if runtime.GOOS != "linux" {
+		util.Skip("linux-specific cgroup test", map[string]string{"OS": runtime.GOOS})
+		return
+	}
+
+	devices, err := os.ReadDir("/sys/class/infiniband")
+	if err != nil || len(devices) == 0 {
+		diagnostic := map[string]string{"path": "/sys/class/infiniband"}
+		if err != nil {
+			diagnostic["error"] = err.Error()
+		}
+		util.Skip("no RDMA devices available", diagnostic)
+		return
+	}
+
+	var hcaHandles, hcaObjects uint32 = 3, 10000
+
+	if util.SkipRootlessCgroups() {
+		return
+	}
+
+	t := tap.New()
+	t.Header(0)
+	defer t.AutoPlan()
+
+	g, err := util.GetDefaultGenerator()
+	if err != nil {
+		util.Fatal(err)
+	}
+	g.SetLinuxCgroupsPath(cgroups.AbsCgroupPath)
+	g.AddLinuxResourcesRdma(devices[0].Name(), rspec.LinuxRdma{
+		HcaHandles: &hcaHandles,
+		HcaObjects: &hcaObjects,
+	})
+	err = util.RuntimeOutsideValidate(g, t, util.ValidateLinuxResourcesRdma)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+}
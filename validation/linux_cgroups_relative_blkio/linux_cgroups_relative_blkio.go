@@ -1,4 +1,4 @@
-package main
+package linuxcgroupsrelativeblkio
 
 import (
 	"github.com/mndrix/tap-go"
@@ -6,7 +6,15 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
-func main() {
+func init() {
+	util.RegisterTest("linux_cgroups_relative_blkio", run)
+}
+
+func run() {
+	if util.SkipRootlessCgroups() {
+		return
+	}
+
 	var weight uint16 = 500
 	var leafWeight uint16 = 300
 	var major, minor int64 = 8, 0
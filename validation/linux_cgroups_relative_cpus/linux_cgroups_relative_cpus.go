@@ -1,4 +1,4 @@
-package main
+package linuxcgroupsrelativecpus
 
 import (
 	"github.com/mndrix/tap-go"
@@ -7,7 +7,15 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
-func main() {
+func init() {
+	util.RegisterTest("linux_cgroups_relative_cpus", run)
+}
+
+func run() {
+	if util.SkipRootlessCgroups() {
+		return
+	}
+
 	const (
 		shares     uint64 = 1024
 		period     uint64 = 100000
@@ -1,4 +1,4 @@
-package main
+package linuxcgroupsrelativehugetlb
 
 import (
 	"fmt"
@@ -9,7 +9,15 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
-func main() {
+func init() {
+	util.RegisterTest("linux_cgroups_relative_hugetlb", run)
+}
+
+func run() {
+	if util.SkipRootlessCgroups() {
+		return
+	}
+
 	t := tap.New()
 	t.Header(0)
 	defer t.AutoPlan()
@@ -1,4 +1,4 @@
-package main
+package linuxcgroupsrelativememory
 
 import (
 	"github.com/mndrix/tap-go"
@@ -6,7 +6,15 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
-func main() {
+func init() {
+	util.RegisterTest("linux_cgroups_relative_memory", run)
+}
+
+func run() {
+	if util.SkipRootlessCgroups() {
+		return
+	}
+
 	var limit int64 = 50593792
 	var swappiness uint64 = 50
 
@@ -1,4 +1,4 @@
-package main
+package linuxdevices
 
 import (
 	"os"
@@ -7,7 +7,15 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
-func main() {
+func init() {
+	util.RegisterTest("linux_devices", run)
+}
+
+func run() {
+	if util.SkipRootless("creating device nodes needs CAP_MKNOD on the host") {
+		return
+	}
+
 	g, err := util.GetDefaultGenerator()
 	if err != nil {
 		util.Fatal(err)
@@ -0,0 +1,71 @@
+package linuxintelrdt
+
+import (
+	"runtime"
+	"strings"
+
+	"github.com/mndrix/tap-go"
+	"github.com/opencontainers/runtime-tools/resctrl"
+	"github.com/opencontainers/runtime-tools/validation/util"
+)
+
+func init() {
+	util.RegisterTest("linux_intelrdt", run)
+}
+
+func run() {
+	if util.SkipRootless("resctrl groups can only be created by root") {
+		return
+	}
+
+	if runtime.GOOS != "linux" {
+		util.Skip("linux-specific intelRdt test", map[string]string{"OS": runtime.GOOS})
+		return
+	}
+
+	rc, err := resctrl.FindResctrl()
+	if err != nil {
+		util.Skip("resctrl is not mounted", map[string]string{"error": err.Error()})
+		return
+	}
+
+	// Reuse the allocations of the default group, which are valid for
+	// every cache domain of the host.
+	schemas, err := rc.GetSchemata("")
+	if err != nil {
+		util.Fatal(err)
+	}
+	var l3, mb []string
+	for _, schema := range schemas {
+		switch schema.Resource {
+		case "L3":
+			l3 = append(l3, schema.String())
+		case "MB":
+			mb = append(mb, schema.String())
+		}
+	}
+	if len(l3) == 0 && len(mb) == 0 {
+		util.Skip("host supports neither L3 cache allocation nor memory bandwidth allocation", map[string]any{"schemata": schemas})
+		return
+	}
+
+	t := tap.New()
+	t.Header(0)
+	defer t.AutoPlan()
+
+	g, err := util.GetDefaultGenerator()
+	if err != nil {
+		util.Fatal(err)
+	}
+	g.SetLinuxIntelRdtClosID("ocitest")
+	if len(l3) > 0 {
+		g.SetLinuxIntelRdtL3CacheSchema(strings.Join(l3, "\n"))
+	}
+	if len(mb) > 0 {
+		g.SetLinuxIntelRdtMemBwSchema(strings.Join(mb, "\n"))
+	}
+	err = util.RuntimeOutsideValidate(g, t, util.ValidateLinuxIntelRdt)
+	if err != nil {
+		t.Fail(err.Error())
+	}
+}
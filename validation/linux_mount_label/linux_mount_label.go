@@ -1,10 +1,14 @@
-package main
+package linuxmountlabel
 
 import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
-func main() {
+func init() {
+	util.RegisterTest("linux_mount_label", run)
+}
+
+func run() {
 	g, err := util.GetDefaultGenerator()
 	if err != nil {
 		util.Fatal(err)
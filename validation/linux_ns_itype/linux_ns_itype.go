@@ -1,4 +1,4 @@
-package main
+package linuxnsitype
 
 import (
 	"fmt"
@@ -12,6 +12,10 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
+func init() {
+	util.RegisterTest("linux_ns_itype", run)
+}
+
 func printDiag(t *tap.T, diagActual, diagExpected, diagNsType string, errNs error) {
 	specErr := specerror.NewError(specerror.NSInheritWithoutType,
 		errNs, rspec.Version)
@@ -21,6 +25,7 @@ func printDiag(t *tap.T, diagActual, diagExpected, diagNsType string, errNs erro
 		"namespace type": diagNsType,
 		"level":          specErr.(*specerror.Error).Err.Level.String(),
 		"reference":      specErr.(*specerror.Error).Err.Reference,
+		"code":           specErr.(*specerror.Error).Code.String(),
 	}
 	_ = t.YAML(diagnostic)
 }
@@ -114,7 +119,7 @@ func testNamespaceInheritType(t *tap.T) error {
 	return errNs
 }
 
-func main() {
+func run() {
 	t := tap.New()
 	t.Header(0)
 
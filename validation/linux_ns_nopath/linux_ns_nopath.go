@@ -1,4 +1,4 @@
-package main
+package linuxnsnopath
 
 import (
 	"fmt"
@@ -12,6 +12,10 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
+func init() {
+	util.RegisterTest("linux_ns_nopath", run)
+}
+
 func printDiag(t *tap.T, diagActual, diagExpected, diagNsType string, errNs error) {
 	specErr := specerror.NewError(specerror.NSNewNSWithoutPath,
 		errNs, rspec.Version)
@@ -21,6 +25,7 @@ func printDiag(t *tap.T, diagActual, diagExpected, diagNsType string, errNs erro
 		"namespace type": diagNsType,
 		"level":          specErr.(*specerror.Error).Err.Level.String(),
 		"reference":      specErr.(*specerror.Error).Err.Reference,
+		"code":           specErr.(*specerror.Error).Code.String(),
 	}
 	_ = t.YAML(diagnostic)
 }
@@ -115,7 +120,7 @@ func testNamespaceNoPath(t *tap.T) error {
 	return errNs
 }
 
-func main() {
+func run() {
 	t := tap.New()
 	t.Header(0)
 
@@ -1,4 +1,4 @@
-package main
+package linuxnspath
 
 import (
 	"fmt"
@@ -14,8 +14,12 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
+func init() {
+	util.RegisterTest("linux_ns_path", run)
+}
+
 func waitForState(stateCheckFunc func() error) error {
-	timeout := 3 * time.Second
+	timeout := util.ScaleTimeout(3 * time.Second)
 	alarm := time.After(timeout)
 	ticker := time.Tick(200 * time.Millisecond)
 	for {
@@ -132,7 +136,11 @@ func testNamespacePath(t *tap.T, ns string, unshareOpt string) error {
 	return checkNamespacePath(t, cmd.Process.Pid, ns)
 }
 
-func main() {
+func run() {
+	if util.SkipRootless("the namespaces to join are created on the host with unshare") {
+		return
+	}
+
 	t := tap.New()
 	t.Header(0)
 
@@ -165,6 +173,7 @@ func main() {
 				"namespace type": c.name,
 				"level":          rfcError.Level.String(),
 				"reference":      rfcError.Reference,
+				"code":           specerror.NSProcInPath.String(),
 			}
 			_ = t.YAML(diagnostic)
 		}
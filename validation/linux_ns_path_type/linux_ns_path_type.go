@@ -1,4 +1,4 @@
-package main
+package linuxnspathtype
 
 import (
 	"fmt"
@@ -12,6 +12,10 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
+func init() {
+	util.RegisterTest("linux_ns_path_type", run)
+}
+
 func checkNSPathMatchType(t *tap.T, ns, wrongNs string) error {
 	// Deliberately set ns path with a wrong namespace, to check if the runtime
 	// returns error when running with the wrong namespace path.
@@ -41,6 +45,7 @@ func checkNSPathMatchType(t *tap.T, ns, wrongNs string) error {
 			"namespace type": rtns,
 			"level":          rfcError.Level.String(),
 			"reference":      rfcError.Reference,
+			"code":           specerror.NSPathMatchTypeError.String(),
 		}
 		_ = t.YAML(diagnostic)
 
@@ -74,7 +79,7 @@ func testNSPathMatchType(t *tap.T, ns, unshareOpt, wrongNs string) error {
 	return checkNSPathMatchType(t, ns, wrongNs)
 }
 
-func main() {
+func run() {
 	t := tap.New()
 	t.Header(0)
 
@@ -1,10 +1,14 @@
-package main
+package linuxprocessapparmorprofile
 
 import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
-func main() {
+func init() {
+	util.RegisterTest("linux_process_apparmor_profile", run)
+}
+
+func run() {
 	g, err := util.GetDefaultGenerator()
 	if err != nil {
 		util.Fatal(err)
@@ -1,4 +1,4 @@
-package main
+package linuxreadonlypaths
 
 import (
 	"fmt"
@@ -10,6 +10,10 @@ import (
 	"golang.org/x/sys/unix"
 )
 
+func init() {
+	util.RegisterTest("linux_readonly_paths", run)
+}
+
 func checkReadonlyPaths(t *tap.T) error {
 	g, err := util.GetDefaultGenerator()
 	if err != nil {
@@ -149,7 +153,7 @@ func checkReadonlyDeviceNodes(t *tap.T, mode uint32) error {
 	})
 }
 
-func main() {
+func run() {
 	t := tap.New()
 	t.Header(0)
 	defer t.AutoPlan()
@@ -1,10 +1,14 @@
-package main
+package linuxrootfspropagation
 
 import (
 	"github.com/mndrix/tap-go"
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
+func init() {
+	util.RegisterTest("linux_rootfs_propagation", run)
+}
+
 func testLinuxRootPropagation(t *tap.T, propMode string) error {
 	g, err := util.GetDefaultGenerator()
 	if err != nil {
@@ -20,7 +24,7 @@ func testLinuxRootPropagation(t *tap.T, propMode string) error {
 	return util.RuntimeInsideValidate(g, t, nil)
 }
 
-func main() {
+func run() {
 	t := tap.New()
 	t.Header(0)
 	defer t.AutoPlan()
@@ -1,4 +1,4 @@
-package main
+package linuxseccomp
 
 import (
 	tap "github.com/mndrix/tap-go"
@@ -6,7 +6,11 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
-func main() {
+func init() {
+	util.RegisterTest("linux_seccomp", run)
+}
+
+func run() {
 	t := tap.New()
 	t.Header(0)
 	defer t.AutoPlan()
@@ -0,0 +1,39 @@
+package linuxtimeoffsets
+
+import (
+	"strconv"
+
+	rspecs "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/opencontainers/runtime-tools/validation/util"
+	"golang.org/x/sys/unix"
+)
+
+func init() {
+	util.RegisterTest("linux_time_offsets", run)
+}
+
+func run() {
+	g, err := util.GetDefaultGenerator()
+	if err != nil {
+		util.Fatal(err)
+	}
+	g.AddOrReplaceLinuxNamespace("time", "")
+	g.SetLinuxTimeOffset("monotonic", rspecs.LinuxTimeOffset{Secs: 31536000, Nanosecs: 500})
+	g.SetLinuxTimeOffset("boottime", rspecs.LinuxTimeOffset{Secs: 63072000, Nanosecs: 1000})
+	err = util.RuntimeInsideValidate(g, nil, func(path string) error {
+		for name, clockID := range map[string]int32{
+			"MONOTONIC": unix.CLOCK_MONOTONIC,
+			"BOOTTIME":  unix.CLOCK_BOOTTIME,
+		} {
+			var ts unix.Timespec
+			if err := unix.ClockGettime(clockID, &ts); err != nil {
+				return err
+			}
+			g.AddProcessEnv(util.TimeOffsetHostClockEnv+name, strconv.FormatInt(ts.Nano(), 10))
+		}
+		return nil
+	})
+	if err != nil {
+		util.Fatal(err)
+	}
+}
@@ -1,10 +1,18 @@
-package main
+package linuxuidmappings
 
 import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
-func main() {
+func init() {
+	util.RegisterTest("linux_uid_mappings", run)
+}
+
+func run() {
+	if util.SkipRootless("mapping arbitrary host IDs needs CAP_SETUID and CAP_SETGID on the host") {
+		return
+	}
+
 	g, err := util.GetDefaultGenerator()
 	if err != nil {
 		util.Fatal(err)
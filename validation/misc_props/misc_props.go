@@ -1,4 +1,4 @@
-package main
+package miscprops
 
 import (
 	"encoding/json"
@@ -14,6 +14,10 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
+func init() {
+	util.RegisterTest("misc_props", run)
+}
+
 func saveConfig(path string, v any) error {
 	data, err := json.Marshal(v)
 	if err != nil {
@@ -23,7 +27,7 @@ func saveConfig(path string, v any) error {
 	return os.WriteFile(path, data, 0o644)
 }
 
-func main() {
+func run() {
 	t := tap.New()
 	t.Header(0)
 	bundleDir, err := util.PrepareBundle()
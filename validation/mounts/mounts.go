@@ -1,11 +1,15 @@
-package main
+package mounts
 
 import (
 	rspec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
-func main() {
+func init() {
+	util.RegisterTest("mounts", run)
+}
+
+func run() {
 	defaultOptions := []string{
 		"nosuid",
 		"strictatime",
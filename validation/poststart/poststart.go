@@ -1,4 +1,4 @@
-package main
+package poststart
 
 import (
 	"fmt"
@@ -15,7 +15,11 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
-func main() {
+func init() {
+	util.RegisterTest("poststart", run)
+}
+
+func run() {
 	t := tap.New()
 	t.Header(0)
 
@@ -1,4 +1,4 @@
-package main
+package poststartfail
 
 import (
 	"fmt"
@@ -13,7 +13,11 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
-func main() {
+func init() {
+	util.RegisterTest("poststart_fail", run)
+}
+
+func run() {
 	t := tap.New()
 	t.Header(0)
 
@@ -1,4 +1,4 @@
-package main
+package poststop
 
 import (
 	"errors"
@@ -16,7 +16,11 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
-func main() {
+func init() {
+	util.RegisterTest("poststop", run)
+}
+
+func run() {
 	t := tap.New()
 	t.Header(0)
 
@@ -1,4 +1,4 @@
-package main
+package prestartfail
 
 import (
 	"fmt"
@@ -12,7 +12,11 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
-func main() {
+func init() {
+	util.RegisterTest("prestart_fail", run)
+}
+
+func run() {
 	t := tap.New()
 	t.Header(0)
 
@@ -1,4 +1,4 @@
-package main
+package process
 
 import (
 	"os"
@@ -7,7 +7,11 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
-func main() {
+func init() {
+	util.RegisterTest("process", run)
+}
+
+func run() {
 	g, err := util.GetDefaultGenerator()
 	if err != nil {
 		util.Fatal(err)
@@ -1,4 +1,4 @@
-package main
+package processcapabilitiesfail
 
 import (
 	"fmt"
@@ -10,7 +10,11 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
-func main() {
+func init() {
+	util.RegisterTest("process_capabilities_fail", run)
+}
+
+func run() {
 	if runtime.GOOS != "linux" {
 		util.Skip("linux-specific process.capabilities test", map[string]string{"OS": runtime.GOOS})
 		os.Exit(0)
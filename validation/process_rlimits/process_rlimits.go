@@ -1,4 +1,4 @@
-package main
+package processrlimits
 
 import (
 	"os"
@@ -7,7 +7,11 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
-func main() {
+func init() {
+	util.RegisterTest("process_rlimits", run)
+}
+
+func run() {
 	if runtime.GOOS != "linux" && runtime.GOOS != "solaris" {
 		util.Skip("POSIX-specific process.rlimits test", map[string]string{"OS": runtime.GOOS})
 		os.Exit(0)
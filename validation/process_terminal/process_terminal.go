@@ -0,0 +1,134 @@
+package processterminal
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mndrix/tap-go"
+	"github.com/mrunalp/fileutils"
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/opencontainers/runtime-tools/validation/util"
+	"golang.org/x/sys/unix"
+)
+
+func init() {
+	util.RegisterTest("process_terminal", run)
+}
+
+func run() {
+	t := tap.New()
+	t.Header(0)
+	defer t.AutoPlan()
+
+	bundleDir, err := util.PrepareBundle()
+	if err != nil {
+		util.Fatal(err)
+	}
+
+	g, err := util.GetDefaultGenerator()
+	if err != nil {
+		util.Fatal(err)
+	}
+	consoleSize := rspec.Box{Height: 30, Width: 100}
+	g.SetProcessTerminal(true)
+	g.SetProcessConsoleSize(consoleSize.Width, consoleSize.Height)
+
+	consoleSocket, err := util.NewConsoleSocket()
+	if err != nil {
+		util.Fatal(err)
+	}
+	defer consoleSocket.Close()
+
+	r, err := util.NewRuntime(util.RuntimeCommand, bundleDir)
+	if err != nil {
+		os.RemoveAll(bundleDir)
+		util.Fatal(err)
+	}
+	defer r.Clean()
+	r.ConsoleSocket = consoleSocket.Path
+	if err := r.SetConfig(g); err != nil {
+		util.Fatal(err)
+	}
+	if err := fileutils.CopyFile("runtimetest", filepath.Join(bundleDir, "runtimetest")); err != nil {
+		util.Fatal(err)
+	}
+	r.SetID(uuid.NewString())
+
+	type received struct {
+		master *os.File
+		err    error
+	}
+	receiving := make(chan received, 1)
+	go func() {
+		master, err := consoleSocket.ReceiveTerminal(r.ID, util.ScaleTimeout(10*time.Second))
+		receiving <- received{master, err}
+	}()
+
+	if err := r.Create(); err != nil {
+		util.Fatal(err)
+	}
+	terminal := <-receiving
+	t.Ok(terminal.err == nil, "runtime sends a valid terminal request to the console socket")
+	if terminal.err != nil {
+		_ = t.YAML(map[string]string{
+			"error": terminal.err.Error(),
+		})
+		return
+	}
+	master := terminal.master
+	defer master.Close()
+
+	ws, err := unix.IoctlGetWinsize(int(master.Fd()), unix.TIOCGWINSZ)
+	if err != nil {
+		t.Fail("pseudoterminal has the console size")
+		_ = t.YAML(map[string]string{
+			"error": err.Error(),
+		})
+	} else {
+		t.Ok(uint(ws.Row) == consoleSize.Height && uint(ws.Col) == consoleSize.Width, "pseudoterminal has the console size")
+		_ = t.YAML(map[string]any{
+			"expected": consoleSize,
+			"actual": map[string]uint16{
+				"height": ws.Row,
+				"width":  ws.Col,
+			},
+		})
+	}
+
+	// The container writes its TAP to the pseudoterminal; reading from
+	// the master fails with EIO once the container has exited.
+	var output bytes.Buffer
+	copied := make(chan struct{})
+	go func() {
+		_, _ = io.Copy(&output, master)
+		close(copied)
+	}()
+
+	if err := r.Start(); err != nil {
+		util.Fatal(err)
+	}
+	err = util.WaitingForStatus(r, util.LifecycleStatusStopped, 10*time.Second, 1*time.Second)
+	if err == nil {
+		select {
+		case <-copied:
+		case <-time.After(util.ScaleTimeout(5 * time.Second)):
+		}
+	}
+
+	// The terminal translates "\n" into "\r\n".
+	stdout := strings.ReplaceAll(output.String(), "\r", "")
+	diagnostic := map[string]string{
+		"stdout": stdout,
+	}
+	if err != nil {
+		diagnostic["error"] = fmt.Sprintf("%v", err)
+	}
+	_ = t.YAML(diagnostic)
+	t.Ok(err == nil && util.TAPPassed(util.ParseTAP(strings.NewReader(stdout))), "runtimetest passes with a terminal")
+}
@@ -1,4 +1,4 @@
-package main
+package processuser
 
 import (
 	"runtime"
@@ -6,7 +6,11 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
-func main() {
+func init() {
+	util.RegisterTest("process_user", run)
+}
+
+func run() {
 	g, err := util.GetDefaultGenerator()
 	if err != nil {
 		util.Fatal(err)
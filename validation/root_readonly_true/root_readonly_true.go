@@ -1,4 +1,4 @@
-package main
+package rootreadonlytrue
 
 import (
 	"os"
@@ -7,7 +7,11 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
-func main() {
+func init() {
+	util.RegisterTest("root_readonly_true", run)
+}
+
+func run() {
 	if runtime.GOOS == "windows" {
 		util.Skip("non-Windows root.readonly test", map[string]string{"OS": runtime.GOOS})
 		os.Exit(0)
@@ -1,4 +1,4 @@
-package main
+package state
 
 import (
 	"fmt"
@@ -13,7 +13,11 @@ import (
 	"github.com/opencontainers/runtime-tools/validation/util"
 )
 
-func main() {
+func init() {
+	util.RegisterTest("state", run)
+}
+
+func run() {
 	t := tap.New()
 	t.Header(0)
 
@@ -55,6 +59,7 @@ func main() {
 		if e, ok := err.(*specerror.Error); ok {
 			diagnostic := map[string]string{
 				"reference": e.Err.Reference,
+				"code":      e.Code.String(),
 				"error":     e.Err.Error(),
 			}
 			_ = t.YAML(diagnostic)
@@ -0,0 +1,154 @@
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/opencontainers/runtime-tools/api/socket"
+	"github.com/opencontainers/runtime-tools/schema"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// ConsoleSocket is a server for the console socket described in the OCI
+// Runtime Command Line Interface.  Pass its Path to the runtime with
+// --console-socket (see Runtime.ConsoleSocket) and call ReceiveTerminal
+// to get the pseudoterminal master.
+type ConsoleSocket struct {
+	Path     string
+	dir      string
+	listener *net.UnixListener
+}
+
+// NewConsoleSocket creates a console socket in a new temporary directory.
+func NewConsoleSocket() (*ConsoleSocket, error) {
+	dir, err := os.MkdirTemp("", "ocitest-console")
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, "console.sock")
+	listener, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	return &ConsoleSocket{Path: path, dir: dir, listener: listener}, nil
+}
+
+// Close stops listening and removes the socket.
+func (s *ConsoleSocket) Close() error {
+	err := s.listener.Close()
+	if err2 := os.RemoveAll(s.dir); err == nil {
+		err = err2
+	}
+	return err
+}
+
+// ReceiveTerminal waits up to timeout for the runtime to connect and send
+// a terminal request for containerID.  The request is validated against
+// the terminal request schema, and a success or error response is sent
+// back.  It returns the pseudoterminal master passed with the request.
+func (s *ConsoleSocket) ReceiveTerminal(containerID string, timeout time.Duration) (*os.File, error) {
+	if err := s.listener.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+	conn, err := s.listener.AcceptUnix()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 4096)
+	oob := make([]byte, syscall.CmsgSpace(4*4))
+	n, oobn, _, _, err := conn.ReadMsgUnix(buf, oob)
+	if err != nil {
+		return nil, err
+	}
+
+	master, err := parseTerminalRequest(buf[:n], oob[:oobn], containerID)
+	response := socket.Response{Common: socket.Common{Type: "success"}}
+	if err != nil {
+		response = socket.Response{Common: socket.Common{Type: "error"}, Message: err.Error()}
+	}
+	// Runtimes are not required to wait for the response, so a failure
+	// to send it is not an error.
+	if data, merr := json.Marshal(response); merr == nil {
+		if verr := validateSocketMessage(schema.SocketResponse, data); verr != nil {
+			if master != nil {
+				master.Close()
+			}
+			return nil, verr
+		}
+		_, _ = conn.Write(data)
+	}
+
+	return master, err
+}
+
+func parseTerminalRequest(data []byte, oob []byte, containerID string) (*os.File, error) {
+	var fds []int
+	messages, err := syscall.ParseSocketControlMessage(oob)
+	if err != nil {
+		return nil, err
+	}
+	for _, message := range messages {
+		rights, err := syscall.ParseUnixRights(&message)
+		if err != nil {
+			continue
+		}
+		fds = append(fds, rights...)
+	}
+	if len(fds) == 0 {
+		return nil, errors.New("terminal request does not pass a file descriptor with SCM_RIGHTS")
+	}
+	for _, fd := range fds[1:] {
+		syscall.Close(fd)
+	}
+	master := os.NewFile(uintptr(fds[0]), "console-master")
+
+	if err := validateSocketMessage(schema.SocketTerminalRequest, data); err != nil {
+		master.Close()
+		return nil, err
+	}
+	var request socket.TerminalRequest
+	if err := json.Unmarshal(data, &request); err != nil {
+		master.Close()
+		return nil, err
+	}
+	if request.Container != containerID {
+		master.Close()
+		return nil, fmt.Errorf("terminal request for container %q, expected %q", request.Container, containerID)
+	}
+
+	return master, nil
+}
+
+// validateSocketMessage validates a console socket message against one of
+// the schemas in the schema package.
+func validateSocketMessage(name string, data []byte) error {
+	schemaData, err := schema.FS.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(schemaData), gojsonschema.NewBytesLoader(data))
+	if err != nil {
+		return fmt.Errorf("invalid console socket message %q: %w", data, err)
+	}
+	if !result.Valid() {
+		var errs []string
+		for _, e := range result.Errors() {
+			errs = append(errs, e.String())
+		}
+		return fmt.Errorf("console socket message %q does not match %s: %s", data, name, strings.Join(errs, "; "))
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package util
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -20,14 +21,25 @@ type Runtime struct {
 	BundleDir      string
 	PidFile        string
 	ID             string
-	stdout         *os.File
-	stderr         *os.File
+	// ConsoleSocket is passed with --console-socket to create, see
+	// ConsoleSocket.
+	ConsoleSocket string
+	// Profile holds the global arguments, environment and wrapper used
+	// for every invocation.  Profile.Command is ignored in favor of
+	// RuntimeCommand.
+	Profile RuntimeProfile
+	// Trace, if set, is called after every invocation of the runtime.
+	Trace  func(Invocation)
+	stdout *os.File
+	stderr *os.File
 }
 
 // DefaultSignal represents the default signal sends to a container
 const DefaultSignal = "TERM"
 
-// NewRuntime create a runtime by command and the bundle directory
+// NewRuntime create a runtime by command and the bundle directory.  The
+// runtime is invoked with DefaultRuntimeProfile and traces its
+// invocations with TraceInvocation.
 func NewRuntime(runtimeCommand string, bundleDir string) (Runtime, error) {
 	var r Runtime
 	var err error
@@ -36,10 +48,66 @@ func NewRuntime(runtimeCommand string, bundleDir string) (Runtime, error) {
 		return Runtime{}, err
 	}
 
+	r.Profile = DefaultRuntimeProfile
+	if len(r.Profile.Wrapper) > 0 {
+		if _, err := exec.LookPath(r.Profile.Wrapper[0]); err != nil {
+			return Runtime{}, err
+		}
+	}
+	r.Trace = TraceInvocation
 	r.BundleDir = bundleDir
 	return r, err
 }
 
+// command builds the command line for a runtime operation from the
+// profile.
+func (r *Runtime) command(args ...string) *exec.Cmd {
+	var argv []string
+	argv = append(argv, r.Profile.Wrapper...)
+	argv = append(argv, r.RuntimeCommand)
+	argv = append(argv, r.Profile.GlobalArgs...)
+	argv = append(argv, args...)
+
+	cmd := exec.Command(argv[0], argv[1:]...)
+	if len(r.Profile.Env) > 0 {
+		cmd.Env = append(os.Environ(), r.Profile.Env...)
+	}
+	return cmd
+}
+
+func (r *Runtime) trace(cmd *exec.Cmd, stdout []byte, stderr []byte, err error) {
+	if r.Trace == nil {
+		return
+	}
+	r.Trace(Invocation{
+		Args:   cmd.Args,
+		Env:    r.Profile.Env,
+		Stdout: stdout,
+		Stderr: stderr,
+		Err:    err,
+	})
+}
+
+// run invokes the runtime and returns its stdout.  If the runtime fails,
+// the returned *exec.ExitError holds its stderr, or its stdout if nothing
+// was written to stderr.
+func (r *Runtime) run(args ...string) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := r.command(args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	r.trace(cmd, stdout.Bytes(), stderr.Bytes(), err)
+	if e, ok := err.(*exec.ExitError); ok {
+		e.Stderr = stderr.Bytes()
+		if len(e.Stderr) == 0 {
+			e.Stderr = stdout.Bytes()
+		}
+	}
+	return stdout.Bytes(), err
+}
+
 // bundleDir returns the bundle directory.  Generally this is
 // BundleDir, but when BundleDir is the empty string, it falls back to
 // ., as specified in the CLI spec.
@@ -70,13 +138,16 @@ func (r *Runtime) Create() (err error) {
 	if r.PidFile != "" {
 		args = append(args, "--pid-file", r.PidFile)
 	}
+	if r.ConsoleSocket != "" {
+		args = append(args, "--console-socket", r.ConsoleSocket)
+	}
 	if r.BundleDir != "" {
 		args = append(args, "--bundle", r.BundleDir)
 	}
 	if r.ID != "" {
 		args = append(args, r.ID)
 	}
-	cmd := exec.Command(r.RuntimeCommand, args...)
+	cmd := r.command(args...)
 	id := uuid.NewString()
 	r.stdout, err = os.OpenFile(filepath.Join(r.bundleDir(), fmt.Sprintf("stdout-%s", id)), os.O_CREATE|os.O_EXCL|os.O_RDWR, 0o600)
 	if err != nil {
@@ -90,12 +161,13 @@ func (r *Runtime) Create() (err error) {
 	cmd.Stderr = r.stderr
 
 	err = cmd.Run()
+	stdout, stderr, _ := r.ReadStandardStreams()
+	r.trace(cmd, stdout, stderr, err)
 	if err == nil {
 		return err
 	}
 
 	if e, ok := err.(*exec.ExitError); ok {
-		stdout, stderr, _ := r.ReadStandardStreams()
 		if len(stderr) == 0 {
 			stderr = stdout
 		}
@@ -123,8 +195,8 @@ func (r *Runtime) Start() (err error) {
 		args = append(args, r.ID)
 	}
 
-	cmd := exec.Command(r.RuntimeCommand, args...)
-	return execWithStderrFallbackToStdout(cmd)
+	_, err = r.run(args...)
+	return err
 }
 
 // State a container information
@@ -135,14 +207,8 @@ func (r *Runtime) State() (rspecs.State, error) {
 		args = append(args, r.ID)
 	}
 
-	out, err := exec.Command(r.RuntimeCommand, args...).Output()
+	out, err := r.run(args...)
 	if err != nil {
-		if e, ok := err.(*exec.ExitError); ok {
-			if len(e.Stderr) == 0 {
-				e.Stderr = out
-				return rspecs.State{}, e
-			}
-		}
 		return rspecs.State{}, err
 	}
 
@@ -169,8 +235,8 @@ func (r *Runtime) Kill(sig string) (err error) {
 		args = append(args, DefaultSignal)
 	}
 
-	cmd := exec.Command(r.RuntimeCommand, args...)
-	return execWithStderrFallbackToStdout(cmd)
+	_, err = r.run(args...)
+	return err
 }
 
 // Delete removes a (stopped) container.
@@ -193,8 +259,8 @@ func (r *Runtime) del(force bool) (err error) {
 		args = append(args, r.ID)
 	}
 
-	cmd := exec.Command(r.RuntimeCommand, args...)
-	return execWithStderrFallbackToStdout(cmd)
+	_, err = r.run(args...)
+	return err
 }
 
 // Clean kills and removes the container and its bundle directory.
@@ -209,18 +275,3 @@ func (r *Runtime) Clean() {
 		fmt.Fprintln(os.Stderr, "Clean: ", err)
 	}
 }
-
-func execWithStderrFallbackToStdout(cmd *exec.Cmd) (err error) {
-	stdout, err := cmd.Output()
-	if err == nil {
-		return err
-	}
-
-	if e, ok := err.(*exec.ExitError); ok {
-		if len(e.Stderr) == 0 {
-			e.Stderr = stdout
-			return e
-		}
-	}
-	return err
-}
@@ -0,0 +1,90 @@
+package util
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/mndrix/tap-go"
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/opencontainers/runtime-tools/resctrl"
+	"github.com/opencontainers/runtime-tools/specerror"
+)
+
+// ValidateLinuxIntelRdt validates linux.intelRdt.
+func ValidateLinuxIntelRdt(config *rspec.Spec, t *tap.T, state *rspec.State) error {
+	rc, err := resctrl.FindResctrl()
+	t.Ok((err == nil), "find resctrl")
+	if err != nil {
+		t.Diagnostic(err.Error())
+		return nil
+	}
+
+	rdt := config.Linux.IntelRdt
+	closID := rdt.ClosID
+	if closID == "" {
+		closID = state.ID
+	}
+
+	tasks, err := rc.GetTasks(closID)
+	t.Ok((err == nil), "get resctrl tasks")
+	if err != nil {
+		t.Diagnostic(err.Error())
+		return nil
+	}
+	SpecErrorOK(t, slices.Contains(tasks, state.Pid), specerror.NewError(specerror.IntelRdtPIDWrite, fmt.Errorf("the runtime MUST write the container process ID to the tasks file of %q", closID), rspec.Version), nil)
+
+	actual, err := rc.GetSchemata(closID)
+	t.Ok((err == nil), "get resctrl schemata")
+	if err != nil {
+		t.Diagnostic(err.Error())
+		return nil
+	}
+
+	checkSchemata := func(field string, schemata string, code specerror.Code) {
+		expected, err := resctrl.ParseSchemata(schemata)
+		if err != nil {
+			t.Fail(fmt.Sprintf("parse linux.intelRdt.%s", field))
+			t.Diagnostic(err.Error())
+			return
+		}
+		for _, schema := range expected {
+			found := false
+			for _, a := range actual {
+				if a.Contains(schema) {
+					found = true
+					break
+				}
+			}
+			if code == specerror.NonError {
+				t.Ok(found, fmt.Sprintf("linux.intelRdt.%s %s is set correctly", field, schema))
+			} else {
+				SpecErrorOK(t, found, specerror.NewError(code, fmt.Errorf("linux.intelRdt.%s %s is set correctly", field, schema), rspec.Version), nil)
+			}
+			t.Diagnosticf("expect: %s, actual: %v", schema, actual)
+		}
+	}
+
+	// schemata overwrites l3CacheSchema and memBwSchema when it is set.
+	if len(rdt.Schemata) > 0 {
+		for i, line := range rdt.Schemata {
+			checkSchemata(fmt.Sprintf("schemata[%d]", i), line, specerror.NonError)
+		}
+	} else {
+		if rdt.L3CacheSchema != "" {
+			checkSchemata("l3CacheSchema", rdt.L3CacheSchema, specerror.IntelRdtL3CacheSchemaWrite)
+		}
+		if rdt.MemBwSchema != "" {
+			checkSchemata("memBwSchema", rdt.MemBwSchema, specerror.NonError)
+		}
+	}
+
+	if rdt.EnableMonitoring {
+		groups, err := rc.GetMonitoringGroups(closID)
+		t.Ok(err == nil && len(groups) > 0, "resctrl monitoring group is created")
+		if err != nil {
+			t.Diagnostic(err.Error())
+		}
+	}
+
+	return nil
+}
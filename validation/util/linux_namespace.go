@@ -13,6 +13,12 @@ var ProcNamespaces = []string{
 	"uts",
 }
 
+// TimeOffsetHostClockEnv prefixes the process.env variables in which the
+// linux_time_offsets test passes the host CLOCK_MONOTONIC and
+// CLOCK_BOOTTIME to runtimetest, in nanoseconds, read before creating the
+// container.
+const TimeOffsetHostClockEnv = "RUNTIMETEST_HOST_CLOCK_"
+
 // GetRuntimeToolsNamespace converts a namespace type string for /proc into
 // a string for runtime-tools. It deals with exceptional cases of "net" and
 // "mnt", because those strings cannot be recognized by mapStrToNamespace(),
@@ -56,6 +56,24 @@ func ValidateLinuxResourcesCPU(config *rspec.Spec, t *tap.T, state *rspec.State)
 	t.Ok(*lcd.Quota == *config.Linux.Resources.CPU.Quota, "cpu quota is set correctly")
 	t.Diagnosticf("expect: %d, actual: %d", *config.Linux.Resources.CPU.Quota, *lcd.Quota)
 
+	if config.Linux.Resources.CPU.Burst != nil {
+		if lcd.Burst == nil {
+			t.Skip(1, "cpu.cfs_burst_us is not supported by the kernel")
+		} else {
+			t.Ok(*lcd.Burst == *config.Linux.Resources.CPU.Burst, "cpu burst is set correctly")
+			t.Diagnosticf("expect: %d, actual: %d", *config.Linux.Resources.CPU.Burst, *lcd.Burst)
+		}
+	}
+
+	if config.Linux.Resources.CPU.Idle != nil {
+		if lcd.Idle == nil {
+			t.Skip(1, "cpu.idle is not supported by the kernel")
+		} else {
+			t.Ok(*lcd.Idle == *config.Linux.Resources.CPU.Idle, "cpu idle is set correctly")
+			t.Diagnosticf("expect: %d, actual: %d", *config.Linux.Resources.CPU.Idle, *lcd.Idle)
+		}
+	}
+
 	t.Ok(lcd.Cpus == config.Linux.Resources.CPU.Cpus, "cpu cpus is set correctly")
 	t.Diagnosticf("expect: %s, actual: %s", config.Linux.Resources.CPU.Cpus, lcd.Cpus)
 
@@ -43,5 +43,10 @@ func ValidateLinuxResourcesMemory(config *rspec.Spec, t *tap.T, state *rspec.Sta
 	t.Ok(*lm.DisableOOMKiller == *config.Linux.Resources.Memory.DisableOOMKiller, "memory oom is set correctly")
 	t.Diagnosticf("expect: %t, actual: %t", *config.Linux.Resources.Memory.DisableOOMKiller, *lm.DisableOOMKiller)
 
+	if config.Linux.Resources.Memory.UseHierarchy != nil {
+		t.Ok(*lm.UseHierarchy == *config.Linux.Resources.Memory.UseHierarchy, "memory useHierarchy is set correctly")
+		t.Diagnosticf("expect: %t, actual: %t", *config.Linux.Resources.Memory.UseHierarchy, *lm.UseHierarchy)
+	}
+
 	return nil
 }
@@ -0,0 +1,48 @@
+package util
+
+import (
+	"fmt"
+
+	"github.com/mndrix/tap-go"
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/opencontainers/runtime-tools/cgroups"
+)
+
+// ValidateLinuxResourcesRdma validates linux.resources.rdma.
+func ValidateLinuxResourcesRdma(config *rspec.Spec, t *tap.T, state *rspec.State) error {
+	cg, err := cgroups.FindCgroup()
+	t.Ok((err == nil), "find rdma cgroup")
+	if err != nil {
+		t.Diagnostic(err.Error())
+		return nil
+	}
+
+	lr, err := cg.GetRdmaData(state.Pid, config.Linux.CgroupsPath)
+	t.Ok((err == nil), "get rdma cgroup data")
+	if err != nil {
+		t.Diagnostic(err.Error())
+		return nil
+	}
+
+	for device, expected := range config.Linux.Resources.Rdma {
+		actual, ok := lr[device]
+		t.Ok(ok, fmt.Sprintf("rdma device %s is limited", device))
+		if !ok {
+			continue
+		}
+		if expected.HcaHandles != nil {
+			t.Ok(actual.HcaHandles != nil && *actual.HcaHandles == *expected.HcaHandles, fmt.Sprintf("rdma %s hcaHandles is set correctly", device))
+			if actual.HcaHandles != nil {
+				t.Diagnosticf("expect: %d, actual: %d", *expected.HcaHandles, *actual.HcaHandles)
+			}
+		}
+		if expected.HcaObjects != nil {
+			t.Ok(actual.HcaObjects != nil && *actual.HcaObjects == *expected.HcaObjects, fmt.Sprintf("rdma %s hcaObjects is set correctly", device))
+			if actual.HcaObjects != nil {
+				t.Diagnosticf("expect: %d, actual: %d", *expected.HcaObjects, *actual.HcaObjects)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+//go:build linux
+
+package util
+
+import (
+	"errors"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// processWatcher reports the exit of a process through a pidfd.  The
+// container process is not our child, so waitid(P_PIDFD) cannot reap it;
+// polling the pidfd still tells us when it exits.
+type processWatcher struct {
+	fd int
+}
+
+func watchProcess(pid int) (*processWatcher, error) {
+	fd, err := unix.PidfdOpen(pid, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &processWatcher{fd: fd}, nil
+}
+
+// Wait waits up to timeout and reports whether the process has exited.
+func (w *processWatcher) Wait(timeout time.Duration) (bool, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		fds := []unix.PollFd{{Fd: int32(w.fd), Events: unix.POLLIN}}
+		ms := int(time.Until(deadline).Milliseconds())
+		if ms < 0 {
+			ms = 0
+		}
+		n, err := unix.Poll(fds, ms)
+		if errors.Is(err, unix.EINTR) {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		return n > 0, nil
+	}
+}
+
+// Close releases the pidfd.
+func (w *processWatcher) Close() error {
+	return unix.Close(w.fd)
+}
@@ -0,0 +1,22 @@
+//go:build !linux
+
+package util
+
+import (
+	"errors"
+	"time"
+)
+
+type processWatcher struct{}
+
+func watchProcess(pid int) (*processWatcher, error) {
+	return nil, errors.New("watching processes is not supported on this platform")
+}
+
+func (w *processWatcher) Wait(timeout time.Duration) (bool, error) {
+	return false, errors.New("watching processes is not supported on this platform")
+}
+
+func (w *processWatcher) Close() error {
+	return nil
+}
@@ -0,0 +1,101 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mndrix/tap-go"
+)
+
+// RuntimeProfile describes how to invoke the runtime under test.
+//
+// A profile can be loaded from the JSON file named by the RUNTIME_PROFILE
+// environment variable, for example:
+//
+//	{
+//		"command": "runc",
+//		"globalArgs": ["--root", "/run/runc-test", "--systemd-cgroup"],
+//		"env": ["XDG_RUNTIME_DIR=/run/user/1000"],
+//		"wrapper": ["sudo", "-E"]
+//	}
+//
+// The RUNTIME environment variable, when set, overrides the command.
+type RuntimeProfile struct {
+	// Command is the runtime command.
+	Command string `json:"command,omitempty"`
+	// GlobalArgs are passed to the runtime before the operation, e.g.
+	// --root, --log or --debug.
+	GlobalArgs []string `json:"globalArgs,omitempty"`
+	// Env holds KEY=VALUE pairs added to the environment of the runtime.
+	Env []string `json:"env,omitempty"`
+	// Wrapper is prepended to the runtime command line, e.g. sudo,
+	// unshare or rootlesskit with their own arguments.
+	Wrapper []string `json:"wrapper,omitempty"`
+}
+
+// DefaultRuntimeProfile is the profile used by NewRuntime.
+var DefaultRuntimeProfile = RuntimeProfile{Command: RuntimeCommand}
+
+// LoadRuntimeProfile reads a runtime profile from a JSON file.
+func LoadRuntimeProfile(path string) (RuntimeProfile, error) {
+	var profile RuntimeProfile
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return profile, err
+	}
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return profile, fmt.Errorf("invalid runtime profile %s: %w", path, err)
+	}
+	for _, env := range profile.Env {
+		if !strings.Contains(env, "=") {
+			return profile, fmt.Errorf("invalid runtime profile %s: env %q is not KEY=VALUE", path, env)
+		}
+	}
+	return profile, nil
+}
+
+// Invocation records a single call of the runtime.
+type Invocation struct {
+	// Args is the full command line, including the wrapper.
+	Args []string
+	// Env is the environment added by the profile.
+	Env    []string
+	Stdout []byte
+	Stderr []byte
+	Err    error
+}
+
+// TraceInvocation writes an invocation as TAP diagnostics to stdout,
+// so that failing runtime calls can be reproduced by hand.
+func TraceInvocation(inv Invocation) {
+	t := tap.New()
+	line := quoteArgs(inv.Args)
+	if len(inv.Env) > 0 {
+		line = quoteArgs(append([]string{"env"}, inv.Env...)) + " " + line
+	}
+	t.Diagnostic("runtime: " + line)
+	if inv.Err != nil {
+		t.Diagnostic("runtime error: " + inv.Err.Error())
+	}
+	if len(inv.Stdout) > 0 {
+		t.Diagnostic("runtime stdout: " + string(inv.Stdout))
+	}
+	if len(inv.Stderr) > 0 {
+		t.Diagnostic("runtime stderr: " + string(inv.Stderr))
+	}
+}
+
+// quoteArgs formats args as a POSIX shell command line.
+func quoteArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n\"'\\$`*?[]{}()<>|&;#~!") {
+			quoted[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		} else {
+			quoted[i] = arg
+		}
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,46 @@
+package util
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Test is a validation test that has been registered with RegisterTest.
+type Test struct {
+	// Name is the name of the test, which is the name of the directory
+	// under validation/ holding it.
+	Name string
+	// Run runs the test and writes TAP to stdout.  It may call os.Exit
+	// (e.g. through Fatal), so callers should run it in its own process.
+	Run func()
+}
+
+var registeredTests = map[string]Test{}
+
+// RegisterTest registers a validation test so it can be run by the
+// validation suite.  It panics if a test with the same name has already
+// been registered.
+func RegisterTest(name string, run func()) {
+	if _, ok := registeredTests[name]; ok {
+		panic(fmt.Sprintf("validation test %q is already registered", name))
+	}
+	registeredTests[name] = Test{Name: name, Run: run}
+}
+
+// RegisteredTests returns all registered validation tests sorted by name.
+func RegisteredTests() []Test {
+	tests := make([]Test, 0, len(registeredTests))
+	for _, test := range registeredTests {
+		tests = append(tests, test)
+	}
+	sort.Slice(tests, func(i, j int) bool {
+		return tests[i].Name < tests[j].Name
+	})
+	return tests
+}
+
+// LookupTest returns the registered validation test with the given name.
+func LookupTest(name string) (Test, bool) {
+	test, ok := registeredTests[name]
+	return test, ok
+}
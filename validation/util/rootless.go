@@ -0,0 +1,32 @@
+package util
+
+// Rootless is true when the runtime under test runs without root
+// privileges.  It is read from the RUNTIME_TOOLS_ROOTLESS environment
+// variable.  It is not guessed from the user running the tests, since a
+// runtime profile may wrap the runtime with sudo.
+var Rootless bool
+
+// SkipRootless skips the whole test in rootless mode, for tests which need
+// real root on the host.  It reports whether the test was skipped.
+func SkipRootless(reason string) bool {
+	if !Rootless {
+		return false
+	}
+	Skip("needs root: "+reason, nil)
+	return true
+}
+
+// SkipRootlessCgroups skips the whole test in rootless mode.  Rootless
+// runtimes can only use the cgroup v2 subtree that systemd delegates to
+// the user (user@<uid>.service), and the cgroups package only reads
+// cgroup v1, so rootless cgroup tests are always skipped.  It reports
+// whether the test was skipped.
+func SkipRootlessCgroups() bool {
+	if !Rootless {
+		return false
+	}
+	Skip("cgroups are not available in rootless mode", map[string]string{
+		"reason": "the cgroups package does not read cgroup v2 yet",
+	})
+	return true
+}
@@ -0,0 +1,110 @@
+package util
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// TAP directives.
+const (
+	TAPDirectiveSkip = "SKIP"
+	TAPDirectiveTODO = "TODO"
+)
+
+// TAPResult is a single test point of a TAP stream.
+type TAPResult struct {
+	Number      int    `json:"number"`
+	Ok          bool   `json:"ok"`
+	Description string `json:"description,omitempty"`
+	Directive   string `json:"directive,omitempty"`
+	Diagnostic  string `json:"diagnostic,omitempty"`
+	// Code is the spec error code from the YAML diagnostics, if any.
+	Code string `json:"code,omitempty"`
+
+	// Line is the index of the test point in the TAP stream.
+	Line int `json:"-"`
+}
+
+var (
+	testPointRegexp = regexp.MustCompile(`^(not )?ok\b\s*(\d*)\s*(?:- )?(.*)$`)
+	directiveRegexp = regexp.MustCompile(`(?i)^(.*?)\s*# (SKIP|TODO)\S*\s*(.*)$`)
+	codeRegexp      = regexp.MustCompile(`^\s*"?code"?\s*:\s*"?(0x[0-9a-fA-F]+)"?,?\s*$`)
+)
+
+// ParseTAP parses the test points of the TAP stream in r.  Diagnostics and
+// YAML blocks are attached to the test point they follow.  A stream may
+// contain several TAP documents, since some validation tests run more
+// than one.
+func ParseTAP(r io.Reader) []TAPResult {
+	var results []TAPResult
+	inYAML := false
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 1024*1024)
+	for index := 0; scanner.Scan(); index++ {
+		line := scanner.Text()
+
+		if inYAML {
+			if strings.TrimSpace(line) == "..." {
+				inYAML = false
+			} else {
+				addDiagnostic(results, strings.TrimPrefix(line, "  "))
+				if match := codeRegexp.FindStringSubmatch(line); match != nil && len(results) > 0 {
+					results[len(results)-1].Code = match[1]
+				}
+			}
+			continue
+		}
+		if strings.TrimSpace(line) == "---" {
+			inYAML = true
+			continue
+		}
+		if diagnostic, ok := strings.CutPrefix(line, "#"); ok {
+			addDiagnostic(results, strings.TrimSpace(diagnostic))
+			continue
+		}
+
+		match := testPointRegexp.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		result := TAPResult{Ok: match[1] == "", Line: index}
+		result.Number, _ = strconv.Atoi(match[2])
+		result.Description = strings.TrimSpace(match[3])
+		if match := directiveRegexp.FindStringSubmatch(result.Description); match != nil {
+			result.Directive = strings.ToUpper(match[2])
+			result.Description = strings.TrimSpace(match[1] + " " + match[3])
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func addDiagnostic(results []TAPResult, line string) {
+	if len(results) == 0 {
+		return
+	}
+	last := &results[len(results)-1]
+	if last.Diagnostic != "" {
+		last.Diagnostic += "\n"
+	}
+	last.Diagnostic += line
+}
+
+// TAPPassed reports whether results has test points and none of them
+// failed, except for TODO ones.
+func TAPPassed(results []TAPResult) bool {
+	if len(results) == 0 {
+		return false
+	}
+	for _, result := range results {
+		if !result.Ok && result.Directive != TAPDirectiveTODO {
+			return false
+		}
+	}
+	return true
+}
@@ -7,6 +7,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -79,9 +80,36 @@ type PreFunc func(string) error
 type AfterFunc func(config *rspec.Spec, t *tap.T, state *rspec.State) error
 
 func init() {
+	if path := os.Getenv("RUNTIME_PROFILE"); path != "" {
+		profile, err := LoadRuntimeProfile(path)
+		if err != nil {
+			Fatal(err)
+		}
+		DefaultRuntimeProfile = profile
+	}
 	runtimeInEnv := os.Getenv("RUNTIME")
 	if runtimeInEnv != "" {
-		RuntimeCommand = runtimeInEnv
+		DefaultRuntimeProfile.Command = runtimeInEnv
+	}
+	if DefaultRuntimeProfile.Command == "" {
+		DefaultRuntimeProfile.Command = RuntimeCommand
+	}
+	RuntimeCommand = DefaultRuntimeProfile.Command
+
+	if value := os.Getenv("RUNTIME_TOOLS_TIMEOUT_SCALE"); value != "" {
+		scale, err := parseTimeoutScale(value)
+		if err != nil {
+			Fatal(err)
+		}
+		TimeoutScale = scale
+	}
+
+	if value := os.Getenv("RUNTIME_TOOLS_ROOTLESS"); value != "" {
+		rootless, err := strconv.ParseBool(value)
+		if err != nil {
+			Fatal(fmt.Errorf("invalid RUNTIME_TOOLS_ROOTLESS %q: %w", value, err))
+		}
+		Rootless = rootless
 	}
 }
 
@@ -106,6 +134,7 @@ func SpecErrorOK(t *tap.T, expected bool, specErr error, detailedErr error) {
 	t.Ok(expected, specErr.(*specerror.Error).Err.Err.Error())
 	diagnostic := map[string]string{
 		"reference": specErr.(*specerror.Error).Err.Reference,
+		"code":      specErr.(*specerror.Error).Code.String(),
 	}
 
 	if detailedErr != nil {
@@ -146,29 +175,24 @@ func GetDefaultGenerator() (*generate.Generator, error) {
 	}
 	g.SetRootPath(".")
 	g.SetProcessArgs([]string{"/runtimetest", "--path=/"})
+	if err := SetupRootless(&g); err != nil {
+		return nil, err
+	}
 	return &g, err
 }
 
-// WaitingForStatus waits an expected runtime status, return error if
-// 1. fail to query the status
-// 2. timeout
-func WaitingForStatus(r Runtime, status LifecycleStatus, retryTimeout time.Duration, pollInterval time.Duration) error {
-	for start := time.Now(); time.Since(start) < retryTimeout; time.Sleep(pollInterval) {
-		state, err := r.State()
-		if err != nil {
-			return err
-		}
-		if v, ok := lifecycleStatusMap[state.Status]; ok {
-			if status&v != 0 {
-				return nil
-			}
-		} else {
-			// In spec, it says 'Additional values MAY be defined by the runtime'.
-			continue
-		}
+// SetupRootless sets up a user namespace with the rootless ID mappings in
+// rootless mode, and does nothing otherwise.
+func SetupRootless(g *generate.Generator) error {
+	if !Rootless {
+		return nil
 	}
-
-	return errors.New("timeout in waiting for the container status")
+	uidMappings, gidMappings, err := generate.RootlessIDMappings()
+	if err != nil {
+		return err
+	}
+	g.SetupRootless(uidMappings, gidMappings)
+	return nil
 }
 
 var runtimeInsideValidateCalled bool
@@ -191,6 +215,17 @@ func RuntimeInsideValidate(g *generate.Generator, t *tap.T, f PreFunc) (err erro
 		os.RemoveAll(bundleDir)
 		return err
 	}
+	// The TAP written below comes from runtimetest, so hold the runtime
+	// invocations back until it has been written.
+	var invocations []Invocation
+	r.Trace = func(inv Invocation) {
+		invocations = append(invocations, inv)
+	}
+	defer func() {
+		for _, inv := range invocations {
+			TraceInvocation(inv)
+		}
+	}()
 	defer r.Clean()
 	err = r.SetConfig(g)
 	if err != nil {
@@ -0,0 +1,159 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	rspec "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// TimeoutScale multiplies the timeouts used by the validation tests.  It
+// is read from the RUNTIME_TOOLS_TIMEOUT_SCALE environment variable, so
+// that slow or loaded machines can allow more time without editing tests.
+var TimeoutScale = 1.0
+
+// minPollInterval is the first interval of the polling backoff.
+const minPollInterval = 10 * time.Millisecond
+
+// ScaleTimeout applies TimeoutScale to a timeout.
+func ScaleTimeout(timeout time.Duration) time.Duration {
+	return time.Duration(float64(timeout) * TimeoutScale)
+}
+
+func parseTimeoutScale(value string) (float64, error) {
+	scale, err := strconv.ParseFloat(value, 64)
+	if err != nil || scale <= 0 {
+		return 0, fmt.Errorf("invalid RUNTIME_TOOLS_TIMEOUT_SCALE %q: must be a positive number", value)
+	}
+	return scale, nil
+}
+
+// String returns the state names of the statuses in s.
+func (s LifecycleStatus) String() string {
+	var names []string
+	for _, state := range []rspec.ContainerState{rspec.StateCreating, rspec.StateCreated, rspec.StateRunning, rspec.StateStopped} {
+		if s&lifecycleStatusMap[state] != 0 {
+			names = append(names, string(state))
+		}
+	}
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, "|")
+}
+
+type statusChange struct {
+	elapsed time.Duration
+	status  rspec.ContainerState
+}
+
+// statusHistory records the statuses seen while waiting, for diagnostics.
+type statusHistory struct {
+	changes []statusChange
+	queries int
+}
+
+func (h *statusHistory) add(elapsed time.Duration, status rspec.ContainerState) {
+	h.queries++
+	if len(h.changes) > 0 && h.changes[len(h.changes)-1].status == status {
+		return
+	}
+	h.changes = append(h.changes, statusChange{elapsed, status})
+}
+
+func (h *statusHistory) String() string {
+	changes := make([]string, len(h.changes))
+	for i, change := range h.changes {
+		changes[i] = fmt.Sprintf("%s at %s", change.status, change.elapsed.Round(time.Millisecond))
+	}
+	return fmt.Sprintf("%s (%d state queries)", strings.Join(changes, ", "), h.queries)
+}
+
+// WaitingForStatus waits an expected runtime status, return error if
+// 1. fail to query the status
+// 2. timeout
+//
+// retryTimeout is scaled by TimeoutScale.  The state is queried with an
+// exponential backoff starting at 10ms and capped at pollInterval.  When
+// waiting for "stopped", the exit of the container process is watched with
+// a pidfd where available, so the state is queried as soon as it exits.
+// On timeout, the error includes the history of the statuses seen.
+func WaitingForStatus(r Runtime, status LifecycleStatus, retryTimeout time.Duration, pollInterval time.Duration) error {
+	retryTimeout = ScaleTimeout(retryTimeout)
+	if pollInterval < minPollInterval {
+		pollInterval = minPollInterval
+	}
+
+	var history statusHistory
+	var watcher *processWatcher
+	defer func() {
+		if watcher != nil {
+			watcher.Close()
+		}
+	}()
+	watched := false
+	interval := minPollInterval
+	start := time.Now()
+	for {
+		state, err := r.State()
+		if err != nil {
+			return err
+		}
+		history.add(time.Since(start), state.Status)
+		// In spec, it says 'Additional values MAY be defined by the runtime'.
+		if v, ok := lifecycleStatusMap[state.Status]; ok && status&v != 0 {
+			return nil
+		}
+
+		remaining := retryTimeout - time.Since(start)
+		if remaining <= 0 {
+			return fmt.Errorf("timeout after %s waiting for the container status %s; status history: %s", retryTimeout, status, &history)
+		}
+
+		if !watched && status&LifecycleStatusStopped != 0 && (state.Status == rspec.StateCreated || state.Status == rspec.StateRunning) {
+			watched = true
+			if pid := containerPid(r, state); pid > 0 {
+				// Without pidfd support, fall back to polling.
+				watcher, _ = watchProcess(pid)
+			}
+		}
+
+		delay := min(interval, remaining)
+		if watcher == nil {
+			time.Sleep(delay)
+			interval = min(2*interval, pollInterval)
+			continue
+		}
+		if status == LifecycleStatusStopped {
+			// Nothing but the exit of the process is expected.
+			delay = remaining
+		}
+		exited, err := watcher.Wait(delay)
+		if err != nil || exited {
+			// The runtime may take a moment to notice the exit, so
+			// restart the backoff and poll from here on.
+			watcher.Close()
+			watcher = nil
+			interval = minPollInterval
+			continue
+		}
+		interval = min(2*interval, pollInterval)
+	}
+}
+
+// containerPid returns the container process ID from the runtime's pid
+// file when there is one, and from the state otherwise.
+func containerPid(r Runtime, state rspec.State) int {
+	if r.PidFile != "" {
+		data, err := os.ReadFile(r.PidFile)
+		if err == nil {
+			if pid, err := strconv.Atoi(strings.TrimSpace(string(data))); err == nil {
+				return pid
+			}
+		}
+	}
+	return state.Pid
+}